@@ -24,6 +24,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/kelseyhightower/envconfig"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	configmap "knative.dev/pkg/configmap/informer"
 	"knative.dev/pkg/controller"
@@ -42,6 +44,7 @@ import (
 	eventingclientset "knative.dev/eventing/pkg/client/clientset/versioned"
 	eventinginformers "knative.dev/eventing/pkg/client/informers/externalversions"
 	"knative.dev/eventing/pkg/reconciler/names"
+	"knative.dev/eventing/pkg/utils"
 )
 
 const (
@@ -103,6 +106,9 @@ func main() {
 	// TODO change the component name to broker once Stackdriver metrics are approved.
 	// Watch the observability config map and dynamically update request logs.
 	configMapWatcher.Watch(logging.ConfigMapName(), logging.UpdateLevelFromConfigMap(sl, atomicLevel, component))
+	// Watch the header pass-through policy and dynamically update the forwarded and proxied headers.
+	configMapWatcher.WatchWithDefault(corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: utils.HeadersConfigName}},
+		utils.UpdateHeadersPolicyFromConfigMap(sl))
 
 	featureStore := feature.NewStore(logging.FromContext(ctx).Named("feature-config-store"))
 	featureStore.WatchConfigs(configMapWatcher)
@@ -122,7 +128,7 @@ func main() {
 
 	// We are running both the receiver (takes messages in from the Broker) and the dispatcher (send
 	// the messages to the triggers' subscribers) in this binary.
-	handler, err := filter.NewHandler(logger, triggerInformer.Lister(), reporter, env.Port, ctxFunc, env.IgnoreResponseBody)
+	handler, err := filter.NewHandler(logger, triggerInformer, reporter, env.Port, ctxFunc, env.IgnoreResponseBody)
 	if err != nil {
 		logger.Fatal("Error creating Handler", zap.Error(err))
 	}
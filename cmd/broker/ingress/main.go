@@ -27,6 +27,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/kelseyhightower/envconfig"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeinformers "k8s.io/client-go/informers"
 
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	configmap "knative.dev/pkg/configmap/informer"
@@ -41,11 +44,14 @@ import (
 	tracingconfig "knative.dev/pkg/tracing/config"
 
 	cmdbroker "knative.dev/eventing/cmd/broker"
+	"knative.dev/eventing/pkg/apis/eventing"
 	broker "knative.dev/eventing/pkg/broker"
 	"knative.dev/eventing/pkg/broker/ingress"
 	brokerinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/broker"
+	eventtypeinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1beta1/eventtype"
 	"knative.dev/eventing/pkg/kncloudevents"
 	"knative.dev/eventing/pkg/reconciler/names"
+	"knative.dev/eventing/pkg/utils"
 )
 
 // TODO make these constants configurable (either as env variables, config map, or part of broker spec).
@@ -104,6 +110,13 @@ func main() {
 	logger.Info("Starting the Broker Ingress")
 
 	brokerLister := brokerinformer.Get(ctx).Lister()
+	eventTypeLister := eventtypeinformer.Get(ctx).Lister()
+	// Only watch the ConfigMaps holding the Broker ingress settings, not all the ConfigMaps of the cluster.
+	configMapInformer := kubeinformers.NewSharedInformerFactoryWithOptions(kubeclient.Get(ctx), controller.GetResyncPeriod(ctx),
+		kubeinformers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.LabelSelector = eventing.BrokerIngressConfigLabelKey + "=true"
+		})).Core().V1().ConfigMaps()
+	configMapLister := configMapInformer.Lister()
 
 	// Watch the logging config map and dynamically update logging levels.
 	configMapWatcher := configmap.NewInformedWatcher(kubeclient.Get(ctx), system.Namespace())
@@ -119,6 +132,9 @@ func main() {
 	// TODO change the component name to broker once Stackdriver metrics are approved.
 	// Watch the observability config map and dynamically update request logs.
 	configMapWatcher.Watch(logging.ConfigMapName(), logging.UpdateLevelFromConfigMap(sl, atomicLevel, component))
+	// Watch the header pass-through policy and dynamically update the forwarded and proxied headers.
+	configMapWatcher.WatchWithDefault(corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: utils.HeadersConfigName}},
+		utils.UpdateHeadersPolicyFromConfigMap(sl))
 
 	bin := fmt.Sprintf("%s.%s", names.BrokerIngressName, system.Namespace())
 	tracer, err := tracing.SetupPublishingWithDynamicConfig(sl, configMapWatcher, bin, tracingconfig.ConfigName)
@@ -139,13 +155,17 @@ func main() {
 	reporter := ingress.NewStatsReporter(env.ContainerName, kmeta.ChildName(env.PodName, uuid.New().String()))
 
 	h := &ingress.Handler{
-		Receiver:     kncloudevents.NewHTTPMessageReceiver(env.Port),
-		Sender:       sender,
-		Defaulter:    broker.TTLDefaulter(logger, int32(env.MaxTTL)),
-		Reporter:     reporter,
-		Logger:       logger,
-		BrokerLister: brokerLister,
+		Receiver:        kncloudevents.NewHTTPMessageReceiver(env.Port),
+		Sender:          sender,
+		Defaulter:       broker.TTLDefaulter(logger, int32(env.MaxTTL)),
+		Reporter:        reporter,
+		Logger:          logger,
+		BrokerLister:    brokerLister,
+		ConfigMapLister: configMapLister,
+		EventTypeLister: eventTypeLister,
 	}
+	// Evict the cached EventTypes of the Brokers and the compiled schemas when the EventTypes change.
+	eventtypeinformer.Get(ctx).Informer().AddEventHandler(h.EventTypeEventHandler())
 
 	// configMapWatcher does not block, so start it first.
 	if err = configMapWatcher.Start(ctx.Done()); err != nil {
@@ -154,7 +174,7 @@ func main() {
 
 	// Start all of the informers and wait for them to sync.
 	logger.Info("Starting informers.")
-	if err := controller.StartInformers(ctx.Done(), informers...); err != nil {
+	if err := controller.StartInformers(ctx.Done(), append(informers, configMapInformer.Informer())...); err != nil {
 		logger.Fatal("Failed to start informers", zap.Error(err))
 	}
 
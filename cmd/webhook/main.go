@@ -54,6 +54,7 @@ import (
 	sourcesv1beta2 "knative.dev/eventing/pkg/apis/sources/v1beta2"
 	sugar "knative.dev/eventing/pkg/apis/sugar"
 	"knative.dev/eventing/pkg/reconciler/sinkbinding"
+	"knative.dev/eventing/pkg/utils"
 
 	versionedscheme "knative.dev/eventing/pkg/client/clientset/versioned/scheme"
 )
@@ -184,6 +185,7 @@ func NewConfigValidationController(ctx context.Context, _ configmap.Watcher) *co
 			logging.ConfigMapName():        logging.NewConfigFromConfigMap,
 			leaderelection.ConfigMapName(): leaderelection.NewConfigFromConfigMap,
 			sugar.ConfigName:               sugar.NewConfigFromConfigMap,
+			utils.HeadersConfigName:        utils.NewHeadersPolicyFromConfigMap,
 		},
 	)
 }
@@ -26,6 +26,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
@@ -33,6 +34,8 @@ import (
 
 	"knative.dev/eventing/pkg/adapter/v2"
 	v1 "knative.dev/eventing/pkg/apis/sources/v1"
+	"knative.dev/eventing/pkg/eventfilter"
+	"knative.dev/eventing/pkg/eventfilter/subscriptionsapi"
 )
 
 type envConfig struct {
@@ -64,12 +67,31 @@ func (a *apiServerAdapter) start(ctx context.Context, stopCh <-chan struct{}) er
 
 	resyncPeriod := 10 * time.Hour
 
+	delivery, err := adapter.NewDelivery(a.config.Delivery, a.config.DeadLetterSinkURI)
+	if err != nil {
+		a.logger.Errorw("Could not read the delivery, sending the events without retries", zap.Error(err))
+	}
+
+	var filter eventfilter.Filter
+	if len(a.config.Filters) > 0 {
+		filter = subscriptionsapi.CreateSubscriptionsAPIFilters(ctx, a.config.Filters)
+	}
+
 	var delegate cache.Store = &resourceDelegate{
 		ce:                  a.ce,
 		source:              a.source,
 		logger:              a.logger,
 		ref:                 a.config.EventMode == v1.ReferenceMode,
 		apiServerSourceName: a.name,
+		delivery:            delivery,
+		filter:              filter,
+	}
+	if a.config.OnlyStatusChanges {
+		a.logger.Info("only the status changes will be sent")
+		delegate = &statusChangeFilter{
+			statuses: make(map[types.UID]uint64),
+			delegate: delegate,
+		}
 	}
 	if a.config.ResourceOwner != nil {
 		a.logger.Infow("will be filtered",
@@ -106,8 +128,8 @@ func (a *apiServerAdapter) start(ctx context.Context, stopCh <-chan struct{}) er
 
 				for _, res := range resources {
 					lw := &cache.ListWatch{
-						ListFunc:  asUnstructuredLister(ctx, res.List, configRes.LabelSelector),
-						WatchFunc: asUnstructuredWatcher(ctx, res.Watch, configRes.LabelSelector),
+						ListFunc:  asUnstructuredLister(ctx, res.List, configRes.LabelSelector, configRes.FieldSelector),
+						WatchFunc: asUnstructuredWatcher(ctx, res.Watch, configRes.LabelSelector, configRes.FieldSelector),
 					}
 
 					reflector := cache.NewReflector(lw, &unstructured.Unstructured{}, delegate, resyncPeriod)
@@ -143,11 +165,14 @@ func (a *apiServerAdapter) start(ctx context.Context, stopCh <-chan struct{}) er
 
 type unstructuredLister func(context.Context, metav1.ListOptions) (*unstructured.UnstructuredList, error)
 
-func asUnstructuredLister(ctx context.Context, ulist unstructuredLister, selector string) cache.ListFunc {
+func asUnstructuredLister(ctx context.Context, ulist unstructuredLister, selector, fieldSelector string) cache.ListFunc {
 	return func(opts metav1.ListOptions) (runtime.Object, error) {
 		if selector != "" && opts.LabelSelector == "" {
 			opts.LabelSelector = selector
 		}
+		if fieldSelector != "" && opts.FieldSelector == "" {
+			opts.FieldSelector = fieldSelector
+		}
 		ul, err := ulist(ctx, opts)
 		if err != nil {
 			return nil, err
@@ -158,11 +183,14 @@ func asUnstructuredLister(ctx context.Context, ulist unstructuredLister, selecto
 
 type structuredWatcher func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
 
-func asUnstructuredWatcher(ctx context.Context, wf structuredWatcher, selector string) cache.WatchFunc {
+func asUnstructuredWatcher(ctx context.Context, wf structuredWatcher, selector, fieldSelector string) cache.WatchFunc {
 	return func(lo metav1.ListOptions) (watch.Interface, error) {
 		if selector != "" && lo.LabelSelector == "" {
 			lo.LabelSelector = selector
 		}
+		if fieldSelector != "" && lo.FieldSelector == "" {
+			lo.FieldSelector = fieldSelector
+		}
 		return wf(ctx, lo)
 	}
 }
@@ -28,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/discovery"
 	discoveryfake "k8s.io/client-go/discovery/fake"
 	"k8s.io/client-go/dynamic"
@@ -186,6 +187,33 @@ func TestAdapter_StartNonNamespacedResource(t *testing.T) {
 // Common methods:
 
 // GetDynamicClient returns the mockDynamicClient to use for this test case.
+func TestAdapter_Selectors(t *testing.T) {
+	var listed, watched metav1.ListOptions
+	lister := asUnstructuredLister(context.Background(), func(_ context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+		listed = opts
+		return &unstructured.UnstructuredList{}, nil
+	}, "app=test", "status.phase=Running")
+	watcher := asUnstructuredWatcher(context.Background(), func(_ context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+		watched = opts
+		return watch.NewEmptyWatch(), nil
+	}, "app=test", "status.phase=Running")
+
+	if _, err := lister(metav1.ListOptions{}); err != nil {
+		t.Fatal("List() =", err)
+	}
+	if _, err := watcher(metav1.ListOptions{}); err != nil {
+		t.Fatal("Watch() =", err)
+	}
+	for _, opts := range []metav1.ListOptions{listed, watched} {
+		if opts.LabelSelector != "app=test" {
+			t.Errorf("LabelSelector = %q, want app=test", opts.LabelSelector)
+		}
+		if opts.FieldSelector != "status.phase=Running" {
+			t.Errorf("FieldSelector = %q, want status.phase=Running", opts.FieldSelector)
+		}
+	}
+}
+
 func makeDynamicClient(objects ...runtime.Object) dynamic.Interface {
 	sc := runtime.NewScheme()
 	_ = corev1.AddToScheme(sc)
@@ -17,6 +17,9 @@ package apiserver
 
 import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	v1 "knative.dev/eventing/pkg/apis/sources/v1"
 )
 
@@ -28,6 +31,11 @@ type ResourceWatch struct {
 	// label selector.
 	// +optional
 	LabelSelector string `json:"selector,omitempty"`
+
+	// FieldSelector filters this source to the resources matching the field
+	// selector, the resources are filtered by the API server.
+	// +optional
+	FieldSelector string `json:"fieldSelector,omitempty"`
 }
 
 type Config struct {
@@ -56,4 +64,21 @@ type Config struct {
 	// Defaults to `Reference`
 	// +optional
 	EventMode string `json:"mode,omitempty"`
+	// Delivery contains the retries and the dead letter sink of the events
+	// sent to the sink.
+	// +optional
+	Delivery *eventingduckv1.DeliverySpec `json:"delivery,omitempty"`
+
+	// DeadLetterSinkURI is the resolved URI of the dead letter sink of Delivery.
+	// +optional
+	DeadLetterSinkURI *apis.URL `json:"deadLetterSinkUri,omitempty"`
+
+	// Filters are the filters the events must pass to be sent to the sink.
+	// +optional
+	Filters []eventingduckv1.SubscriptionsAPIFilter `json:"filters,omitempty"`
+
+	// OnlyStatusChanges sends the update events of the resources only when their
+	// status changed.
+	// +optional
+	OnlyStatusChanges bool `json:"onlyStatusChanges,omitempty"`
 }
@@ -23,7 +23,10 @@ import (
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"k8s.io/client-go/tools/cache"
+
 	"knative.dev/eventing/pkg/adapter/apiserver/events"
+	"knative.dev/eventing/pkg/adapter/v2"
+	"knative.dev/eventing/pkg/eventfilter"
 )
 
 type resourceDelegate struct {
@@ -31,6 +34,10 @@ type resourceDelegate struct {
 	source              string
 	ref                 bool
 	apiServerSourceName string
+	// delivery holds the retries and the dead letter sink of the events, nil for the defaults.
+	delivery *adapter.Delivery
+	// filter drops the events not passing the filters of the source, nil when there are no filters.
+	filter eventfilter.Filter
 
 	logger *zap.SugaredLogger
 }
@@ -70,11 +77,18 @@ func (a *resourceDelegate) Delete(obj interface{}) error {
 // sendCloudEvent sends a cloudevent everytime k8s api event is created, updated or deleted.
 func (a *resourceDelegate) sendCloudEvent(ctx context.Context, event cloudevents.Event) {
 	event.SetID(uuid.New().String()) // provide an ID here so we can track it with logging
-	defer a.logger.Debug("Finished sending cloudevent id: ", event.ID())
 	source := event.Context.GetSource()
 	subject := event.Context.GetSubject()
+	if a.filter != nil && a.filter.Filter(ctx, event) == eventfilter.FailFilter {
+		a.logger.Debugf("cloudevent filtered id: %s, source: %s, subject: %s", event.ID(), source, subject)
+		return
+	}
+	defer a.logger.Debug("Finished sending cloudevent id: ", event.ID())
 	a.logger.Debugf("sending cloudevent id: %s, source: %s, subject: %s", event.ID(), source, subject)
 
+	if a.delivery != nil {
+		ctx = adapter.ContextWithDelivery(ctx, a.delivery)
+	}
 	if result := a.ce.Send(ctx, event); !cloudevents.IsACK(result) {
 		a.logger.Errorw("failed to send cloudevent", zap.Error(result), zap.String("source", source),
 			zap.String("subject", subject), zap.String("id", event.ID()))
@@ -16,9 +16,17 @@ limitations under the License.
 package apiserver
 
 import (
+	"context"
 	"testing"
 
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+
+	"knative.dev/eventing/pkg/adapter/v2"
+	adaptertest "knative.dev/eventing/pkg/adapter/v2/test"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/apis/sources"
+	"knative.dev/eventing/pkg/eventfilter/subscriptionsapi"
 )
 
 func TestResourceAddEvent(t *testing.T) {
@@ -57,6 +65,40 @@ func TestResourceDeleteEventNil(t *testing.T) {
 	validateNotSent(t, ce, sources.ApiServerSourceDeleteEventType)
 }
 
+// deliveryClient records the delivery of the events sent.
+type deliveryClient struct {
+	*adaptertest.TestCloudEventsClient
+	delivery *adapter.Delivery
+}
+
+func (c *deliveryClient) Send(ctx context.Context, out cloudevents.Event) protocol.Result {
+	c.delivery = adapter.DeliveryFromContext(ctx)
+	return c.TestCloudEventsClient.Send(ctx, out)
+}
+
+func TestResourceAddEventWithDelivery(t *testing.T) {
+	d, ce := makeResourceAndTestingClient()
+	dc := &deliveryClient{TestCloudEventsClient: ce}
+	d.ce = dc
+	d.delivery = &adapter.Delivery{}
+	d.Add(simplePod("unit", "test"))
+	validateSent(t, ce, sources.ApiServerSourceAddEventType)
+	if dc.delivery != d.delivery {
+		t.Errorf("delivery = %v, want %v", dc.delivery, d.delivery)
+	}
+}
+
+func TestResourceEventsWithFilters(t *testing.T) {
+	d, ce := makeResourceAndTestingClient()
+	d.filter = subscriptionsapi.CreateSubscriptionsAPIFilters(context.Background(), []eventingduckv1.SubscriptionsAPIFilter{{
+		Exact: map[string]string{"type": sources.ApiServerSourceUpdateEventType},
+	}})
+	d.Add(simplePod("unit", "test"))
+	validateNotSent(t, ce, sources.ApiServerSourceAddEventType)
+	d.Update(simplePod("unit", "test"))
+	validateSent(t, ce, sources.ApiServerSourceUpdateEventType)
+}
+
 // HACKHACKHACK For test coverage.
 func TestResourceStub(t *testing.T) {
 	d, _ := makeResourceAndTestingClient()
@@ -17,8 +17,13 @@ limitations under the License.
 package apiserver
 
 import (
+	"encoding/json"
+	"hash/fnv"
+	"sync"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 )
 
@@ -95,3 +100,101 @@ func (c *controllerFilter) Replace([]interface{}, string) error {
 func (c *controllerFilter) Resync() error {
 	return nil
 }
+
+// statusChangeFilter only lets the updates of the resources whose status changed through,
+// it keeps a hash of the last status of each resource.
+type statusChangeFilter struct {
+	mu       sync.Mutex
+	statuses map[types.UID]uint64
+	delegate cache.Store
+}
+
+var _ cache.Store = (*statusChangeFilter)(nil)
+
+// Implements Store
+
+func (c *statusChangeFilter) Add(obj interface{}) error {
+	c.changed(obj)
+	return c.delegate.Add(obj)
+}
+
+func (c *statusChangeFilter) Update(obj interface{}) error {
+	if !c.changed(obj) {
+		return nil
+	}
+
+	return c.delegate.Update(obj)
+}
+
+func (c *statusChangeFilter) Delete(obj interface{}) error {
+	u := obj.(*unstructured.Unstructured)
+	c.mu.Lock()
+	delete(c.statuses, u.GetUID())
+	c.mu.Unlock()
+
+	return c.delegate.Delete(obj)
+}
+
+// Replace records the statuses of the resources listed again, and forgets the statuses of the
+// resources that are no longer listed, after they were deleted while the watch was interrupted.
+func (c *statusChangeFilter) Replace(list []interface{}, resourceVersion string) error {
+	statuses := make(map[types.UID]uint64, len(list))
+	for _, obj := range list {
+		u := obj.(*unstructured.Unstructured)
+		statuses[u.GetUID()] = statusHash(u)
+	}
+
+	c.mu.Lock()
+	c.statuses = statuses
+	c.mu.Unlock()
+
+	return c.delegate.Replace(list, resourceVersion)
+}
+
+// changed records the status of the resource, it returns true when the status differs from
+// the last status recorded or when no status was recorded for the resource.
+func (c *statusChangeFilter) changed(obj interface{}) bool {
+	u := obj.(*unstructured.Unstructured)
+	sum := statusHash(u)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	last, ok := c.statuses[u.GetUID()]
+	c.statuses[u.GetUID()] = sum
+	return !ok || last != sum
+}
+
+// statusHash returns the hash of the status of the resource.
+func statusHash(u *unstructured.Unstructured) uint64 {
+	status, _ := json.Marshal(u.Object["status"])
+	h := fnv.New64a()
+	_, _ = h.Write(status)
+	return h.Sum64()
+}
+
+// Stub cache.Store impl
+
+// Implements cache.Store
+func (c *statusChangeFilter) List() []interface{} {
+	return nil
+}
+
+// Implements cache.Store
+func (c *statusChangeFilter) ListKeys() []string {
+	return nil
+}
+
+// Implements cache.Store
+func (c *statusChangeFilter) Get(obj interface{}) (item interface{}, exists bool, err error) {
+	return nil, false, nil
+}
+
+// Implements cache.Store
+func (c *statusChangeFilter) GetByKey(key string) (item interface{}, exists bool, err error) {
+	return nil, false, nil
+}
+
+// Implements cache.Store
+func (c *statusChangeFilter) Resync() error {
+	return nil
+}
@@ -19,6 +19,9 @@ package apiserver
 import (
 	"testing"
 
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
 	adaptertest "knative.dev/eventing/pkg/adapter/v2/test"
 	sources "knative.dev/eventing/pkg/apis/sources"
 )
@@ -103,3 +106,101 @@ func makeController(apiVersion, kind string) (*controllerFilter, *adaptertest.Te
 		delegate:   delegate,
 	}, tc
 }
+
+func TestStatusChangeFilter(t *testing.T) {
+	delegate, tc := makeRefAndTestingClient()
+	c := &statusChangeFilter{
+		statuses: make(map[types.UID]uint64),
+		delegate: delegate,
+	}
+	pod := func(phase string) *unstructured.Unstructured {
+		p := simplePod("unit", "test")
+		p.SetUID("0c119059-7113-11e9-a6c5-42010a8a00ed")
+		p.Object["status"] = map[string]interface{}{"phase": phase}
+		return p
+	}
+	sent := func(want int) {
+		t.Helper()
+		if got := len(tc.Sent()); got != want {
+			t.Errorf("Expected %d events to be sent, got: %d", want, got)
+		}
+	}
+
+	c.Add(pod("Pending"))
+	sent(1)
+
+	// Same status, only the metadata changed.
+	updated := pod("Pending")
+	updated.SetLabels(map[string]string{"unit": "test"})
+	c.Update(updated)
+	sent(1)
+
+	c.Update(pod("Running"))
+	sent(2)
+
+	c.Delete(pod("Running"))
+	sent(3)
+
+	// The status of the resource deleted is forgotten.
+	c.Update(pod("Running"))
+	sent(4)
+}
+
+func TestStatusChangeFilterReplace(t *testing.T) {
+	delegate, tc := makeRefAndTestingClient()
+	c := &statusChangeFilter{
+		statuses: make(map[types.UID]uint64),
+		delegate: delegate,
+	}
+	pod := func(uid types.UID, phase string) *unstructured.Unstructured {
+		p := simplePod("unit", "test")
+		p.SetUID(uid)
+		p.Object["status"] = map[string]interface{}{"phase": phase}
+		return p
+	}
+	sent := func(want int) {
+		t.Helper()
+		if got := len(tc.Sent()); got != want {
+			t.Errorf("Expected %d events to be sent, got: %d", want, got)
+		}
+	}
+
+	c.Add(pod("listed", "Pending"))
+	c.Add(pod("deleted", "Pending"))
+	sent(2)
+
+	// The watch is interrupted, the status of the resource listed again changed, and the other
+	// resource was deleted.
+	if err := c.Replace([]interface{}{pod("listed", "Running")}, "42"); err != nil {
+		t.Fatal("Replace() =", err)
+	}
+	sent(2)
+
+	// The status listed again is recorded.
+	c.Update(pod("listed", "Running"))
+	sent(2)
+
+	c.Update(pod("listed", "Succeeded"))
+	sent(3)
+
+	// The status of the resource deleted while the watch was interrupted is forgotten.
+	c.mu.Lock()
+	_, ok := c.statuses["deleted"]
+	c.mu.Unlock()
+	if ok {
+		t.Error("The status of the resource deleted while the watch was interrupted wasn't forgotten")
+	}
+}
+
+// HACKHACKHACK For test coverage.
+func TestStatusChangeFilterStub(t *testing.T) {
+	delegate, _ := makeRefAndTestingClient()
+	c := &statusChangeFilter{delegate: delegate}
+
+	c.List()
+	c.ListKeys()
+	c.Get(nil)
+	c.GetByKey("")
+	c.Replace(nil, "")
+	c.Resync()
+}
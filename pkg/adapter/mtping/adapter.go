@@ -27,12 +27,15 @@ import (
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/robfig/cron/v3"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/equality"
 
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/logging"
 
 	"knative.dev/eventing/pkg/adapter/v2"
+	sourcesconfig "knative.dev/eventing/pkg/apis/sources/config"
 	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+	eventingclient "knative.dev/eventing/pkg/client/injection/client"
 )
 
 const (
@@ -45,6 +48,8 @@ type mtpingAdapter struct {
 	runner    CronJobRunner
 	entryidMu sync.RWMutex
 	entryids  map[string]cron.EntryID // key: resource namespace/name
+	// sources are the PingSources the entries were scheduled for, key: resource namespace/name
+	sources map[string]*sourcesv1.PingSource
 }
 
 var (
@@ -58,13 +63,19 @@ func NewEnvConfig() adapter.EnvConfigAccessor {
 
 func NewAdapter(ctx context.Context, _ adapter.EnvConfigAccessor, ceClient cloudevents.Client) adapter.Adapter {
 	logger := logging.FromContext(ctx)
-	runner := NewCronJobsRunner(ceClient, kubeclient.Get(ctx), logging.FromContext(ctx))
+	runner := NewCronJobsRunner(ceClient, kubeclient.Get(ctx), eventingclient.Get(ctx), logging.FromContext(ctx))
+	if cmw := adapter.ConfigWatcherFromContext(ctx); cmw != nil {
+		// The size of the data rendered from the data templates is limited like the data of the PingSources.
+		runner.configStore = sourcesconfig.NewStore(logger.Named("config-store"))
+		runner.configStore.WatchConfigs(cmw)
+	}
 
 	return &mtpingAdapter{
 		logger:    logger,
 		runner:    runner,
 		entryidMu: sync.RWMutex{},
 		entryids:  make(map[string]cron.EntryID),
+		sources:   make(map[string]*sourcesv1.PingSource),
 	}
 }
 
@@ -100,9 +111,15 @@ func (a *mtpingAdapter) Update(ctx context.Context, source *sourcesv1.PingSource
 	// Is the schedule already cached?
 	a.entryidMu.RLock()
 	id, ok := a.entryids[key]
+	scheduled := a.sources[key]
 	a.entryidMu.RUnlock()
 
 	if ok {
+		// The status changes each time the last schedule time is recorded, the schedule
+		// doesn't need to be replaced then.
+		if !needsRescheduling(scheduled, source) {
+			return
+		}
 		a.runner.RemoveSchedule(id)
 	}
 
@@ -110,9 +127,19 @@ func (a *mtpingAdapter) Update(ctx context.Context, source *sourcesv1.PingSource
 
 	a.entryidMu.Lock()
 	a.entryids[key] = id
+	a.sources[key] = source.DeepCopy()
 	a.entryidMu.Unlock()
 }
 
+// needsRescheduling returns true when the source was changed in a way that affects its schedule,
+// that is its spec, or its resolved sink and dead letter sink.
+func needsRescheduling(scheduled, source *sourcesv1.PingSource) bool {
+	return scheduled == nil ||
+		!equality.Semantic.DeepEqual(scheduled.Spec, source.Spec) ||
+		scheduled.Status.SinkURI.String() != source.Status.SinkURI.String() ||
+		scheduled.Status.DeadLetterSinkURI.String() != source.Status.DeadLetterSinkURI.String()
+}
+
 func (a *mtpingAdapter) Remove(source *sourcesv1.PingSource) {
 	key := fmt.Sprintf("%s/%s", source.Namespace, source.Name)
 
@@ -125,6 +152,7 @@ func (a *mtpingAdapter) Remove(source *sourcesv1.PingSource) {
 
 		a.entryidMu.Lock()
 		delete(a.entryids, key)
+		delete(a.sources, key)
 		a.entryidMu.Unlock()
 	}
 }
@@ -137,4 +165,5 @@ func (a *mtpingAdapter) RemoveAll(ctx context.Context) {
 		a.runner.RemoveSchedule(id)
 	}
 	a.entryids = make(map[string]cron.EntryID)
+	a.sources = make(map[string]*sourcesv1.PingSource)
 }
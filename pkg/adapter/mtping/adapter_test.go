@@ -68,6 +68,7 @@ func TestUpdateRemoveAdapter(t *testing.T) {
 		runner:    &testRunner{},
 		entryidMu: sync.RWMutex{},
 		entryids:  make(map[string]cron.EntryID),
+		sources:   make(map[string]*sourcesv1.PingSource),
 	}
 
 	adapter.Update(ctx, &sourcesv1.PingSource{
@@ -92,11 +93,52 @@ func TestUpdateRemoveAdapter(t *testing.T) {
 	}
 }
 
+func TestUpdateAdapterStatusOnly(t *testing.T) {
+	ctx, _ := rectesting.SetupFakeContext(t)
+	runner := &testRunner{}
+	adapter := mtpingAdapter{
+		logger:    logging.FromContext(ctx),
+		runner:    runner,
+		entryidMu: sync.RWMutex{},
+		entryids:  make(map[string]cron.EntryID),
+		sources:   make(map[string]*sourcesv1.PingSource),
+	}
+
+	source := &sourcesv1.PingSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-name",
+			Namespace: "test-ns",
+		},
+		Spec: sourcesv1.PingSourceSpec{
+			Schedule: "* * * * *",
+		},
+	}
+	adapter.Update(ctx, source)
+
+	// Recording the last schedule time doesn't replace the schedule.
+	source = source.DeepCopy()
+	source.Status.LastScheduleTime = &metav1.Time{Time: time.Now()}
+	source.Status.LastSequence = 1
+	adapter.Update(ctx, source)
+	if runner.added != 1 {
+		t.Errorf("Expected the schedule to be added once, got %d", runner.added)
+	}
+
+	source = source.DeepCopy()
+	source.Spec.Schedule = "*/2 * * * *"
+	adapter.Update(ctx, source)
+	if runner.added != 2 {
+		t.Errorf("Expected the schedule to be replaced, got %d additions", runner.added)
+	}
+}
+
 type testRunner struct {
 	CronJobRunner
+	added int
 }
 
-func (*testRunner) AddSchedule(*sourcesv1.PingSource) cron.EntryID {
-	return cron.EntryID(1)
+func (r *testRunner) AddSchedule(*sourcesv1.PingSource) cron.EntryID {
+	r.added++
+	return cron.EntryID(r.added)
 }
 func (*testRunner) RemoveSchedule(cron.EntryID) {}
@@ -19,8 +19,11 @@ package mtping
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"math/rand"
+	"sync"
+	"text/template"
 	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
@@ -29,13 +32,17 @@ import (
 	"github.com/robfig/cron/v3"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/record"
 
 	kncloudevents "knative.dev/eventing/pkg/adapter/v2"
 	"knative.dev/eventing/pkg/adapter/v2/util/crstatusevent"
+	sourcesconfig "knative.dev/eventing/pkg/apis/sources/config"
 	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+	"knative.dev/eventing/pkg/client/clientset/versioned"
 	"knative.dev/eventing/pkg/observability"
 )
 
@@ -58,18 +65,79 @@ type cronJobsRunner struct {
 
 	// kubeClient for sending k8s events
 	kubeClient kubernetes.Interface
+
+	// eventingClient for recording the last schedule time of the PingSources
+	eventingClient versioned.Interface
+
+	// configStore holds the PingSource defaults, the defaults are used when nil
+	configStore *sourcesconfig.Store
+}
+
+// lastSchedule tracks the ticks of a PingSource fired by the runner, it makes sure that a tick is
+// fired at most once.
+type lastSchedule struct {
+	mu sync.Mutex
+	// time is the last tick claimed.
+	time time.Time
+	// sequence is the sequence number of the last tick claimed.
+	sequence int64
+	// delivered is the last tick whose event was delivered.
+	delivered time.Time
+}
+
+// newLastSchedule returns the last schedule of the PingSource, as recorded in its status.
+func newLastSchedule(source *sourcesv1.PingSource) *lastSchedule {
+	last := &lastSchedule{sequence: source.Status.LastSequence}
+	if source.Status.LastScheduleTime != nil {
+		last.time = source.Status.LastScheduleTime.Time
+		last.delivered = source.Status.LastScheduleTime.Time
+	}
+	return last
+}
+
+// claim returns true, the sequence number of the tick and the sequence number of the previous
+// tick claimed if the tick wasn't claimed yet, and reserves the sequence number for the tick.
+func (l *lastSchedule) claim(tick time.Time) (int64, int64, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !tick.After(l.time) {
+		return 0, 0, false
+	}
+	l.time = tick
+	l.sequence++
+	return l.sequence, l.sequence - 1, true
+}
+
+// resetSequence resets the sequence number of the last tick claimed, after a claim that couldn't
+// be recorded.
+func (l *lastSchedule) resetSequence(sequence int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sequence = sequence
+}
+
+// deliver returns true if the tick is the latest tick whose event was delivered.
+func (l *lastSchedule) deliver(tick time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !tick.After(l.delivered) {
+		return false
+	}
+	l.delivered = tick
+	return true
 }
 
 const (
 	resourceGroup = "pingsources.sources.knative.dev"
 )
 
-func NewCronJobsRunner(ceClient cloudevents.Client, kubeClient kubernetes.Interface, logger *zap.SugaredLogger, opts ...cron.Option) *cronJobsRunner {
+func NewCronJobsRunner(ceClient cloudevents.Client, kubeClient kubernetes.Interface, eventingClient versioned.Interface, logger *zap.SugaredLogger, opts ...cron.Option) *cronJobsRunner {
 	return &cronJobsRunner{
-		cron:       *cron.New(opts...),
-		Client:     ceClient,
-		Logger:     logger,
-		kubeClient: kubeClient,
+		cron:           *cron.New(opts...),
+		Client:         ceClient,
+		Logger:         logger,
+		kubeClient:     kubeClient,
+		eventingClient: eventingClient,
 	}
 }
 
@@ -79,15 +147,30 @@ func (a *cronJobsRunner) AddSchedule(source *sourcesv1.PingSource) cron.EntryID
 		a.Logger.Error("failed to makeEvent: ", zap.Error(err))
 	}
 
+	var dataTemplate *template.Template
+	if source.Spec.DataTemplate != "" {
+		if dataTemplate, err = sourcesv1.ParseDataTemplate(source.Spec.DataTemplate); err != nil {
+			a.Logger.Error("failed to parse the data template: ", zap.Error(err))
+		}
+	}
+
 	ctx := context.Background()
 	ctx = cloudevents.ContextWithTarget(ctx, source.Status.SinkURI.String())
 
 	var kubeEventSink record.EventSink = &typedcorev1.EventSinkImpl{Interface: a.kubeClient.CoreV1().Events(source.Namespace)}
 	ctx = crstatusevent.ContextWithCRStatus(ctx, &kubeEventSink, "ping-source-mt-adapter", source, a.Logger.Infof)
 
-	// Simple retry configuration to be less than 1mn.
-	// We might want to retry more times for less-frequent schedule.
-	ctx = cloudevents.ContextWithRetriesExponentialBackoff(ctx, 50*time.Millisecond, 5)
+	delivery, err := kncloudevents.NewDelivery(source.Spec.Delivery, source.Status.DeadLetterSinkURI)
+	if err != nil {
+		a.Logger.Error("failed to read the delivery, using the default retries: ", zap.Error(err))
+	}
+	if delivery != nil {
+		ctx = kncloudevents.ContextWithDelivery(ctx, delivery)
+	} else {
+		// Simple retry configuration to be less than 1mn.
+		// We might want to retry more times for less-frequent schedule.
+		ctx = cloudevents.ContextWithRetriesExponentialBackoff(ctx, 50*time.Millisecond, 5)
+	}
 
 	metricTag := &kncloudevents.MetricTag{
 		Namespace:     source.Namespace,
@@ -101,16 +184,123 @@ func (a *cronJobsRunner) AddSchedule(source *sourcesv1.PingSource) cron.EntryID
 	ctx = observability.WithSpanData(ctx, spanName, int(trace.SpanKindProducer),
 		observability.K8sAttributes(source.Name, source.Namespace, sourcesv1.Resource("pingsource").String()))
 
-	schedule := source.Spec.Schedule
-	if source.Spec.Timezone != "" {
-		schedule = "CRON_TZ=" + source.Spec.Timezone + " " + schedule
+	ctx = kncloudevents.ContextWithMetricTag(ctx, metricTag)
+
+	sched, resolution, err := schedule(source)
+	if err != nil {
+		a.Logger.Error("failed to parse the schedule: ", zap.Error(err))
+		return 0
 	}
 
-	ctx = kncloudevents.ContextWithMetricTag(ctx, metricTag)
-	id, _ := a.cron.AddFunc(schedule, a.cronTick(ctx, event))
+	tick := a.cronTick(ctx, event, dataTemplate, source.Namespace, source.Name, newLastSchedule(source), recordsLastSchedule(source))
+
+	// The tick is the time the job runs at, truncated to the resolution of the schedule.
+	id := a.cron.Schedule(sched, cron.FuncJob(func() {
+		tick(time.Now().Truncate(resolution))
+	}))
+
+	// Fire the tick missed while the schedule wasn't running, if any.
+	if missed, ok := missedTick(sched, source, time.Now()); ok {
+		a.Logger.Infow("Firing a missed tick", zap.String("source", event.Source()), zap.Time("tick", missed))
+		go tick(missed)
+	}
 	return id
 }
 
+// recordsLastSchedule returns true when the ticks of the source are claimed and the last schedule
+// time is recorded in its status, that is when the source catches up on the missed ticks, when it
+// is a one-shot source whose completion is reported by the controller, or when its data template is
+// rendered with sequence numbers that must keep increasing after a restart.
+func recordsLastSchedule(source *sourcesv1.PingSource) bool {
+	return source.Spec.StartingDeadlineSeconds != nil || source.Spec.At != nil || source.Spec.DataTemplate != ""
+}
+
+// schedule returns the schedule of the PingSource and the resolution of its ticks.
+func schedule(source *sourcesv1.PingSource) (cron.Schedule, time.Duration, error) {
+	switch {
+	case source.Spec.At != nil:
+		return atSchedule{at: source.Spec.At.Time.Truncate(time.Second)}, time.Second, nil
+	case source.Spec.Interval != nil:
+		return newIntervalSchedule(source.CreationTimestamp.Time, source.Spec.Interval.Duration), time.Second, nil
+	}
+
+	spec := source.Spec.Schedule
+	if source.Spec.Timezone != "" {
+		spec = "CRON_TZ=" + source.Spec.Timezone + " " + spec
+	}
+	// The standard cron schedules have a minute resolution.
+	sched, err := cron.ParseStandard(spec)
+	return sched, time.Minute, err
+}
+
+// intervalSchedule is the schedule of a PingSource ticking at a fixed interval. The ticks are anchored
+// to an origin so that they don't drift when the jobs run late or the schedule is added again.
+type intervalSchedule struct {
+	origin   time.Time
+	interval time.Duration
+}
+
+// newIntervalSchedule returns the schedule ticking every interval, rounded down to the second, from
+// origin, usually the creation time of the source. The ticks are anchored to the Unix epoch when the
+// origin is unknown.
+func newIntervalSchedule(origin time.Time, interval time.Duration) intervalSchedule {
+	if origin.IsZero() {
+		origin = time.Unix(0, 0)
+	}
+	interval = interval.Truncate(time.Second)
+	if interval < time.Second {
+		interval = time.Second
+	}
+	return intervalSchedule{origin: origin.Truncate(time.Second), interval: interval}
+}
+
+// Next implements cron.Schedule, it returns the first tick after t.
+func (s intervalSchedule) Next(t time.Time) time.Time {
+	if t.Before(s.origin) {
+		return s.origin
+	}
+	return s.origin.Add((t.Sub(s.origin)/s.interval + 1) * s.interval)
+}
+
+// atSchedule is the schedule of a one-shot PingSource, it ticks once at the given time.
+type atSchedule struct {
+	at time.Time
+}
+
+// Next implements cron.Schedule, it returns the zero time once the tick is past so that it
+// never runs again.
+func (s atSchedule) Next(t time.Time) time.Time {
+	if t.Before(s.at) {
+		return s.at
+	}
+	return time.Time{}
+}
+
+// missedTick returns the latest tick of the schedule missed since the last schedule time of the
+// source, or since its creation when it never fired, if the source catches up on the missed ticks
+// and the tick is within its starting deadline.
+func missedTick(schedule cron.Schedule, source *sourcesv1.PingSource, now time.Time) (time.Time, bool) {
+	if source.Spec.StartingDeadlineSeconds == nil {
+		return time.Time{}, false
+	}
+	var from time.Time
+	if source.Status.LastScheduleTime != nil {
+		from = source.Status.LastScheduleTime.Time
+	} else if !source.CreationTimestamp.IsZero() {
+		from = source.CreationTimestamp.Time
+	} else {
+		return time.Time{}, false
+	}
+	if earliest := now.Add(-time.Duration(*source.Spec.StartingDeadlineSeconds) * time.Second); from.Before(earliest) {
+		from = earliest
+	}
+	var missed time.Time
+	for t := schedule.Next(from); !t.IsZero() && !t.After(now); t = schedule.Next(t) {
+		missed = t
+	}
+	return missed, !missed.IsZero()
+}
+
 func (a *cronJobsRunner) RemoveSchedule(id cron.EntryID) {
 	a.cron.Remove(id)
 }
@@ -128,8 +318,18 @@ func (a *cronJobsRunner) Stop() {
 	}
 }
 
-func (a *cronJobsRunner) cronTick(ctx context.Context, event cloudevents.Event) func() {
-	return func() {
+func (a *cronJobsRunner) cronTick(ctx context.Context, event cloudevents.Event, dataTemplate *template.Template, namespace, name string, last *lastSchedule, record bool) func(tick time.Time) {
+	return func(tick time.Time) {
+		sequence, previous, ok := last.claim(tick)
+		if !ok {
+			a.Logger.Debugw("Skipping a tick already fired", zap.String("source", event.Source()), zap.Time("tick", tick))
+			return
+		}
+		// The sequence number of the tick is recorded before its event is sent, so that the tick
+		// isn't fired by another replica and its sequence number isn't reused after a restart.
+		if record && !a.claimTick(ctx, namespace, name, tick, last, previous, sequence) {
+			return
+		}
 		event := event.Clone()
 		event.SetID(uuid.New().String()) // provide an ID here so we can track it with logging
 		defer a.Logger.Debug("Finished sending cloudevent id: ", event.ID())
@@ -139,13 +339,89 @@ func (a *cronJobsRunner) cronTick(ctx context.Context, event cloudevents.Event)
 		// Provide a delay so not all ping fired instantaneously distribute load on resources.
 		time.Sleep(time.Duration(rand.Intn(500)) * time.Millisecond) //nolint:gosec // Cryptographic randomness not necessary here.
 
+		if dataTemplate != nil {
+			data, err := sourcesv1.RenderDataTemplate(dataTemplate, tick, time.Now(), sequence)
+			if maxSize := a.dataMaxSize(); err == nil && maxSize > -1 && int64(len(data)) > maxSize {
+				err = fmt.Errorf("the rendered data length of %d bytes exceeds limit set at %d", len(data), maxSize)
+			}
+			if err == nil {
+				err = event.SetData(event.DataContentType(), data)
+			}
+			if err != nil {
+				a.Logger.Error("failed to render the data template: ", zap.Error(err),
+					zap.String("source", source), zap.String("id", event.ID()))
+				return
+			}
+		}
+
 		a.Logger.Debugf("sending cloudevent id: %s, source: %s, target: %s", event.ID(), source, target)
 
 		if result := a.Client.Send(ctx, event); !cloudevents.IsACK(result) {
 			// Exhausted number of retries. Event is lost.
 			a.Logger.Error("failed to send cloudevent result: ", zap.Any("result", result),
 				zap.String("source", source), zap.String("target", target), zap.String("id", event.ID()))
+			return
 		}
+
+		if record && last.deliver(tick) {
+			a.recordLastSchedule(ctx, namespace, name, tick)
+		}
+	}
+}
+
+// dataMaxSize returns the maximum size of the data of the events, -1 when unlimited.
+func (a *cronJobsRunner) dataMaxSize() int64 {
+	if a.configStore == nil {
+		return sourcesconfig.DefaultDataMaxSize
+	}
+	return a.configStore.Load().PingDefaults.DataMaxSize
+}
+
+// claimTick records the sequence number of the tick in the status of the PingSource, provided that
+// the sequence number recorded is still the one of the previous tick. It returns false when the
+// tick was claimed by another replica or the claim failed, in which case the event isn't sent and
+// the sequence number is reset to the one recorded.
+func (a *cronJobsRunner) claimTick(ctx context.Context, namespace, name string, tick time.Time, last *lastSchedule, previous, sequence int64) bool {
+	// The sequence number is omitted from the status until the first tick is claimed.
+	var recorded interface{}
+	if previous != 0 {
+		recorded = previous
+	}
+	patch, err := json.Marshal([]map[string]interface{}{
+		{"op": "test", "path": "/status/lastSequence", "value": recorded},
+		{"op": "add", "path": "/status/lastSequence", "value": sequence},
+	})
+	if err == nil {
+		_, err = a.eventingClient.SourcesV1().PingSources(namespace).Patch(ctx, name, types.JSONPatchType, patch, metav1.PatchOptions{}, "status")
+	}
+	if err == nil {
+		return true
+	}
+	a.Logger.Warnw("Skipping a tick that couldn't be claimed", zap.Error(err),
+		zap.String("namespace", namespace), zap.String("name", name), zap.Time("tick", tick))
+
+	if source, err := a.eventingClient.SourcesV1().PingSources(namespace).Get(ctx, name, metav1.GetOptions{}); err == nil {
+		previous = source.Status.LastSequence
+	}
+	last.resetSequence(previous)
+	return false
+}
+
+// recordLastSchedule records the last tick whose event was delivered in the status of the
+// PingSource, so that the tick isn't fired again after a restart or a leader change.
+func (a *cronJobsRunner) recordLastSchedule(ctx context.Context, namespace, name string, tick time.Time) {
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"lastScheduleTime": metav1.NewTime(tick),
+		},
+	})
+	if err != nil {
+		a.Logger.Warn("failed to marshal the last schedule time patch", zap.Error(err))
+		return
+	}
+	if _, err := a.eventingClient.SourcesV1().PingSources(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}, "status"); err != nil {
+		a.Logger.Warn("failed to record the last schedule time", zap.Error(err),
+			zap.String("namespace", namespace), zap.String("name", name), zap.Time("tick", tick))
 	}
 }
 
@@ -159,6 +435,14 @@ func makeEvent(source *sourcesv1.PingSource) (cloudevents.Event, error) {
 		}
 	}
 
+	if source.Spec.DataTemplate != "" {
+		// The data is rendered at each tick.
+		if source.Spec.ContentType != "" {
+			event.SetDataContentType(source.Spec.ContentType)
+		}
+		return event, nil
+	}
+
 	var data interface{}
 	if source.Spec.DataBase64 != "" {
 		data, _ = base64.StdEncoding.DecodeString(source.Spec.DataBase64)
@@ -20,13 +20,21 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
 	"reflect"
 	"testing"
 	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+	"github.com/robfig/cron/v3"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/pointer"
 
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -35,8 +43,13 @@ import (
 	"knative.dev/pkg/logging"
 	rectesting "knative.dev/pkg/reconciler/testing"
 
+	kncloudevents "knative.dev/eventing/pkg/adapter/v2"
 	adaptertesting "knative.dev/eventing/pkg/adapter/v2/test"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	sourcesconfig "knative.dev/eventing/pkg/apis/sources/config"
 	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+	eventingclient "knative.dev/eventing/pkg/client/injection/client"
+	fakeeventingclient "knative.dev/eventing/pkg/client/injection/client/fake"
 )
 
 const (
@@ -201,7 +214,7 @@ func TestAddRunRemoveSchedules(t *testing.T) {
 			logger := logging.FromContext(ctx)
 			ce := adaptertesting.NewTestClient()
 
-			runner := NewCronJobsRunner(ce, kubeclient.Get(ctx), logger)
+			runner := NewCronJobsRunner(ce, kubeclient.Get(ctx), eventingclient.Get(ctx), logger)
 			entryId := runner.AddSchedule(tc.src)
 
 			entry := runner.cron.Entry(entryId)
@@ -228,7 +241,7 @@ func TestStartStopCron(t *testing.T) {
 	logger := logging.FromContext(ctx)
 	ce := adaptertesting.NewTestClient()
 
-	runner := NewCronJobsRunner(ce, kubeclient.Get(ctx), logger)
+	runner := NewCronJobsRunner(ce, kubeclient.Get(ctx), eventingclient.Get(ctx), logger)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	wctx, wcancel := context.WithCancel(context.Background())
@@ -257,7 +270,7 @@ func TestStartStopCronDelayWait(t *testing.T) {
 	logger := logging.FromContext(ctx)
 	ce := adaptertesting.NewTestClientWithDelay(time.Second * 5)
 
-	runner := NewCronJobsRunner(ce, kubeclient.Get(ctx), logger)
+	runner := NewCronJobsRunner(ce, kubeclient.Get(ctx), eventingclient.Get(ctx), logger)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -296,6 +309,469 @@ func TestStartStopCronDelayWait(t *testing.T) {
 	validateSent(t, ce, []byte("some delayed data"), cloudevents.TextPlain, nil)
 }
 
+func TestMissedTick(t *testing.T) {
+	schedule, err := cron.ParseStandard("*/10 * * * *")
+	if err != nil {
+		t.Fatal("ParseStandard() =", err)
+	}
+	now := time.Date(2023, 1, 1, 12, 35, 0, 0, time.UTC)
+	at := func(minute int) *metav1.Time {
+		mt := metav1.NewTime(time.Date(2023, 1, 1, 12, minute, 0, 0, time.UTC))
+		return &mt
+	}
+
+	testCases := map[string]struct {
+		deadline *int64
+		created  *metav1.Time
+		last     *metav1.Time
+		want     *metav1.Time
+	}{
+		"no catch up": {
+			last: at(0),
+		},
+		"never fired": {
+			deadline: pointer.Int64(3600),
+		},
+		"never fired since the creation": {
+			deadline: pointer.Int64(3600),
+			created:  at(0),
+			want:     at(30),
+		},
+		"latest missed tick": {
+			deadline: pointer.Int64(3600),
+			last:     at(0),
+			want:     at(30),
+		},
+		"missed tick older than the deadline": {
+			deadline: pointer.Int64(60),
+			last:     at(0),
+		},
+		"no missed tick": {
+			deadline: pointer.Int64(3600),
+			last:     at(30),
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			source := &sourcesv1.PingSource{
+				Spec:   sourcesv1.PingSourceSpec{StartingDeadlineSeconds: tc.deadline},
+				Status: sourcesv1.PingSourceStatus{LastScheduleTime: tc.last},
+			}
+			if tc.created != nil {
+				source.CreationTimestamp = *tc.created
+			}
+			got, ok := missedTick(schedule, source, now)
+			if ok != (tc.want != nil) {
+				t.Fatalf("missedTick() = %v, %v, want %v", got, ok, tc.want)
+			}
+			if tc.want != nil && !got.Equal(tc.want.Time) {
+				t.Errorf("missedTick() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 30, 0, time.UTC)
+	at := metav1.NewTime(now.Add(time.Hour))
+
+	testCases := map[string]struct {
+		spec           sourcesv1.PingSourceSpec
+		wantResolution time.Duration
+		wantNext       []time.Time
+	}{
+		"cron": {
+			spec:           sourcesv1.PingSourceSpec{Schedule: "*/10 * * * *"},
+			wantResolution: time.Minute,
+			wantNext:       []time.Time{now.Add(9*time.Minute + 30*time.Second), now.Add(19*time.Minute + 30*time.Second)},
+		},
+		"interval": {
+			spec:           sourcesv1.PingSourceSpec{Interval: &metav1.Duration{Duration: 15 * time.Second}},
+			wantResolution: time.Second,
+			wantNext:       []time.Time{now.Add(15 * time.Second), now.Add(30 * time.Second)},
+		},
+		"at": {
+			spec:           sourcesv1.PingSourceSpec{At: &at},
+			wantResolution: time.Second,
+			wantNext:       []time.Time{at.Time, {}},
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			sched, resolution, err := schedule(&sourcesv1.PingSource{Spec: tc.spec})
+			if err != nil {
+				t.Fatal("schedule() =", err)
+			}
+			if resolution != tc.wantResolution {
+				t.Errorf("resolution = %v, want %v", resolution, tc.wantResolution)
+			}
+			next := now
+			for _, want := range tc.wantNext {
+				next = sched.Next(next)
+				if !next.Equal(want) {
+					t.Fatalf("Next() = %v, want %v", next, want)
+				}
+			}
+		})
+	}
+}
+
+func TestIntervalScheduleAnchored(t *testing.T) {
+	created := time.Date(2023, 1, 1, 12, 0, 7, 0, time.UTC)
+	sched, _, err := schedule(&sourcesv1.PingSource{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+		Spec:       sourcesv1.PingSourceSpec{Interval: &metav1.Duration{Duration: 15 * time.Second}},
+	})
+	if err != nil {
+		t.Fatal("schedule() =", err)
+	}
+
+	// The jobs run a bit late, the ticks stay anchored to the creation time.
+	next := created
+	for i := 1; i <= 3; i++ {
+		next = sched.Next(next.Add(300 * time.Millisecond))
+		if want := created.Add(time.Duration(i) * 15 * time.Second); !next.Equal(want) {
+			t.Fatalf("Next() = %v, want %v", next, want)
+		}
+	}
+
+	// Adding the schedule again doesn't shift the ticks.
+	if got, want := sched.Next(created.Add(time.Minute+time.Second)), created.Add(75*time.Second); !got.Equal(want) {
+		t.Errorf("Next() = %v, want %v", got, want)
+	}
+	if got := sched.Next(created.Add(-time.Hour)); !got.Equal(created) {
+		t.Errorf("Next() = %v before the creation time, want %v", got, created)
+	}
+}
+
+func TestMissedOneShotTick(t *testing.T) {
+	at := metav1.NewTime(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	source := &sourcesv1.PingSource{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(at.Add(-time.Hour))},
+		Spec: sourcesv1.PingSourceSpec{
+			At:                      &at,
+			StartingDeadlineSeconds: pointer.Int64(3600),
+		},
+	}
+	sched, _, err := schedule(source)
+	if err != nil {
+		t.Fatal("schedule() =", err)
+	}
+
+	if got, ok := missedTick(sched, source, at.Add(time.Minute)); !ok || !got.Equal(at.Time) {
+		t.Errorf("missedTick() = %v, %v, want %v, true", got, ok, at)
+	}
+
+	source.Status.LastScheduleTime = &at
+	if got, ok := missedTick(sched, source, at.Add(time.Minute)); ok {
+		t.Errorf("missedTick() = %v for a one-shot source already fired", got)
+	}
+}
+
+func TestLastScheduleClaim(t *testing.T) {
+	tick := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	last := &lastSchedule{time: tick.Add(-time.Minute), sequence: 41}
+	if sequence, previous, ok := last.claim(tick); !ok || sequence != 42 || previous != 41 {
+		t.Errorf("claim() = %d, %d, %v for a new tick, want 42, 41, true", sequence, previous, ok)
+	}
+	if _, _, ok := last.claim(tick); ok {
+		t.Error("claim() = true for a tick already fired")
+	}
+	if _, _, ok := last.claim(tick.Add(-time.Minute)); ok {
+		t.Error("claim() = true for a tick older than the last tick fired")
+	}
+	last.resetSequence(50)
+	if sequence, previous, ok := last.claim(tick.Add(time.Minute)); !ok || sequence != 51 || previous != 50 {
+		t.Errorf("claim() = %d, %d, %v for the next tick, want 51, 50, true", sequence, previous, ok)
+	}
+}
+
+func TestLastScheduleDeliver(t *testing.T) {
+	tick := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	last := &lastSchedule{delivered: tick.Add(-time.Minute)}
+	if !last.deliver(tick) {
+		t.Error("deliver() = false for a new tick")
+	}
+	if last.deliver(tick.Add(-30 * time.Second)) {
+		t.Error("deliver() = true for a tick older than the last tick delivered")
+	}
+}
+
+func TestCatchUp(t *testing.T) {
+	ctx, _ := rectesting.SetupFakeContext(t)
+	logger := logging.FromContext(ctx)
+	ce := adaptertesting.NewTestClient()
+
+	missed := time.Now().Truncate(time.Minute)
+	last := metav1.NewTime(missed.Add(-3 * time.Minute))
+	src := &sourcesv1.PingSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-name",
+			Namespace: "test-ns",
+		},
+		Spec: sourcesv1.PingSourceSpec{
+			Schedule:                "* * * * *",
+			ContentType:             cloudevents.TextPlain,
+			Data:                    sampleData,
+			StartingDeadlineSeconds: pointer.Int64(3600),
+		},
+		Status: sourcesv1.PingSourceStatus{
+			SourceStatus: duckv1.SourceStatus{
+				SinkURI: &apis.URL{Path: "a sink"},
+			},
+			LastScheduleTime: &last,
+		},
+	}
+	client := eventingclient.Get(ctx).SourcesV1().PingSources(src.Namespace)
+	if _, err := client.Create(ctx, src, metav1.CreateOptions{}); err != nil {
+		t.Fatal("Create() =", err)
+	}
+
+	runner := NewCronJobsRunner(ce, kubeclient.Get(ctx), eventingclient.Get(ctx), logger)
+	runner.AddSchedule(src)
+
+	err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		got, err := client.Get(ctx, src.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		// The tick is recorded once its event is delivered.
+		return got.Status.LastScheduleTime != nil && got.Status.LastScheduleTime.Equal(&metav1.Time{Time: missed}) && len(ce.Sent()) > 0, nil
+	})
+	if err != nil {
+		t.Fatal("The last schedule time wasn't recorded:", err)
+	}
+	validateSent(t, ce, []byte(sampleData), cloudevents.TextPlain, nil)
+}
+
+func TestLastScheduleNotRecordedWithoutCatchUp(t *testing.T) {
+	ctx, _ := rectesting.SetupFakeContext(t)
+	logger := logging.FromContext(ctx)
+	ce := adaptertesting.NewTestClient()
+
+	src := &sourcesv1.PingSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-name",
+			Namespace: "test-ns",
+		},
+		Spec: sourcesv1.PingSourceSpec{
+			Schedule:    "* * * * *",
+			ContentType: cloudevents.TextPlain,
+			Data:        sampleData,
+		},
+	}
+	event, err := makeEvent(src)
+	if err != nil {
+		t.Fatal("makeEvent() =", err)
+	}
+
+	runner := NewCronJobsRunner(ce, kubeclient.Get(ctx), eventingclient.Get(ctx), logger)
+	ctx = cloudevents.ContextWithTarget(ctx, "http://sink.example.com")
+	runner.cronTick(ctx, event, nil, src.Namespace, src.Name, &lastSchedule{}, recordsLastSchedule(src))(time.Now().Truncate(time.Minute))
+
+	validateSent(t, ce, []byte(sampleData), cloudevents.TextPlain, nil)
+	for _, action := range fakeeventingclient.Get(ctx).Actions() {
+		if action.GetVerb() == "patch" {
+			t.Errorf("The last schedule time was recorded without catch-up: %v", action)
+		}
+	}
+}
+
+func TestDataTemplate(t *testing.T) {
+	ctx, _ := rectesting.SetupFakeContext(t)
+	logger := logging.FromContext(ctx)
+	ce := adaptertesting.NewTestClient()
+
+	missed := time.Now().Truncate(time.Minute)
+	last := metav1.NewTime(missed.Add(-3 * time.Minute))
+	src := &sourcesv1.PingSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-name",
+			Namespace: "test-ns",
+		},
+		Spec: sourcesv1.PingSourceSpec{
+			Schedule:                "* * * * *",
+			ContentType:             cloudevents.ApplicationJSON,
+			DataTemplate:            `{"scheduled": {{ .ScheduleTime.Unix }}, "sequence": {{ .Sequence }}}`,
+			StartingDeadlineSeconds: pointer.Int64(3600),
+		},
+		Status: sourcesv1.PingSourceStatus{
+			SourceStatus: duckv1.SourceStatus{
+				SinkURI: &apis.URL{Path: "a sink"},
+			},
+			LastScheduleTime: &last,
+			LastSequence:     41,
+		},
+	}
+	client := eventingclient.Get(ctx).SourcesV1().PingSources(src.Namespace)
+	if _, err := client.Create(ctx, src, metav1.CreateOptions{}); err != nil {
+		t.Fatal("Create() =", err)
+	}
+
+	runner := NewCronJobsRunner(ce, kubeclient.Get(ctx), eventingclient.Get(ctx), logger)
+	runner.AddSchedule(src)
+
+	err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		got, err := client.Get(ctx, src.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return got.Status.LastSequence == 42 && len(ce.Sent()) > 0, nil
+	})
+	if err != nil {
+		t.Fatal("The last sequence wasn't recorded:", err)
+	}
+	want := fmt.Sprintf(`{"scheduled": %d, "sequence": 42}`, missed.Unix())
+	validateSent(t, ce, []byte(want), cloudevents.ApplicationJSON, nil)
+}
+
+func TestDataTemplateSequenceRecordedOnFailure(t *testing.T) {
+	ctx, _ := rectesting.SetupFakeContext(t)
+	logger := logging.FromContext(ctx)
+	ce := &failingClient{TestCloudEventsClient: adaptertesting.NewTestClient()}
+
+	src := &sourcesv1.PingSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-name",
+			Namespace: "test-ns",
+		},
+		Spec: sourcesv1.PingSourceSpec{
+			Schedule:     "* * * * *",
+			ContentType:  cloudevents.TextPlain,
+			DataTemplate: `{{ .Sequence }}`,
+		},
+		Status: sourcesv1.PingSourceStatus{
+			LastSequence: 41,
+		},
+	}
+	client := eventingclient.Get(ctx).SourcesV1().PingSources(src.Namespace)
+	if _, err := client.Create(ctx, src, metav1.CreateOptions{}); err != nil {
+		t.Fatal("Create() =", err)
+	}
+	event, err := makeEvent(src)
+	if err != nil {
+		t.Fatal("makeEvent() =", err)
+	}
+	dataTemplate, err := sourcesv1.ParseDataTemplate(src.Spec.DataTemplate)
+	if err != nil {
+		t.Fatal("ParseDataTemplate() =", err)
+	}
+
+	runner := NewCronJobsRunner(ce, kubeclient.Get(ctx), eventingclient.Get(ctx), logger)
+	ctx = cloudevents.ContextWithTarget(ctx, "http://sink.example.com")
+	last := &lastSchedule{sequence: src.Status.LastSequence}
+	runner.cronTick(ctx, event, dataTemplate, src.Namespace, src.Name, last, recordsLastSchedule(src))(time.Now().Truncate(time.Minute))
+
+	// The sequence number of the failed tick isn't reused after a restart.
+	got, err := client.Get(ctx, src.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal("Get() =", err)
+	}
+	if got.Status.LastSequence != 42 {
+		t.Errorf("LastSequence = %d, want 42", got.Status.LastSequence)
+	}
+	// The failed tick is fired again when catching up.
+	if got.Status.LastScheduleTime != nil {
+		t.Errorf("LastScheduleTime = %v, want nil for a failed tick", got.Status.LastScheduleTime)
+	}
+}
+
+func TestTickClaimedByAnotherReplica(t *testing.T) {
+	ctx, _ := rectesting.SetupFakeContext(t)
+	logger := logging.FromContext(ctx)
+	ce := adaptertesting.NewTestClient()
+
+	src := &sourcesv1.PingSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-name",
+			Namespace: "test-ns",
+		},
+		Spec: sourcesv1.PingSourceSpec{
+			Schedule:     "* * * * *",
+			ContentType:  cloudevents.TextPlain,
+			DataTemplate: `{{ .Sequence }}`,
+		},
+		Status: sourcesv1.PingSourceStatus{
+			LastSequence: 42,
+		},
+	}
+	client := eventingclient.Get(ctx).SourcesV1().PingSources(src.Namespace)
+	if _, err := client.Create(ctx, src, metav1.CreateOptions{}); err != nil {
+		t.Fatal("Create() =", err)
+	}
+	event, err := makeEvent(src)
+	if err != nil {
+		t.Fatal("makeEvent() =", err)
+	}
+	dataTemplate, err := sourcesv1.ParseDataTemplate(src.Spec.DataTemplate)
+	if err != nil {
+		t.Fatal("ParseDataTemplate() =", err)
+	}
+
+	runner := NewCronJobsRunner(ce, kubeclient.Get(ctx), eventingclient.Get(ctx), logger)
+	ctx = cloudevents.ContextWithTarget(ctx, "http://sink.example.com")
+	// The tick 42 was claimed by another replica.
+	tick := runner.cronTick(ctx, event, dataTemplate, src.Namespace, src.Name, &lastSchedule{sequence: 41}, recordsLastSchedule(src))
+
+	now := time.Now().Truncate(time.Minute)
+	tick(now)
+	if got := len(ce.Sent()); got != 0 {
+		t.Fatalf("Expected no event to be sent for a tick claimed by another replica, got %d", got)
+	}
+
+	tick(now.Add(time.Minute))
+	validateSent(t, ce, []byte("43"), cloudevents.TextPlain, nil)
+	got, err := client.Get(ctx, src.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal("Get() =", err)
+	}
+	if got.Status.LastSequence != 43 {
+		t.Errorf("LastSequence = %d, want 43", got.Status.LastSequence)
+	}
+	if want := metav1.NewTime(now.Add(time.Minute)); got.Status.LastScheduleTime == nil || !got.Status.LastScheduleTime.Equal(&want) {
+		t.Errorf("LastScheduleTime = %v, want %v", got.Status.LastScheduleTime, want)
+	}
+}
+
+func TestDataTemplateTooLarge(t *testing.T) {
+	ctx, _ := rectesting.SetupFakeContext(t)
+	logger := logging.FromContext(ctx)
+	ce := adaptertesting.NewTestClient()
+
+	src := &sourcesv1.PingSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-name",
+			Namespace: "test-ns",
+		},
+		Spec: sourcesv1.PingSourceSpec{
+			Schedule:     "* * * * *",
+			ContentType:  cloudevents.TextPlain,
+			DataTemplate: `{{ printf "%100d" .Sequence }}`,
+		},
+	}
+	event, err := makeEvent(src)
+	if err != nil {
+		t.Fatal("makeEvent() =", err)
+	}
+	dataTemplate, err := sourcesv1.ParseDataTemplate(src.Spec.DataTemplate)
+	if err != nil {
+		t.Fatal("ParseDataTemplate() =", err)
+	}
+
+	runner := NewCronJobsRunner(ce, kubeclient.Get(ctx), eventingclient.Get(ctx), logger)
+	runner.configStore = sourcesconfig.NewStore(logger)
+	runner.configStore.OnConfigChanged(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: sourcesconfig.PingDefaultsConfigName},
+		Data:       map[string]string{sourcesconfig.DataMaxSizeKey: "64"},
+	})
+	ctx = cloudevents.ContextWithTarget(ctx, "http://sink.example.com")
+	runner.cronTick(ctx, event, dataTemplate, src.Namespace, src.Name, &lastSchedule{}, false)(time.Now().Truncate(time.Minute))
+
+	if got := len(ce.Sent()); got != 0 {
+		t.Errorf("Expected no event to be sent when the rendered data exceeds the max size, got %d", got)
+	}
+}
+
 func validateSent(t *testing.T, ce *adaptertesting.TestCloudEventsClient, wantData []byte, wantContentType string, extensions map[string]string) {
 	if got := len(ce.Sent()); got != 1 {
 		t.Error("Expected 1 event to be sent, got", got)
@@ -331,3 +807,88 @@ func validateSent(t *testing.T, ce *adaptertesting.TestCloudEventsClient, wantDa
 		}
 	}
 }
+
+// contextClient records the context of the events sent.
+type contextClient struct {
+	*adaptertesting.TestCloudEventsClient
+	ctx context.Context
+}
+
+func (c *contextClient) Send(ctx context.Context, out cloudevents.Event) protocol.Result {
+	c.ctx = ctx
+	return c.TestCloudEventsClient.Send(ctx, out)
+}
+
+// failingClient fails to send the events.
+type failingClient struct {
+	*adaptertesting.TestCloudEventsClient
+}
+
+func (c *failingClient) Send(ctx context.Context, out cloudevents.Event) protocol.Result {
+	c.TestCloudEventsClient.Send(ctx, out)
+	return errors.New("sink unavailable")
+}
+
+func TestDelivery(t *testing.T) {
+	dls := apis.HTTP("dls.example.com")
+
+	testCases := map[string]struct {
+		delivery           *eventingduckv1.DeliverySpec
+		wantDeadLetterSink *url.URL
+		wantDelivery       bool
+	}{
+		"default retries": {},
+		"delivery with a dead letter sink": {
+			delivery: &eventingduckv1.DeliverySpec{
+				DeadLetterSink: &duckv1.Destination{URI: dls},
+				Retry:          pointer.Int32(3),
+			},
+			wantDeadLetterSink: dls.URL(),
+			wantDelivery:       true,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			ctx, _ := rectesting.SetupFakeContext(t)
+			ce := &contextClient{TestCloudEventsClient: adaptertesting.NewTestClient()}
+
+			src := &sourcesv1.PingSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-name",
+					Namespace: "test-ns",
+				},
+				Spec: sourcesv1.PingSourceSpec{
+					Schedule: "* * * * ?",
+					Data:     sampleData,
+					Delivery: tc.delivery,
+				},
+				Status: sourcesv1.PingSourceStatus{
+					SourceStatus: duckv1.SourceStatus{
+						SinkURI: &apis.URL{Path: "a sink"},
+					},
+					DeliveryStatus: eventingduckv1.DeliveryStatus{
+						DeadLetterSinkURI: dls,
+					},
+				},
+			}
+
+			runner := NewCronJobsRunner(ce, kubeclient.Get(ctx), eventingclient.Get(ctx), logging.FromContext(ctx))
+			entryId := runner.AddSchedule(src)
+			runner.cron.Entry(entryId).Job.Run()
+
+			delivery := kncloudevents.DeliveryFromContext(ce.ctx)
+			if got := delivery != nil; got != tc.wantDelivery {
+				t.Fatalf("delivery set = %v, want %v", got, tc.wantDelivery)
+			}
+			if delivery == nil {
+				return
+			}
+			if delivery.RetryConfig.RetryMax != 3 {
+				t.Errorf("RetryMax = %d, want 3", delivery.RetryConfig.RetryMax)
+			}
+			if delivery.DeadLetterSink.String() != tc.wantDeadLetterSink.String() {
+				t.Errorf("DeadLetterSink = %v, want %v", delivery.DeadLetterSink, tc.wantDeadLetterSink)
+			}
+		})
+	}
+}
@@ -36,6 +36,7 @@ import (
 
 	"knative.dev/eventing/pkg/adapter/v2/util/crstatusevent"
 	"knative.dev/eventing/pkg/eventingtls"
+	"knative.dev/eventing/pkg/kncloudevents"
 	"knative.dev/eventing/pkg/metrics/source"
 	obsclient "knative.dev/eventing/pkg/observability/client"
 )
@@ -65,7 +66,14 @@ func NewCloudEventsClient(target string, ceOverrides *duckv1.CloudEventOverrides
 	if len(target) > 0 {
 		opts = append(opts, cloudevents.WithTarget(target))
 	}
-	return newCloudEventsClientCRStatus(nil, ceOverrides, reporter, nil, opts...)
+	c, err := newCloudEventsClientCRStatus(nil, ceOverrides, reporter, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(target) > 0 {
+		c.target, _ = url.Parse(target)
+	}
+	return c, nil
 }
 
 // NewCloudEventsClientWithOptions returns a client created with provided options
@@ -78,7 +86,7 @@ func NewCloudEventsClientCRStatus(env EnvConfigAccessor, reporter source.StatsRe
 	return newCloudEventsClientCRStatus(env, nil, reporter, crStatusEventClient)
 }
 func newCloudEventsClientCRStatus(env EnvConfigAccessor, ceOverrides *duckv1.CloudEventOverrides, reporter source.StatsReporter,
-	crStatusEventClient *crstatusevent.CRStatusEventClient, opts ...http.Option) (cloudevents.Client, error) {
+	crStatusEventClient *crstatusevent.CRStatusEventClient, opts ...http.Option) (*client, error) {
 
 	pOpts := make([]http.Option, 0)
 	pOpts = append(pOpts, cloudevents.WithRoundTripper(&ochttp.Transport{
@@ -129,12 +137,32 @@ func newCloudEventsClientCRStatus(env EnvConfigAccessor, ceOverrides *duckv1.Clo
 	if err != nil {
 		return nil, err
 	}
-	return &client{
+	sender, err := newDeliverySender(opts)
+	if err != nil {
+		return nil, err
+	}
+	c := &client{
+		sender:              sender,
 		ceClient:            ceClient,
 		ceOverrides:         ceOverrides,
 		reporter:            reporter,
 		crStatusEventClient: crStatusEventClient,
-	}, nil
+	}
+	if env != nil && len(env.GetSink()) > 0 {
+		c.target, _ = url.Parse(env.GetSink())
+	}
+	return c, nil
+}
+
+// newDeliverySender returns the sender of the events sent with a Delivery, its HTTP client is
+// configured with the options of the client, so that it uses the same timeout, TLS configuration
+// and round tripper.
+func newDeliverySender(opts []http.Option) (*kncloudevents.HTTPMessageSender, error) {
+	p, err := http.New(append([]http.Option{http.WithClient(nethttp.Client{})}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	return &kncloudevents.HTTPMessageSender{Client: p.Client}, nil
 }
 
 func setTimeOut(duration time.Duration) http.Option {
@@ -155,6 +183,11 @@ type client struct {
 	ceOverrides         *duckv1.CloudEventOverrides
 	reporter            source.StatsReporter
 	crStatusEventClient *crstatusevent.CRStatusEventClient
+
+	// target is the default sink of the events sent with a Delivery.
+	target *url.URL
+	// sender sends the events with a Delivery.
+	sender *kncloudevents.HTTPMessageSender
 }
 
 var _ cloudevents.Client = (*client)(nil)
@@ -162,7 +195,12 @@ var _ cloudevents.Client = (*client)(nil)
 // Send implements client.Send
 func (c *client) Send(ctx context.Context, out event.Event) protocol.Result {
 	c.applyOverrides(&out)
-	res := c.ceClient.Send(ctx, out)
+	var res protocol.Result
+	if delivery := DeliveryFromContext(ctx); delivery != nil {
+		res = c.sendWithDelivery(ctx, out, delivery)
+	} else {
+		res = c.ceClient.Send(ctx, out)
+	}
 	c.reportMetrics(ctx, out, res)
 	return res
 }
@@ -0,0 +1,159 @@
+/*
+Copyright 2023 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	nethttp "net/http"
+	"net/url"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cecontext "github.com/cloudevents/sdk-go/v2/context"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+	"github.com/cloudevents/sdk-go/v2/protocol/http"
+	"knative.dev/pkg/apis"
+
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/channel/attributes"
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+// Delivery holds the retries and the dead letter sink of the events sent by the
+// client of an adapter.
+type Delivery struct {
+	// RetryConfig is the retry configuration of the sink and of the dead letter sink.
+	RetryConfig *kncloudevents.RetryConfig
+	// DeadLetterSink receives the events that could not be sent to the sink, if set.
+	DeadLetterSink *url.URL
+}
+
+// NewDelivery returns the Delivery of the given delivery spec, nil when the spec is nil.
+// deadLetterSink is the resolved URI of the dead letter sink of the spec.
+func NewDelivery(spec *eventingduckv1.DeliverySpec, deadLetterSink *apis.URL) (*Delivery, error) {
+	if spec == nil {
+		return nil, nil
+	}
+	retryConfig, err := kncloudevents.RetryConfigFromDeliverySpec(*spec)
+	if err != nil {
+		return nil, err
+	}
+	d := &Delivery{RetryConfig: &retryConfig}
+	if spec.DeadLetterSink != nil && deadLetterSink != nil {
+		d.DeadLetterSink = deadLetterSink.URL()
+	}
+	return d, nil
+}
+
+type deliveryKey struct{}
+
+// ContextWithDelivery returns a copy of parent context in which the events sent
+// by the client are retried and sent to the dead letter sink of the delivery.
+func ContextWithDelivery(ctx context.Context, delivery *Delivery) context.Context {
+	return context.WithValue(ctx, deliveryKey{}, delivery)
+}
+
+// DeliveryFromContext returns the delivery stored in context.
+// Returns nil if no delivery is set in context.
+func DeliveryFromContext(ctx context.Context) *Delivery {
+	if delivery, ok := ctx.Value(deliveryKey{}).(*Delivery); ok {
+		return delivery
+	}
+	return nil
+}
+
+// sendWithDelivery sends the event with the retries of the delivery, and to its dead letter
+// sink when the sink keeps failing, the same way channels dispatch their events.
+func (c *client) sendWithDelivery(ctx context.Context, out event.Event, delivery *Delivery) protocol.Result {
+	target := cecontext.TargetFrom(ctx)
+	if target == nil {
+		target = c.target
+	}
+	if target == nil {
+		return fmt.Errorf("no target to send the event %s to", out.ID())
+	}
+
+	payload := eventingduckv1.DeadLetterPayloadOriginal
+	if delivery.RetryConfig != nil && delivery.RetryConfig.DeadLetterPayload != "" {
+		payload = delivery.RetryConfig.DeadLetterPayload
+	}
+
+	// Count the attempts when they are reported to the dead letter sink.
+	retries, attempts := delivery.RetryConfig, func() int { return 1 }
+	if delivery.DeadLetterSink != nil && payload != eventingduckv1.DeadLetterPayloadOriginal {
+		retries, attempts = kncloudevents.CountAttempts(delivery.RetryConfig)
+	}
+	start := time.Now()
+
+	code, body, err := c.sendMessage(ctx, target, binding.ToMessage(&out), retries)
+	if err == nil {
+		return http.NewResult(code, "%w", protocol.ResultACK)
+	}
+	if delivery.DeadLetterSink == nil {
+		return http.NewResult(code, "%w", err)
+	}
+
+	message, transformers := binding.Message(binding.ToMessage(&out)), attributes.KnativeErrorTransformers(*target, code, attributes.KnativeErrorData(body))
+	if payload != eventingduckv1.DeadLetterPayloadOriginal {
+		deadLetterEvent, dlErr := attributes.NewDeadLetterEvent(&out, attributes.DeadLetterEventData{
+			Destination:      target.String(),
+			ResponseCode:     code,
+			ResponseBody:     string(body),
+			Attempts:         attempts(),
+			FirstAttemptTime: start,
+			LastAttemptTime:  time.Now(),
+		}, payload == eventingduckv1.DeadLetterPayloadEnvelope)
+		if dlErr != nil {
+			return http.NewResult(code, "unable to complete request to %s (%v) nor to create the dead letter event (%w)", target, err, dlErr)
+		}
+		message, transformers = binding.ToMessage(deadLetterEvent), nil
+	}
+
+	dlsCode, _, dlsErr := c.sendMessage(ctx, delivery.DeadLetterSink, message, delivery.RetryConfig, transformers...)
+	if dlsErr != nil {
+		return http.NewResult(dlsCode, "unable to complete request to either %s (%v) or %s (%w)", target, err, delivery.DeadLetterSink, dlsErr)
+	}
+	return http.NewResult(dlsCode, "%w", protocol.ResultACK)
+}
+
+// sendMessage sends the message to the target with the retries. It returns the status code and,
+// for the failures, the body of the last response.
+func (c *client) sendMessage(ctx context.Context, target *url.URL, message binding.Message, retries *kncloudevents.RetryConfig, transformers ...binding.Transformer) (int, []byte, error) {
+	req, err := c.sender.NewCloudEventRequestWithTarget(ctx, target.String())
+	if err != nil {
+		return nethttp.StatusInternalServerError, nil, err
+	}
+	if err := kncloudevents.WriteHTTPRequestWithAdditionalHeaders(ctx, message, req, nil, transformers...); err != nil {
+		return nethttp.StatusInternalServerError, nil, err
+	}
+
+	res, err := c.sender.SendWithRetries(req, retries)
+	if err != nil {
+		return nethttp.StatusInternalServerError, []byte(fmt.Sprintf("dispatch error: %s", err.Error())), err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < nethttp.StatusOK || res.StatusCode >= nethttp.StatusMultipleChoices {
+		body := new(bytes.Buffer)
+		_, _ = body.ReadFrom(res.Body)
+		return res.StatusCode, body.Bytes(), fmt.Errorf("unexpected HTTP response, expected 2xx, got %d", res.StatusCode)
+	}
+	return res.StatusCode, nil, nil
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2023 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adapter
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	nethttp "net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"k8s.io/utils/pointer"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+)
+
+func TestNewDelivery(t *testing.T) {
+	dls := apis.HTTP("dls.example.com")
+
+	d, err := NewDelivery(nil, dls)
+	if err != nil || d != nil {
+		t.Fatalf("NewDelivery(nil) = %v, %v, want nil, nil", d, err)
+	}
+
+	d, err = NewDelivery(&eventingduckv1.DeliverySpec{Retry: pointer.Int32(3)}, dls)
+	if err != nil {
+		t.Fatal("NewDelivery() =", err)
+	}
+	if d.RetryConfig.RetryMax != 3 {
+		t.Errorf("RetryMax = %d, want 3", d.RetryConfig.RetryMax)
+	}
+	if d.DeadLetterSink != nil {
+		t.Errorf("DeadLetterSink = %v, want nil without a dead letter sink in the spec", d.DeadLetterSink)
+	}
+
+	d, err = NewDelivery(&eventingduckv1.DeliverySpec{DeadLetterSink: &duckv1Destination}, dls)
+	if err != nil {
+		t.Fatal("NewDelivery() =", err)
+	}
+	if d.DeadLetterSink.String() != dls.String() {
+		t.Errorf("DeadLetterSink = %v, want %v", d.DeadLetterSink, dls)
+	}
+}
+
+func TestNewCloudEventsClient_sendWithDelivery(t *testing.T) {
+	testCases := map[string]struct {
+		sinkStatus       int
+		retry            int32
+		deadLetterSink   bool
+		wantACK          bool
+		wantSinkRequests int32
+		wantDeadLetters  int32
+		wantEventCount   int
+	}{
+		"sink accepts the event": {
+			sinkStatus:       nethttp.StatusAccepted,
+			retry:            2,
+			deadLetterSink:   true,
+			wantACK:          true,
+			wantSinkRequests: 1,
+			wantEventCount:   1,
+		},
+		"sink fails, event sent to the dead letter sink": {
+			sinkStatus:       nethttp.StatusServiceUnavailable,
+			retry:            2,
+			deadLetterSink:   true,
+			wantACK:          true,
+			wantSinkRequests: 3,
+			wantDeadLetters:  1,
+			wantEventCount:   1,
+		},
+		"sink fails without a dead letter sink": {
+			sinkStatus:       nethttp.StatusServiceUnavailable,
+			retry:            1,
+			wantSinkRequests: 2,
+			wantEventCount:   1,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			var sinkRequests, deadLetters int32
+			var errorCode, errorData string
+			sink := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+				atomic.AddInt32(&sinkRequests, 1)
+				w.WriteHeader(tc.sinkStatus)
+				_, _ = w.Write([]byte("service\nunavailable"))
+			}))
+			defer sink.Close()
+			dls := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+				atomic.AddInt32(&deadLetters, 1)
+				errorCode = r.Header.Get("ce-knativeerrorcode")
+				errorData = r.Header.Get("ce-knativeerrordata")
+				w.WriteHeader(nethttp.StatusAccepted)
+			}))
+			defer dls.Close()
+
+			spec := &eventingduckv1.DeliverySpec{
+				Retry:         pointer.Int32(tc.retry),
+				BackoffPolicy: &linear,
+				BackoffDelay:  pointer.String("PT0S"),
+			}
+			var dlsURI *apis.URL
+			if tc.deadLetterSink {
+				spec.DeadLetterSink = &duckv1Destination
+				dlsURI, _ = apis.ParseURL(dls.URL)
+			}
+			delivery, err := NewDelivery(spec, dlsURI)
+			if err != nil {
+				t.Fatal("NewDelivery() =", err)
+			}
+
+			reporter := &mockReporter{}
+			c, err := NewCloudEventsClient(sink.URL, nil, reporter)
+			if err != nil {
+				t.Fatal("NewCloudEventsClient() =", err)
+			}
+
+			event := cloudevents.NewEvent()
+			event.SetID("abc-123")
+			event.SetSource("unit/test")
+			event.SetType("unit.type")
+
+			res := c.Send(ContextWithDelivery(context.Background(), delivery), event)
+			if got := cloudevents.IsACK(res); got != tc.wantACK {
+				t.Errorf("IsACK(%v) = %v, want %v", res, got, tc.wantACK)
+			}
+			if got := atomic.LoadInt32(&sinkRequests); got != tc.wantSinkRequests {
+				t.Errorf("sink requests = %d, want %d", got, tc.wantSinkRequests)
+			}
+			if got := atomic.LoadInt32(&deadLetters); got != tc.wantDeadLetters {
+				t.Errorf("dead letter sink requests = %d, want %d", got, tc.wantDeadLetters)
+			}
+			if tc.wantDeadLetters > 0 && errorCode != "503" {
+				t.Errorf("knativeerrorcode = %q, want 503", errorCode)
+			}
+			// The control characters of the response body are removed, and it is encoded as base64.
+			if want := base64.StdEncoding.EncodeToString([]byte("serviceunavailable")); tc.wantDeadLetters > 0 && errorData != want {
+				t.Errorf("knativeerrordata = %q, want %q", errorData, want)
+			}
+			if reporter.eventCount != tc.wantEventCount {
+				t.Errorf("event count = %d, want %d", reporter.eventCount, tc.wantEventCount)
+			}
+		})
+	}
+}
+
+func TestNewCloudEventsClient_sendWithDeliveryTLS(t *testing.T) {
+	sink := httptest.NewTLSServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		w.WriteHeader(nethttp.StatusAccepted)
+	}))
+	defer sink.Close()
+	caCerts := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: sink.Certificate().Raw}))
+
+	delivery, err := NewDelivery(&eventingduckv1.DeliverySpec{Retry: pointer.Int32(1)}, nil)
+	if err != nil {
+		t.Fatal("NewDelivery() =", err)
+	}
+
+	testCases := map[string]struct {
+		caCerts *string
+		wantACK bool
+	}{
+		"sink trusted with the CA certs": {
+			caCerts: &caCerts,
+			wantACK: true,
+		},
+		"sink not trusted without the CA certs": {},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			c, err := NewCloudEventsClientCRStatus(&EnvConfig{Sink: sink.URL, CACerts: tc.caCerts}, &mockReporter{}, nil)
+			if err != nil {
+				t.Fatal("NewCloudEventsClientCRStatus() =", err)
+			}
+
+			event := cloudevents.NewEvent()
+			event.SetID("abc-123")
+			event.SetSource("unit/test")
+			event.SetType("unit.type")
+
+			res := c.Send(ContextWithDelivery(context.Background(), delivery), event)
+			if got := cloudevents.IsACK(res); got != tc.wantACK {
+				t.Errorf("IsACK(%v) = %v, want %v", res, got, tc.wantACK)
+			}
+		})
+	}
+}
+
+var (
+	linear            = eventingduckv1.BackoffPolicyLinear
+	duckv1Destination = duckv1.Destination{URI: apis.HTTP("dls.example.com")}
+)
@@ -18,6 +18,10 @@ package v1
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/rickb777/date/period"
 	"knative.dev/pkg/apis"
@@ -62,6 +66,25 @@ type DeliverySpec struct {
 	// +optional
 	BackoffDelay *string `json:"backoffDelay,omitempty"`
 
+	// BackoffMaxDelay is the maximum delay before retrying, it caps the delay computed by the
+	// backoff policy.
+	// More information on Duration format:
+	//  - https://www.iso.org/iso-8601-date-and-time-format.html
+	//  - https://en.wikipedia.org/wiki/ISO_8601
+	//
+	// Note: This API is EXPERIMENTAL and might be changed at anytime.
+	// +optional
+	BackoffMaxDelay *string `json:"backoffMaxDelay,omitempty"`
+
+	// BackoffJitter randomizes the backoff delays so that the senders retrying at the same time
+	// spread their retries (none, full, equal). With full jitter, the delay is random between zero
+	// and the backoff delay. With equal jitter, it is random between half the backoff delay and
+	// the backoff delay. Defaults to none.
+	//
+	// Note: This API is EXPERIMENTAL and might be changed at anytime.
+	// +optional
+	BackoffJitter *BackoffJitterType `json:"backoffJitter,omitempty"`
+
 	// RetryAfterMax provides an optional upper bound on the duration specified in a "Retry-After" header
 	// when calculating backoff times for retrying 429 and 503 response codes.  Setting the value to
 	// zero ("PT0S") can be used to opt-out of respecting "Retry-After" header values altogether. This
@@ -81,6 +104,144 @@ type DeliverySpec struct {
 	//
 	// +optional
 	RetryAfterMax *string `json:"retryAfterMax,omitempty"`
+
+	// Ordering is the ordering guarantee of the delivery (unordered, keyed).
+	// With the keyed ordering, events sharing the same "partitionkey" CloudEvents extension
+	// are delivered one at a time, in the order they are received, while events with different
+	// keys are still delivered concurrently. Events without the extension are delivered unordered.
+	// The ordering is best-effort: each replica delivering the events only orders the events it
+	// receives itself, and the events can already be reordered before they reach it, for example
+	// by the retries of a channel.
+	// This value depends on specific implementations (Brokers, Channels, etc.) choosing to provide
+	// this capability.
+	//
+	// Note: This API is EXPERIMENTAL and might be changed at anytime.
+	// +optional
+	Ordering *DeliveryOrderingType `json:"ordering,omitempty"`
+
+	// CircuitBreaker stops sending events to a destination that keeps failing. While the circuit
+	// breaker is open, events are sent straight to the dead letter sink, until a trial event is
+	// delivered successfully. Each subscriber has its own circuit breaker, even when subscribers
+	// share a destination. This value depends on specific implementations (Channels, etc.)
+	// choosing to provide this capability.
+	//
+	// Note: This API is EXPERIMENTAL and might be changed at anytime.
+	// +optional
+	CircuitBreaker *CircuitBreakerSpec `json:"circuitBreaker,omitempty"`
+
+	// RetryStatusCodes overrides which response status codes are retried. By default, responses
+	// with status codes 5xx, 404, 408, 409 and 429 are retried. The status codes that are not
+	// listed keep the default behaviour.
+	//
+	// Note: This API is EXPERIMENTAL and might be changed at anytime.
+	// +optional
+	RetryStatusCodes *RetryStatusCodesSpec `json:"retryStatusCodes,omitempty"`
+
+	// DeadLetterPayload is what is sent to the dead letter sink (original, envelope, reference).
+	// With original, the default, the event is sent with the knativeerrordest, knativeerrorcode
+	// and knativeerrordata extensions describing the failed delivery. With envelope, the event is
+	// wrapped in a "dev.knative.delivery.failed" event whose data describes the failed delivery,
+	// including the whole response body, the number of attempts and their timestamps. With
+	// reference, the wrapper only references the event, without its data.
+	//
+	// Note: This API is EXPERIMENTAL and might be changed at anytime.
+	// +optional
+	DeadLetterPayload *DeadLetterPayloadType `json:"deadLetterPayload,omitempty"`
+}
+
+// RetryStatusCodesSpec lists the response status codes that are retried, and those that are not.
+// The status codes are either a single code, like "425", or an inclusive range of codes, like
+// "500-599".
+type RetryStatusCodesSpec struct {
+	// Retryable are the status codes that are retried.
+	// +optional
+	Retryable []string `json:"retryable,omitempty"`
+
+	// NonRetryable are the status codes that are not retried. It takes precedence over Retryable.
+	// +optional
+	NonRetryable []string `json:"nonRetryable,omitempty"`
+}
+
+// StatusCodeRange is an inclusive range of response status codes.
+type StatusCodeRange struct {
+	From int
+	To   int
+}
+
+// Contains returns true if the status code is in the range.
+func (r StatusCodeRange) Contains(code int) bool {
+	return code >= r.From && code <= r.To
+}
+
+// ParseStatusCodeRange parses a status code, like "425", or a range of status codes, like "500-599".
+func ParseStatusCodeRange(s string) (StatusCodeRange, error) {
+	from, to, isRange := strings.Cut(s, "-")
+	r := StatusCodeRange{}
+	var err error
+	if r.From, err = strconv.Atoi(strings.TrimSpace(from)); err != nil {
+		return r, fmt.Errorf("invalid status code %q", s)
+	}
+	r.To = r.From
+	if isRange {
+		if r.To, err = strconv.Atoi(strings.TrimSpace(to)); err != nil {
+			return r, fmt.Errorf("invalid status code %q", s)
+		}
+	}
+	if r.From < 100 || r.To > 599 || r.From > r.To {
+		return r, fmt.Errorf("invalid status code %q, status codes must be between 100 and 599", s)
+	}
+	return r, nil
+}
+
+func (rs *RetryStatusCodesSpec) Validate(ctx context.Context) *apis.FieldError {
+	if rs == nil {
+		return nil
+	}
+	var errs *apis.FieldError
+	for i, code := range rs.Retryable {
+		if _, err := ParseStatusCodeRange(code); err != nil {
+			errs = errs.Also(apis.ErrInvalidArrayValue(code, "retryable", i))
+		}
+	}
+	for i, code := range rs.NonRetryable {
+		if _, err := ParseStatusCodeRange(code); err != nil {
+			errs = errs.Also(apis.ErrInvalidArrayValue(code, "nonRetryable", i))
+		}
+	}
+	return errs
+}
+
+// CircuitBreakerSpec configures the circuit breaker of a destination.
+type CircuitBreakerSpec struct {
+	// FailureThreshold is the number of consecutive failed deliveries, after retries, that opens
+	// the circuit breaker. Defaults to 5.
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+
+	// OpenDuration is how long the circuit breaker stays open before letting a trial event
+	// through. Defaults to 30 seconds.
+	// More information on Duration format:
+	//  - https://www.iso.org/iso-8601-date-and-time-format.html
+	//  - https://en.wikipedia.org/wiki/ISO_8601
+	// +optional
+	OpenDuration *string `json:"openDuration,omitempty"`
+}
+
+func (cb *CircuitBreakerSpec) Validate(ctx context.Context) *apis.FieldError {
+	if cb == nil {
+		return nil
+	}
+	var errs *apis.FieldError
+	if cb.FailureThreshold != nil && *cb.FailureThreshold < 1 {
+		errs = errs.Also(apis.ErrInvalidValue(*cb.FailureThreshold, "failureThreshold"))
+	}
+	if cb.OpenDuration != nil {
+		p, pe := period.Parse(*cb.OpenDuration)
+		if pe != nil || p.IsNegative() || p.IsZero() {
+			errs = errs.Also(apis.ErrInvalidValue(*cb.OpenDuration, "openDuration"))
+		}
+	}
+	return errs
 }
 
 func (ds *DeliverySpec) Validate(ctx context.Context) *apis.FieldError {
@@ -123,6 +284,34 @@ func (ds *DeliverySpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 
+	if ds.BackoffMaxDelay != nil {
+		if feature.FromContext(ctx).IsEnabled(feature.DeliveryBackoffLimits) {
+			p, pe := period.Parse(*ds.BackoffMaxDelay)
+			if pe != nil || p.IsNegative() || p.IsZero() {
+				errs = errs.Also(apis.ErrInvalidValue(*ds.BackoffMaxDelay, "backoffMaxDelay"))
+			} else if ds.BackoffDelay != nil {
+				if delay, de := period.Parse(*ds.BackoffDelay); de == nil && durationOf(p) < durationOf(delay) {
+					errs = errs.Also(apis.ErrGeneric("backoffMaxDelay must not be less than backoffDelay", "backoffMaxDelay"))
+				}
+			}
+		} else {
+			errs = errs.Also(apis.ErrDisallowedFields("backoffMaxDelay"))
+		}
+	}
+
+	if ds.BackoffJitter != nil {
+		if feature.FromContext(ctx).IsEnabled(feature.DeliveryBackoffLimits) {
+			switch *ds.BackoffJitter {
+			case BackoffJitterNone, BackoffJitterFull, BackoffJitterEqual:
+				// nothing
+			default:
+				errs = errs.Also(apis.ErrInvalidValue(*ds.BackoffJitter, "backoffJitter"))
+			}
+		} else {
+			errs = errs.Also(apis.ErrDisallowedFields("backoffJitter"))
+		}
+	}
+
 	if ds.RetryAfterMax != nil {
 		if feature.FromContext(ctx).IsEnabled(feature.DeliveryRetryAfter) {
 			p, me := period.Parse(*ds.RetryAfterMax)
@@ -134,6 +323,48 @@ func (ds *DeliverySpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 
+	if ds.Ordering != nil {
+		if feature.FromContext(ctx).IsEnabled(feature.DeliveryOrdering) {
+			switch *ds.Ordering {
+			case DeliveryOrderingUnordered, DeliveryOrderingKeyed:
+				// nothing
+			default:
+				errs = errs.Also(apis.ErrInvalidValue(*ds.Ordering, "ordering"))
+			}
+		} else {
+			errs = errs.Also(apis.ErrDisallowedFields("ordering"))
+		}
+	}
+
+	if ds.CircuitBreaker != nil {
+		if feature.FromContext(ctx).IsEnabled(feature.DeliveryCircuitBreaker) {
+			errs = errs.Also(ds.CircuitBreaker.Validate(ctx).ViaField("circuitBreaker"))
+		} else {
+			errs = errs.Also(apis.ErrDisallowedFields("circuitBreaker"))
+		}
+	}
+
+	if ds.DeadLetterPayload != nil {
+		if feature.FromContext(ctx).IsEnabled(feature.DeliveryDeadLetterPayload) {
+			switch *ds.DeadLetterPayload {
+			case DeadLetterPayloadOriginal, DeadLetterPayloadEnvelope, DeadLetterPayloadReference:
+				// nothing
+			default:
+				errs = errs.Also(apis.ErrInvalidValue(*ds.DeadLetterPayload, "deadLetterPayload"))
+			}
+		} else {
+			errs = errs.Also(apis.ErrDisallowedFields("deadLetterPayload"))
+		}
+	}
+
+	if ds.RetryStatusCodes != nil {
+		if feature.FromContext(ctx).IsEnabled(feature.DeliveryRetryStatusCodes) {
+			errs = errs.Also(ds.RetryStatusCodes.Validate(ctx).ViaField("retryStatusCodes"))
+		} else {
+			errs = errs.Also(apis.ErrDisallowedFields("retryStatusCodes"))
+		}
+	}
+
 	return errs
 }
 
@@ -148,6 +379,54 @@ const (
 	BackoffPolicyExponential BackoffPolicyType = "exponential"
 )
 
+// BackoffJitterType is the type for backoff jitters
+type BackoffJitterType string
+
+const (
+	// No jitter, the backoff delay is the one of the policy
+	BackoffJitterNone BackoffJitterType = "none"
+
+	// Full jitter, the backoff delay is random between zero and the one of the policy
+	BackoffJitterFull BackoffJitterType = "full"
+
+	// Equal jitter, the backoff delay is random between half the one of the policy and the one of the policy
+	BackoffJitterEqual BackoffJitterType = "equal"
+)
+
+// DeadLetterPayloadType is the type for the payloads sent to dead letter sinks
+type DeadLetterPayloadType string
+
+const (
+	// The original event, with the knativeerror* extensions
+	DeadLetterPayloadOriginal DeadLetterPayloadType = "original"
+
+	// An error event wrapping the original event
+	DeadLetterPayloadEnvelope DeadLetterPayloadType = "envelope"
+
+	// An error event referencing the original event
+	DeadLetterPayloadReference DeadLetterPayloadType = "reference"
+)
+
+func durationOf(p period.Period) time.Duration {
+	d, _ := p.Duration()
+	return d
+}
+
+// DeliveryOrderingType is the type for delivery ordering guarantees
+type DeliveryOrderingType string
+
+const (
+	// Unordered delivery, events are delivered concurrently
+	DeliveryOrderingUnordered DeliveryOrderingType = "unordered"
+
+	// Keyed delivery, events sharing the same partition key are delivered in order
+	DeliveryOrderingKeyed DeliveryOrderingType = "keyed"
+)
+
+// PartitionKeyExtension is the CloudEvents extension holding the partition key of an event.
+// See https://github.com/cloudevents/spec/blob/main/cloudevents/extensions/partitioning.md
+const PartitionKeyExtension = "partitionkey"
+
 // DeliveryStatus contains the Status of an object supporting delivery options. This type is intended to be embedded into a status struct.
 type DeliveryStatus struct {
 	// DeadLetterSink is a KReference that is the reference to the native, platform specific channel
@@ -34,11 +34,36 @@ func TestDeliverySpecValidation(t *testing.T) {
 	deliveryRetryAfterEnabledCtx := feature.ToContext(context.TODO(), feature.Flags{
 		feature.DeliveryRetryAfter: feature.Enabled,
 	})
+	deliveryOrderingEnabledCtx := feature.ToContext(context.TODO(), feature.Flags{
+		feature.DeliveryOrdering: feature.Enabled,
+	})
+	deliveryCircuitBreakerEnabledCtx := feature.ToContext(context.TODO(), feature.Flags{
+		feature.DeliveryCircuitBreaker: feature.Enabled,
+	})
+
+	deliveryBackoffLimitsEnabledCtx := feature.ToContext(context.TODO(), feature.Flags{
+		feature.DeliveryBackoffLimits: feature.Enabled,
+	})
+
+	deliveryRetryStatusCodesEnabledCtx := feature.ToContext(context.TODO(), feature.Flags{
+		feature.DeliveryRetryStatusCodes: feature.Enabled,
+	})
+
+	deliveryDeadLetterPayloadEnabledCtx := feature.ToContext(context.TODO(), feature.Flags{
+		feature.DeliveryDeadLetterPayload: feature.Enabled,
+	})
 
 	invalidString := "invalid time"
 	bop := BackoffPolicyExponential
+	keyed := DeliveryOrderingKeyed
+	invalidOrdering := DeliveryOrderingType("invalid")
 	validDuration := "PT2S"
 	invalidDuration := "1985-04-12T23:20:50.52Z"
+	shortDuration := "PT1S"
+	full := BackoffJitterFull
+	invalidJitter := BackoffJitterType("invalid")
+	envelope := DeadLetterPayloadEnvelope
+	invalidPayload := DeadLetterPayloadType("invalid")
 	tests := []struct {
 		name string
 		spec *DeliverySpec
@@ -141,6 +166,120 @@ func TestDeliverySpecValidation(t *testing.T) {
 		want: func() *apis.FieldError {
 			return apis.ErrDisallowedFields("retryAfterMax")
 		}(),
+	}, {
+		name: "valid ordering",
+		ctx:  deliveryOrderingEnabledCtx,
+		spec: &DeliverySpec{Ordering: &keyed},
+		want: nil,
+	}, {
+		name: "invalid ordering",
+		ctx:  deliveryOrderingEnabledCtx,
+		spec: &DeliverySpec{Ordering: &invalidOrdering},
+		want: func() *apis.FieldError {
+			return apis.ErrInvalidValue(invalidOrdering, "ordering")
+		}(),
+	}, {
+		name: "disabled feature with ordering",
+		spec: &DeliverySpec{Ordering: &keyed},
+		want: func() *apis.FieldError {
+			return apis.ErrDisallowedFields("ordering")
+		}(),
+	}, {
+		name: "valid circuit breaker",
+		ctx:  deliveryCircuitBreakerEnabledCtx,
+		spec: &DeliverySpec{CircuitBreaker: &CircuitBreakerSpec{
+			FailureThreshold: pointer.Int32(3),
+			OpenDuration:     &validDuration,
+		}},
+		want: nil,
+	}, {
+		name: "invalid circuit breaker",
+		ctx:  deliveryCircuitBreakerEnabledCtx,
+		spec: &DeliverySpec{CircuitBreaker: &CircuitBreakerSpec{
+			FailureThreshold: pointer.Int32(0),
+			OpenDuration:     &invalidDuration,
+		}},
+		want: func() *apis.FieldError {
+			return apis.ErrInvalidValue(0, "circuitBreaker.failureThreshold").
+				Also(apis.ErrInvalidValue(invalidDuration, "circuitBreaker.openDuration"))
+		}(),
+	}, {
+		name: "disabled feature with circuit breaker",
+		spec: &DeliverySpec{CircuitBreaker: &CircuitBreakerSpec{}},
+		want: func() *apis.FieldError {
+			return apis.ErrDisallowedFields("circuitBreaker")
+		}(),
+	}, {
+		name: "valid backoffMaxDelay and backoffJitter",
+		ctx:  deliveryBackoffLimitsEnabledCtx,
+		spec: &DeliverySpec{BackoffDelay: &shortDuration, BackoffMaxDelay: &validDuration, BackoffJitter: &full},
+		want: nil,
+	}, {
+		name: "invalid backoffMaxDelay and backoffJitter",
+		ctx:  deliveryBackoffLimitsEnabledCtx,
+		spec: &DeliverySpec{BackoffMaxDelay: &invalidDuration, BackoffJitter: &invalidJitter},
+		want: func() *apis.FieldError {
+			return apis.ErrInvalidValue(invalidDuration, "backoffMaxDelay").
+				Also(apis.ErrInvalidValue(invalidJitter, "backoffJitter"))
+		}(),
+	}, {
+		name: "backoffMaxDelay less than backoffDelay",
+		ctx:  deliveryBackoffLimitsEnabledCtx,
+		spec: &DeliverySpec{BackoffDelay: &validDuration, BackoffMaxDelay: &shortDuration},
+		want: func() *apis.FieldError {
+			return apis.ErrGeneric("backoffMaxDelay must not be less than backoffDelay", "backoffMaxDelay")
+		}(),
+	}, {
+		name: "disabled feature with backoffMaxDelay and backoffJitter",
+		spec: &DeliverySpec{BackoffMaxDelay: &validDuration, BackoffJitter: &full},
+		want: func() *apis.FieldError {
+			return apis.ErrDisallowedFields("backoffMaxDelay").
+				Also(apis.ErrDisallowedFields("backoffJitter"))
+		}(),
+	}, {
+		name: "valid retryStatusCodes",
+		ctx:  deliveryRetryStatusCodesEnabledCtx,
+		spec: &DeliverySpec{RetryStatusCodes: &RetryStatusCodesSpec{
+			Retryable:    []string{"425", "500-599"},
+			NonRetryable: []string{"409"},
+		}},
+		want: nil,
+	}, {
+		name: "invalid retryStatusCodes",
+		ctx:  deliveryRetryStatusCodesEnabledCtx,
+		spec: &DeliverySpec{RetryStatusCodes: &RetryStatusCodesSpec{
+			Retryable:    []string{"5xx", "599-500"},
+			NonRetryable: []string{"600"},
+		}},
+		want: func() *apis.FieldError {
+			return apis.ErrInvalidArrayValue("5xx", "retryStatusCodes.retryable", 0).
+				Also(apis.ErrInvalidArrayValue("599-500", "retryStatusCodes.retryable", 1)).
+				Also(apis.ErrInvalidArrayValue("600", "retryStatusCodes.nonRetryable", 0))
+		}(),
+	}, {
+		name: "disabled feature with retryStatusCodes",
+		spec: &DeliverySpec{RetryStatusCodes: &RetryStatusCodesSpec{Retryable: []string{"425"}}},
+		want: func() *apis.FieldError {
+			return apis.ErrDisallowedFields("retryStatusCodes")
+		}(),
+	}, {
+		name: "valid deadLetterPayload",
+		ctx:  deliveryDeadLetterPayloadEnabledCtx,
+		spec: &DeliverySpec{DeadLetterPayload: &envelope},
+		want: nil,
+	}, {
+		name: "invalid deadLetterPayload",
+		ctx:  deliveryDeadLetterPayloadEnabledCtx,
+		spec: &DeliverySpec{DeadLetterPayload: &invalidPayload},
+		want: func() *apis.FieldError {
+			return apis.ErrInvalidValue(invalidPayload, "deadLetterPayload")
+		}(),
+	}, {
+		name: "disabled feature with deadLetterPayload",
+		spec: &DeliverySpec{DeadLetterPayload: &envelope},
+		want: func() *apis.FieldError {
+			return apis.ErrDisallowedFields("deadLetterPayload")
+		}(),
 	}}
 
 	for _, test := range tests {
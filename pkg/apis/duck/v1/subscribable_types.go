@@ -36,6 +36,10 @@ type SubscriberSpec struct {
 	// UID is used to understand the origin of the subscriber.
 	// +optional
 	UID types.UID `json:"uid,omitempty"`
+	// Name is the name of the origin of the subscriber with uid:UID, in the namespace of the
+	// Subscribable.
+	// +optional
+	Name *string `json:"name,omitempty"`
 	// Generation of the origin of the subscriber with uid:UID.
 	// +optional
 	Generation int64 `json:"generation,omitempty"`
@@ -49,6 +53,10 @@ type SubscriberSpec struct {
 	// DeliverySpec contains options controlling the event delivery
 	// +optional
 	Delivery *DeliverySpec `json:"delivery,omitempty"`
+	// Filters is a set of SubscriptionsAPIFilter which, if any provided, are
+	// evaluated by the channel before sending the event to the subscriber.
+	// +optional
+	Filters []SubscriptionsAPIFilter `json:"filters,omitempty"`
 }
 
 // SubscriberStatus defines the status of a single subscriber to a Channel.
@@ -0,0 +1,206 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"regexp"
+
+	cesqlparser "github.com/cloudevents/sdk-go/sql/v2/parser"
+	"go.uber.org/zap"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/logging"
+)
+
+var (
+	// Only allow lowercase alphanumeric, starting with letters.
+	validAttributeName = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+)
+
+// SubscriptionsAPIFilter allows defining a filter expression using CloudEvents
+// Subscriptions API. If multiple filters are specified, then the same semantics
+// of SubscriptionsAPIFilter.All is applied. If no filter dialect or empty
+// object is specified, then the filter always accept the events.
+type SubscriptionsAPIFilter struct {
+	// All evaluates to true if all the nested expressions evaluate to true.
+	// It must contain at least one filter expression.
+	//
+	// +optional
+	All []SubscriptionsAPIFilter `json:"all,omitempty"`
+
+	// Any evaluates to true if at least one of the nested expressions evaluates
+	// to true. It must contain at least one filter expression.
+	//
+	// +optional
+	Any []SubscriptionsAPIFilter `json:"any,omitempty"`
+
+	// Not evaluates to true if the nested expression evaluates to false.
+	//
+	// +optional
+	Not *SubscriptionsAPIFilter `json:"not,omitempty"`
+
+	// Exact evaluates to true if the values of the matching CloudEvents attributes MUST
+	// all exactly match with the associated value String specified (case-sensitive).
+	// The keys are the names of the CloudEvents attributes to be matched,
+	// and their values are the String values to use in the comparison.
+	// The attribute name and value specified in the filter express MUST NOT be
+	// empty strings.
+	//
+	// +optional
+	Exact map[string]string `json:"exact,omitempty"`
+
+	// Prefix evaluates to true if the values of the matching CloudEvents attributes MUST
+	// all start with the associated value String specified (case sensitive).
+	// The keys are the names of the CloudEvents attributes to be matched,
+	// and their values are the String values to use in the comparison.
+	// The attribute name and value specified in the filter express MUST NOT be
+	// empty strings.
+	//
+	// +optional
+	Prefix map[string]string `json:"prefix,omitempty"`
+
+	// Suffix evaluates to true if the values of the matching CloudEvents attributes MUST
+	// all end with the associated value String specified (case sensitive).
+	// The keys are the names of the CloudEvents attributes to be matched,
+	// and their values are the String values to use in the comparison.
+	// The attribute name and value specified in the filter express MUST NOT be
+	// empty strings.
+	//
+	// +optional
+	Suffix map[string]string `json:"suffix,omitempty"`
+
+	// CESQL is a CloudEvents SQL expression that will be evaluated to true or false against each CloudEvent.
+	//
+	// +optional
+	CESQL string `json:"cesql,omitempty"`
+}
+
+func ValidateAttributesNames(attrs map[string]string) (errs *apis.FieldError) {
+	for attr := range attrs {
+		if !validAttributeName.MatchString(attr) {
+			errs = errs.Also(apis.ErrInvalidKeyName(attr, apis.CurrentField, "Attribute name must start with a letter and can only contain lowercase alphanumeric").ViaKey(attr))
+		}
+	}
+	return errs
+}
+
+func ValidateSubscriptionAPIFiltersList(ctx context.Context, filters []SubscriptionsAPIFilter) (errs *apis.FieldError) {
+	if filters == nil {
+		return nil
+	}
+
+	for i, f := range filters {
+		f := f
+		errs = errs.Also(ValidateSubscriptionAPIFilter(ctx, &f)).ViaIndex(i)
+	}
+	return errs
+}
+
+func ValidateCESQLExpression(ctx context.Context, expression string) (errs *apis.FieldError) {
+	if expression == "" {
+		return nil
+	}
+	// Need to recover in case Parse panics
+	defer func() {
+		if r := recover(); r != nil {
+			logging.FromContext(ctx).Debug("Warning! Calling CESQL Parser panicked. Treating expression as invalid.", zap.Any("recovered value", r), zap.String("CESQL", expression))
+			errs = apis.ErrInvalidValue(expression, apis.CurrentField)
+		}
+	}()
+
+	if _, err := cesqlparser.Parse(expression); err != nil {
+		return apis.ErrInvalidValue(expression, apis.CurrentField, err.Error())
+	}
+	return nil
+}
+
+func ValidateSubscriptionAPIFilter(ctx context.Context, filter *SubscriptionsAPIFilter) (errs *apis.FieldError) {
+	if filter == nil {
+		return nil
+	}
+	errs = errs.Also(
+		ValidateOneOf(filter),
+	).Also(
+		ValidateAttributesNames(filter.Exact).ViaField("exact"),
+	).Also(
+		ValidateAttributesNames(filter.Prefix).ViaField("prefix"),
+	).Also(
+		ValidateAttributesNames(filter.Suffix).ViaField("suffix"),
+	).Also(
+		ValidateSubscriptionAPIFiltersList(ctx, filter.All).ViaField("all"),
+	).Also(
+		ValidateSubscriptionAPIFiltersList(ctx, filter.Any).ViaField("any"),
+	).Also(
+		ValidateSubscriptionAPIFilter(ctx, filter.Not).ViaField("not"),
+	).Also(
+		ValidateCESQLExpression(ctx, filter.CESQL).ViaField("cesql"),
+	)
+	return errs
+}
+
+func ValidateOneOf(filter *SubscriptionsAPIFilter) (err *apis.FieldError) {
+	if filter != nil && hasMultipleDialects(filter) {
+		return apis.ErrGeneric("multiple dialects found, filters can have only one dialect set")
+	}
+	return nil
+}
+
+func hasMultipleDialects(filter *SubscriptionsAPIFilter) bool {
+	dialectFound := false
+	if len(filter.Exact) > 0 {
+		dialectFound = true
+	}
+	if len(filter.Prefix) > 0 {
+		if dialectFound {
+			return true
+		} else {
+			dialectFound = true
+		}
+	}
+	if len(filter.Suffix) > 0 {
+		if dialectFound {
+			return true
+		} else {
+			dialectFound = true
+		}
+	}
+	if len(filter.All) > 0 {
+		if dialectFound {
+			return true
+		} else {
+			dialectFound = true
+		}
+	}
+	if len(filter.Any) > 0 {
+		if dialectFound {
+			return true
+		} else {
+			dialectFound = true
+		}
+	}
+	if filter.Not != nil {
+		if dialectFound {
+			return true
+		} else {
+			dialectFound = true
+		}
+	}
+	if filter.CESQL != "" && dialectFound {
+		return true
+	}
+	return false
+}
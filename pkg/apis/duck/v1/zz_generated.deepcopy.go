@@ -130,6 +130,32 @@ func (in *ChannelableStatus) DeepCopy() *ChannelableStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CircuitBreakerSpec) DeepCopyInto(out *CircuitBreakerSpec) {
+	*out = *in
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.OpenDuration != nil {
+		in, out := &in.OpenDuration, &out.OpenDuration
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CircuitBreakerSpec.
+func (in *CircuitBreakerSpec) DeepCopy() *CircuitBreakerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CircuitBreakerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeliverySpec) DeepCopyInto(out *DeliverySpec) {
 	*out = *in
@@ -158,11 +184,41 @@ func (in *DeliverySpec) DeepCopyInto(out *DeliverySpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.BackoffMaxDelay != nil {
+		in, out := &in.BackoffMaxDelay, &out.BackoffMaxDelay
+		*out = new(string)
+		**out = **in
+	}
+	if in.BackoffJitter != nil {
+		in, out := &in.BackoffJitter, &out.BackoffJitter
+		*out = new(BackoffJitterType)
+		**out = **in
+	}
 	if in.RetryAfterMax != nil {
 		in, out := &in.RetryAfterMax, &out.RetryAfterMax
 		*out = new(string)
 		**out = **in
 	}
+	if in.Ordering != nil {
+		in, out := &in.Ordering, &out.Ordering
+		*out = new(DeliveryOrderingType)
+		**out = **in
+	}
+	if in.CircuitBreaker != nil {
+		in, out := &in.CircuitBreaker, &out.CircuitBreaker
+		*out = new(CircuitBreakerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RetryStatusCodes != nil {
+		in, out := &in.RetryStatusCodes, &out.RetryStatusCodes
+		*out = new(RetryStatusCodesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeadLetterPayload != nil {
+		in, out := &in.DeadLetterPayload, &out.DeadLetterPayload
+		*out = new(DeadLetterPayloadType)
+		**out = **in
+	}
 	return
 }
 
@@ -197,6 +253,48 @@ func (in *DeliveryStatus) DeepCopy() *DeliveryStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryStatusCodesSpec) DeepCopyInto(out *RetryStatusCodesSpec) {
+	*out = *in
+	if in.Retryable != nil {
+		in, out := &in.Retryable, &out.Retryable
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NonRetryable != nil {
+		in, out := &in.NonRetryable, &out.NonRetryable
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryStatusCodesSpec.
+func (in *RetryStatusCodesSpec) DeepCopy() *RetryStatusCodesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryStatusCodesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatusCodeRange) DeepCopyInto(out *StatusCodeRange) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusCodeRange.
+func (in *StatusCodeRange) DeepCopy() *StatusCodeRange {
+	if in == nil {
+		return nil
+	}
+	out := new(StatusCodeRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Subscribable) DeepCopyInto(out *Subscribable) {
 	*out = *in
@@ -305,6 +403,11 @@ func (in *SubscribableStatus) DeepCopy() *SubscribableStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubscriberSpec) DeepCopyInto(out *SubscriberSpec) {
 	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
 	if in.SubscriberURI != nil {
 		in, out := &in.SubscriberURI, &out.SubscriberURI
 		*out = new(apis.URL)
@@ -320,6 +423,13 @@ func (in *SubscriberSpec) DeepCopyInto(out *SubscriberSpec) {
 		*out = new(DeliverySpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]SubscriptionsAPIFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -348,3 +458,59 @@ func (in *SubscriberStatus) DeepCopy() *SubscriberStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubscriptionsAPIFilter) DeepCopyInto(out *SubscriptionsAPIFilter) {
+	*out = *in
+	if in.All != nil {
+		in, out := &in.All, &out.All
+		*out = make([]SubscriptionsAPIFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Any != nil {
+		in, out := &in.Any, &out.Any
+		*out = make([]SubscriptionsAPIFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Not != nil {
+		in, out := &in.Not, &out.Not
+		*out = new(SubscriptionsAPIFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Exact != nil {
+		in, out := &in.Exact, &out.Exact
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Suffix != nil {
+		in, out := &in.Suffix, &out.Suffix
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionsAPIFilter.
+func (in *SubscriptionsAPIFilter) DeepCopy() *SubscriptionsAPIFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(SubscriptionsAPIFilter)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -43,6 +43,16 @@ func (source *DeliverySpec) ConvertTo(ctx context.Context, to apis.Convertible)
 				return fmt.Errorf("unknown BackoffPolicy, got: %q", *source.BackoffPolicy)
 			}
 		}
+		sink.BackoffMaxDelay = source.BackoffMaxDelay
+		if source.BackoffJitter != nil {
+			switch *source.BackoffJitter {
+			case BackoffJitterNone, BackoffJitterFull, BackoffJitterEqual:
+				jitter := eventingduckv1.BackoffJitterType(*source.BackoffJitter)
+				sink.BackoffJitter = &jitter
+			default:
+				return fmt.Errorf("unknown BackoffJitter, got: %q", *source.BackoffJitter)
+			}
+		}
 		sink.DeadLetterSink = source.DeadLetterSink
 		return nil
 	default:
@@ -69,6 +79,16 @@ func (sink *DeliverySpec) ConvertFrom(ctx context.Context, from apis.Convertible
 			}
 
 		}
+		sink.BackoffMaxDelay = source.BackoffMaxDelay
+		if source.BackoffJitter != nil {
+			switch *source.BackoffJitter {
+			case eventingduckv1.BackoffJitterNone, eventingduckv1.BackoffJitterFull, eventingduckv1.BackoffJitterEqual:
+				jitter := BackoffJitterType(*source.BackoffJitter)
+				sink.BackoffJitter = &jitter
+			default:
+				return fmt.Errorf("unknown BackoffJitter, got: %q", *source.BackoffJitter)
+			}
+		}
 		sink.DeadLetterSink = source.DeadLetterSink
 		return nil
 	default:
@@ -45,6 +45,10 @@ func TestDeliverySpecConversion(t *testing.T) {
 	var backoffPolicyExp BackoffPolicyType = BackoffPolicyExponential
 	var backoffPolicyBad BackoffPolicyType = "garbage"
 	badPolicyString := `unknown BackoffPolicy, got: "garbage"`
+	backoffMaxDelay := "PT1M"
+	var backoffJitter BackoffJitterType = BackoffJitterFull
+	var backoffJitterBad BackoffJitterType = "garbage"
+	badJitterString := `unknown BackoffJitter, got: "garbage"`
 
 	tests := []struct {
 		name string
@@ -93,6 +97,21 @@ func TestDeliverySpecConversion(t *testing.T) {
 			},
 		},
 		err: &badPolicyString,
+	}, {
+		name: "with backoff max delay and jitter",
+		in: &DeliverySpec{
+			Retry:           &retryCount,
+			BackoffPolicy:   &backoffPolicyExp,
+			BackoffMaxDelay: &backoffMaxDelay,
+			BackoffJitter:   &backoffJitter,
+		},
+	}, {
+		name: "with bad backoff jitter",
+		in: &DeliverySpec{
+			Retry:         &retryCount,
+			BackoffJitter: &backoffJitterBad,
+		},
+		err: &badJitterString,
 	}}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -122,6 +141,10 @@ func TestDeliverySpecConversionV1(t *testing.T) {
 	var backoffPolicyExp v1.BackoffPolicyType = v1.BackoffPolicyExponential
 	var backoffPolicyBad v1.BackoffPolicyType = "garbage"
 	badPolicyString := `unknown BackoffPolicy, got: "garbage"`
+	backoffMaxDelay := "PT1M"
+	var backoffJitter v1.BackoffJitterType = v1.BackoffJitterEqual
+	var backoffJitterBad v1.BackoffJitterType = "garbage"
+	badJitterString := `unknown BackoffJitter, got: "garbage"`
 
 	tests := []struct {
 		name string
@@ -170,6 +193,21 @@ func TestDeliverySpecConversionV1(t *testing.T) {
 			},
 		},
 		err: &badPolicyString,
+	}, {
+		name: "with backoff max delay and jitter",
+		in: &v1.DeliverySpec{
+			Retry:           &retryCount,
+			BackoffPolicy:   &backoffPolicyExp,
+			BackoffMaxDelay: &backoffMaxDelay,
+			BackoffJitter:   &backoffJitter,
+		},
+	}, {
+		name: "with bad backoff jitter",
+		in: &v1.DeliverySpec{
+			Retry:         &retryCount,
+			BackoffJitter: &backoffJitterBad,
+		},
+		err: &badJitterString,
 	}}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
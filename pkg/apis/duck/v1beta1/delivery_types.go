@@ -18,11 +18,14 @@ package v1beta1
 
 import (
 	"context"
+	"time"
 
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 
 	"github.com/rickb777/date/period"
+
+	"knative.dev/eventing/pkg/apis/feature"
 )
 
 // DeliverySpec contains the delivery options for event senders,
@@ -58,6 +61,22 @@ type DeliverySpec struct {
 	// For exponential policy, backoff delay is backoffDelay*2^<numberOfRetries>.
 	// +optional
 	BackoffDelay *string `json:"backoffDelay,omitempty"`
+
+	// BackoffMaxDelay is the maximum delay before retrying, it caps the delay computed by the
+	// backoff policy.
+	// More information on Duration format:
+	//  - https://www.iso.org/iso-8601-date-and-time-format.html
+	//  - https://en.wikipedia.org/wiki/ISO_8601
+	//
+	// Note: This API is EXPERIMENTAL and might be changed at anytime.
+	// +optional
+	BackoffMaxDelay *string `json:"backoffMaxDelay,omitempty"`
+
+	// BackoffJitter randomizes the backoff delays (none, full, equal).
+	//
+	// Note: This API is EXPERIMENTAL and might be changed at anytime.
+	// +optional
+	BackoffJitter *BackoffJitterType `json:"backoffJitter,omitempty"`
 }
 
 func (ds *DeliverySpec) Validate(ctx context.Context) *apis.FieldError {
@@ -95,9 +114,42 @@ func (ds *DeliverySpec) Validate(ctx context.Context) *apis.FieldError {
 			errs = errs.Also(apis.ErrInvalidValue(*ds.BackoffDelay, "backoffDelay"))
 		}
 	}
+
+	if ds.BackoffMaxDelay != nil {
+		if feature.FromContext(ctx).IsEnabled(feature.DeliveryBackoffLimits) {
+			p, pe := period.Parse(*ds.BackoffMaxDelay)
+			if pe != nil || p.IsNegative() || p.IsZero() {
+				errs = errs.Also(apis.ErrInvalidValue(*ds.BackoffMaxDelay, "backoffMaxDelay"))
+			} else if ds.BackoffDelay != nil {
+				if delay, de := period.Parse(*ds.BackoffDelay); de == nil && durationOf(p) < durationOf(delay) {
+					errs = errs.Also(apis.ErrGeneric("backoffMaxDelay must not be less than backoffDelay", "backoffMaxDelay"))
+				}
+			}
+		} else {
+			errs = errs.Also(apis.ErrDisallowedFields("backoffMaxDelay"))
+		}
+	}
+
+	if ds.BackoffJitter != nil {
+		if feature.FromContext(ctx).IsEnabled(feature.DeliveryBackoffLimits) {
+			switch *ds.BackoffJitter {
+			case BackoffJitterNone, BackoffJitterFull, BackoffJitterEqual:
+				// nothing
+			default:
+				errs = errs.Also(apis.ErrInvalidValue(*ds.BackoffJitter, "backoffJitter"))
+			}
+		} else {
+			errs = errs.Also(apis.ErrDisallowedFields("backoffJitter"))
+		}
+	}
 	return errs
 }
 
+func durationOf(p period.Period) time.Duration {
+	d, _ := p.Duration()
+	return d
+}
+
 // BackoffPolicyType is the type for backoff policies
 type BackoffPolicyType string
 
@@ -109,6 +161,20 @@ const (
 	BackoffPolicyExponential BackoffPolicyType = "exponential"
 )
 
+// BackoffJitterType is the type for backoff jitters
+type BackoffJitterType string
+
+const (
+	// No jitter
+	BackoffJitterNone BackoffJitterType = "none"
+
+	// Full jitter
+	BackoffJitterFull BackoffJitterType = "full"
+
+	// Equal jitter
+	BackoffJitterEqual BackoffJitterType = "equal"
+)
+
 // DeliveryStatus contains the Status of an object supporting delivery options.
 type DeliveryStatus struct {
 	// DeadLetterChannel is a KReference that is the reference to the native, platform specific channel
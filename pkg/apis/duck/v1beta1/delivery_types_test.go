@@ -23,6 +23,8 @@ import (
 	"k8s.io/utils/pointer"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"knative.dev/eventing/pkg/apis/feature"
 )
 
 func TestDeliverySpecValidation(t *testing.T) {
@@ -30,8 +32,16 @@ func TestDeliverySpecValidation(t *testing.T) {
 	bop := BackoffPolicyExponential
 	validDuration := "PT2S"
 	invalidDuration := "1985-04-12T23:20:50.52Z"
+	zeroDuration := "PT0S"
+	jitter := BackoffJitterEqual
+	invalidJitter := BackoffJitterType("invalid")
+	longDuration := "PT10S"
+	backoffLimitsEnabledCtx := feature.ToContext(context.TODO(), feature.Flags{
+		feature.DeliveryBackoffLimits: feature.Enabled,
+	})
 	tests := []struct {
 		name string
+		ctx  context.Context
 		spec *DeliverySpec
 		want *apis.FieldError
 	}{{
@@ -86,11 +96,41 @@ func TestDeliverySpecValidation(t *testing.T) {
 	}, {
 		name: "valid retry 1",
 		spec: &DeliverySpec{Retry: pointer.Int32(1)},
+	}, {
+		name: "backoffMaxDelay and backoffJitter without the feature",
+		spec: &DeliverySpec{BackoffMaxDelay: &validDuration, BackoffJitter: &jitter},
+		want: func() *apis.FieldError {
+			return apis.ErrDisallowedFields("backoffMaxDelay").
+				Also(apis.ErrDisallowedFields("backoffJitter"))
+		}(),
+	}, {
+		name: "valid backoffMaxDelay and backoffJitter",
+		ctx:  backoffLimitsEnabledCtx,
+		spec: &DeliverySpec{BackoffMaxDelay: &validDuration, BackoffJitter: &jitter},
+	}, {
+		name: "invalid backoffMaxDelay and backoffJitter",
+		ctx:  backoffLimitsEnabledCtx,
+		spec: &DeliverySpec{BackoffMaxDelay: &zeroDuration, BackoffJitter: &invalidJitter},
+		want: func() *apis.FieldError {
+			return apis.ErrInvalidValue(zeroDuration, "backoffMaxDelay").
+				Also(apis.ErrInvalidValue(invalidJitter, "backoffJitter"))
+		}(),
+	}, {
+		name: "backoffMaxDelay less than backoffDelay",
+		ctx:  backoffLimitsEnabledCtx,
+		spec: &DeliverySpec{BackoffDelay: &longDuration, BackoffMaxDelay: &validDuration},
+		want: func() *apis.FieldError {
+			return apis.ErrGeneric("backoffMaxDelay must not be less than backoffDelay", "backoffMaxDelay")
+		}(),
 	}}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			got := test.spec.Validate(context.TODO())
+			ctx := test.ctx
+			if ctx == nil {
+				ctx = context.TODO()
+			}
+			got := test.spec.Validate(ctx)
 			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
 				t.Error("DeliverySpec.Validate (-want, +got) =", diff)
 			}
@@ -162,6 +162,16 @@ func (in *DeliverySpec) DeepCopyInto(out *DeliverySpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.BackoffMaxDelay != nil {
+		in, out := &in.BackoffMaxDelay, &out.BackoffMaxDelay
+		*out = new(string)
+		**out = **in
+	}
+	if in.BackoffJitter != nil {
+		in, out := &in.BackoffJitter, &out.BackoffJitter
+		*out = new(BackoffJitterType)
+		**out = **in
+	}
 	return
 }
 
@@ -74,6 +74,59 @@ const (
 	// annotation key used to specify the name of the channel for
 	// the triggers to subscribe to.
 	BrokerChannelNameStatusAnnotationKey = "knative.dev/channelName"
+
+	// EventTypePolicyAnnotationKey is the annotation key on Brokers to
+	// indicate which events the Broker ingress accepts.
+	// Valid values are: enforced.
+	EventTypePolicyAnnotationKey = GroupName + "/eventtype.policy"
+
+	// EventTypePolicyEnforced indicates that the Broker ingress only
+	// accepts events matching an EventType referring to the Broker.
+	EventTypePolicyEnforced = "enforced"
+
+	// EventTypeValidationAnnotationKey is the annotation key on Brokers to
+	// indicate how the Broker ingress handles events whose data doesn't
+	// conform to the schema of the matching EventTypes. Events whose data
+	// isn't JSON don't conform to any schema.
+	// Valid values are: reject, deadletter.
+	EventTypeValidationAnnotationKey = GroupName + "/eventtype.validation"
+
+	// EventTypeValidationReject indicates that the Broker ingress rejects
+	// the non-conforming events.
+	EventTypeValidationReject = "reject"
+
+	// EventTypeValidationDeadLetter indicates that the Broker ingress sends
+	// the non-conforming events to the Broker's dead letter sink.
+	EventTypeValidationDeadLetter = "deadletter"
+
+	// BrokerRateLimitAnnotationKey is the annotation key on Brokers to
+	// limit the rate, in events per second, accepted by the Broker ingress.
+	BrokerRateLimitAnnotationKey = GroupName + "/ingress.rate-limit"
+
+	// BrokerRateLimitBurstAnnotationKey is the annotation key on Brokers to
+	// set the number of events accepted in a burst above the Broker rate limit.
+	BrokerRateLimitBurstAnnotationKey = GroupName + "/ingress.rate-limit-burst"
+
+	// BrokerSourceRateLimitAnnotationKey is the annotation key on Brokers to
+	// limit the rate, in events per second, accepted by the Broker ingress
+	// from each event source.
+	BrokerSourceRateLimitAnnotationKey = GroupName + "/ingress.source-rate-limit"
+
+	// BrokerSourceRateLimitBurstAnnotationKey is the annotation key on Brokers to
+	// set the number of events accepted in a burst above the source rate limit.
+	BrokerSourceRateLimitBurstAnnotationKey = GroupName + "/ingress.source-rate-limit-burst"
+
+	// BrokerDedupWindowAnnotationKey is the annotation key on Brokers to
+	// set the duration during which the Broker ingress drops the events
+	// with the same source and id as an event it already accepted.
+	// Each Broker ingress replica only drops the duplicates of the events
+	// it accepted itself.
+	BrokerDedupWindowAnnotationKey = GroupName + "/ingress.dedup-window"
+
+	// BrokerIngressConfigLabelKey is the label key on the ConfigMaps referenced
+	// by the Broker config to let the Broker ingress read its settings from them.
+	// The Broker ingress only watches the ConfigMaps with this label set to "true".
+	BrokerIngressConfigLabelKey = GroupName + "/broker-ingress-config"
 )
 
 var (
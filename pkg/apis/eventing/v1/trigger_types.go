@@ -104,6 +104,18 @@ type TriggerSpec struct {
 	// Delivery contains the delivery spec for this specific trigger.
 	// +optional
 	Delivery *eventingduckv1.DeliverySpec `json:"delivery,omitempty"`
+
+	// Reply is an experimental field, it is the destination the replies of
+	// the Subscriber are sent to, for example another Broker. When not
+	// specified, the replies are sent back to the Broker of the Trigger.
+	// +optional
+	Reply *duckv1.Destination `json:"reply,omitempty"`
+
+	// DiscardReplies is an experimental field, when true the replies of the
+	// Subscriber are dropped instead of being sent back to the Broker. It
+	// can't be used together with Reply.
+	// +optional
+	DiscardReplies *bool `json:"discardReplies,omitempty"`
 }
 
 type TriggerFilter struct {
@@ -118,62 +130,8 @@ type TriggerFilter struct {
 }
 
 // SubscriptionsAPIFilter allows defining a filter expression using CloudEvents
-// Subscriptions API. If multiple filters are specified, then the same semantics
-// of SubscriptionsAPIFilter.All is applied. If no filter dialect or empty
-// object is specified, then the filter always accept the events.
-type SubscriptionsAPIFilter struct {
-	// All evaluates to true if all the nested expressions evaluate to true.
-	// It must contain at least one filter expression.
-	//
-	// +optional
-	All []SubscriptionsAPIFilter `json:"all,omitempty"`
-
-	// Any evaluates to true if at least one of the nested expressions evaluates
-	// to true. It must contain at least one filter expression.
-	//
-	// +optional
-	Any []SubscriptionsAPIFilter `json:"any,omitempty"`
-
-	// Not evaluates to true if the nested expression evaluates to false.
-	//
-	// +optional
-	Not *SubscriptionsAPIFilter `json:"not,omitempty"`
-
-	// Exact evaluates to true if the values of the matching CloudEvents attributes MUST
-	// all exactly match with the associated value String specified (case-sensitive).
-	// The keys are the names of the CloudEvents attributes to be matched,
-	// and their values are the String values to use in the comparison.
-	// The attribute name and value specified in the filter express MUST NOT be
-	// empty strings.
-	//
-	// +optional
-	Exact map[string]string `json:"exact,omitempty"`
-
-	// Prefix evaluates to true if the values of the matching CloudEvents attributes MUST
-	// all start with the associated value String specified (case sensitive).
-	// The keys are the names of the CloudEvents attributes to be matched,
-	// and their values are the String values to use in the comparison.
-	// The attribute name and value specified in the filter express MUST NOT be
-	// empty strings.
-	//
-	// +optional
-	Prefix map[string]string `json:"prefix,omitempty"`
-
-	// Suffix evaluates to true if the values of the matching CloudEvents attributes MUST
-	// all end with the associated value String specified (case sensitive).
-	// The keys are the names of the CloudEvents attributes to be matched,
-	// and their values are the String values to use in the comparison.
-	// The attribute name and value specified in the filter express MUST NOT be
-	// empty strings.
-	//
-	// +optional
-	Suffix map[string]string `json:"suffix,omitempty"`
-
-	// CESQL is a CloudEvents SQL expression that will be evaluated to true or false against each CloudEvent.
-	//
-	// +optional
-	CESQL string `json:"cesql,omitempty"`
-}
+// Subscriptions API, it is shared with the Subscriptions.
+type SubscriptionsAPIFilter = eventingduckv1.SubscriptionsAPIFilter
 
 // TriggerFilterAttributes is a map of context attribute names to values for
 // filtering by equality. Only exact matches will pass the filter. You can use
@@ -191,6 +149,10 @@ type TriggerStatus struct {
 	// +optional
 	SubscriberURI *apis.URL `json:"subscriberUri,omitempty"`
 
+	// ReplyURI is the resolved URI of the reply destination for this Trigger.
+	// +optional
+	ReplyURI *apis.URL `json:"replyUri,omitempty"`
+
 	// DeliveryStatus contains a resolved URL to the dead letter sink address, and any other
 	// resolved delivery options.
 	eventingduckv1.DeliveryStatus `json:",inline"`
@@ -20,23 +20,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"regexp"
 
-	cesqlparser "github.com/cloudevents/sdk-go/sql/v2/parser"
-	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/kmp"
-	"knative.dev/pkg/logging"
 
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/apis/feature"
 )
 
-var (
-	// Only allow lowercase alphanumeric, starting with letters.
-	validAttributeName = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
-)
-
 // Validate the Trigger.
 func (t *Trigger) Validate(ctx context.Context) *apis.FieldError {
 	errs := t.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec")
@@ -63,9 +55,33 @@ func (ts *TriggerSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 		ts.Subscriber.Validate(ctx).ViaField("subscriber"),
 	).Also(
 		ts.Delivery.Validate(ctx).ViaField("delivery"),
+	).Also(
+		ts.validateReply(ctx),
 	)
 }
 
+func (ts *TriggerSpec) validateReply(ctx context.Context) (errs *apis.FieldError) {
+	if ts.Reply == nil && ts.DiscardReplies == nil {
+		return nil
+	}
+	if !feature.FromContext(ctx).IsEnabled(feature.TriggerReply) {
+		if ts.Reply != nil {
+			errs = errs.Also(apis.ErrDisallowedFields("reply"))
+		}
+		if ts.DiscardReplies != nil {
+			errs = errs.Also(apis.ErrDisallowedFields("discardReplies"))
+		}
+		return errs
+	}
+	if ts.Reply != nil && ts.DiscardReplies != nil && *ts.DiscardReplies {
+		return apis.ErrMultipleOneOf("reply", "discardReplies")
+	}
+	if ts.Reply != nil {
+		errs = errs.Also(ts.Reply.Validate(ctx).ViaField("reply"))
+	}
+	return errs
+}
+
 // CheckImmutableFields checks that any immutable fields were not changed.
 func (t *Trigger) CheckImmutableFields(ctx context.Context, original *Trigger) *apis.FieldError {
 	if original == nil {
@@ -160,117 +176,24 @@ func ValidateAttributeFilters(filter *TriggerFilter) (errs *apis.FieldError) {
 }
 
 func ValidateAttributesNames(attrs map[string]string) (errs *apis.FieldError) {
-	for attr := range attrs {
-		if !validAttributeName.MatchString(attr) {
-			errs = errs.Also(apis.ErrInvalidKeyName(attr, apis.CurrentField, "Attribute name must start with a letter and can only contain lowercase alphanumeric").ViaKey(attr))
-		}
-	}
-	return errs
+	return eventingduckv1.ValidateAttributesNames(attrs)
 }
 
 func ValidateSubscriptionAPIFiltersList(ctx context.Context, filters []SubscriptionsAPIFilter) (errs *apis.FieldError) {
 	if filters == nil || !feature.FromContext(ctx).IsEnabled(feature.NewTriggerFilters) {
 		return nil
 	}
-
-	for i, f := range filters {
-		f := f
-		errs = errs.Also(ValidateSubscriptionAPIFilter(ctx, &f)).ViaIndex(i)
-	}
-	return errs
+	return eventingduckv1.ValidateSubscriptionAPIFiltersList(ctx, filters)
 }
 
 func ValidateCESQLExpression(ctx context.Context, expression string) (errs *apis.FieldError) {
-	if expression == "" {
-		return nil
-	}
-	// Need to recover in case Parse panics
-	defer func() {
-		if r := recover(); r != nil {
-			logging.FromContext(ctx).Debug("Warning! Calling CESQL Parser panicked. Treating expression as invalid.", zap.Any("recovered value", r), zap.String("CESQL", expression))
-			errs = apis.ErrInvalidValue(expression, apis.CurrentField)
-		}
-	}()
-
-	if _, err := cesqlparser.Parse(expression); err != nil {
-		return apis.ErrInvalidValue(expression, apis.CurrentField, err.Error())
-	}
-	return nil
+	return eventingduckv1.ValidateCESQLExpression(ctx, expression)
 }
 
 func ValidateSubscriptionAPIFilter(ctx context.Context, filter *SubscriptionsAPIFilter) (errs *apis.FieldError) {
-	if filter == nil {
-		return nil
-	}
-	errs = errs.Also(
-		ValidateOneOf(filter),
-	).Also(
-		ValidateAttributesNames(filter.Exact).ViaField("exact"),
-	).Also(
-		ValidateAttributesNames(filter.Prefix).ViaField("prefix"),
-	).Also(
-		ValidateAttributesNames(filter.Suffix).ViaField("suffix"),
-	).Also(
-		ValidateSubscriptionAPIFiltersList(ctx, filter.All).ViaField("all"),
-	).Also(
-		ValidateSubscriptionAPIFiltersList(ctx, filter.Any).ViaField("any"),
-	).Also(
-		ValidateSubscriptionAPIFilter(ctx, filter.Not).ViaField("not"),
-	).Also(
-		ValidateCESQLExpression(ctx, filter.CESQL).ViaField("cesql"),
-	)
-	return errs
+	return eventingduckv1.ValidateSubscriptionAPIFilter(ctx, filter)
 }
 
 func ValidateOneOf(filter *SubscriptionsAPIFilter) (err *apis.FieldError) {
-	if filter != nil && hasMultipleDialects(filter) {
-		return apis.ErrGeneric("multiple dialects found, filters can have only one dialect set")
-	}
-	return nil
-}
-
-func hasMultipleDialects(filter *SubscriptionsAPIFilter) bool {
-	dialectFound := false
-	if len(filter.Exact) > 0 {
-		dialectFound = true
-	}
-	if len(filter.Prefix) > 0 {
-		if dialectFound {
-			return true
-		} else {
-			dialectFound = true
-		}
-	}
-	if len(filter.Suffix) > 0 {
-		if dialectFound {
-			return true
-		} else {
-			dialectFound = true
-		}
-	}
-	if len(filter.All) > 0 {
-		if dialectFound {
-			return true
-		} else {
-			dialectFound = true
-		}
-	}
-	if len(filter.Any) > 0 {
-		if dialectFound {
-			return true
-		} else {
-			dialectFound = true
-		}
-	}
-	if filter.Not != nil {
-		if dialectFound {
-			return true
-		} else {
-			dialectFound = true
-		}
-	}
-	if filter.CESQL != "" && dialectFound {
-		return true
-	}
-	return false
+	return eventingduckv1.ValidateOneOf(filter)
 }
@@ -487,6 +487,90 @@ func TestTriggerSpecValidation(t *testing.T) {
 	}
 }
 
+func TestTriggerSpecReplyValidation(t *testing.T) {
+	triggerReplyEnabledCtx := feature.ToContext(context.TODO(), feature.Flags{
+		feature.TriggerReply: feature.Enabled,
+	})
+	discard, keep := true, false
+	tests := []struct {
+		name string
+		ctx  context.Context
+		ts   *TriggerSpec
+		want *apis.FieldError
+	}{{
+		name: "reply disabled",
+		ctx:  context.TODO(),
+		ts: &TriggerSpec{
+			Broker:     "test_broker",
+			Subscriber: validSubscriber,
+			Reply:      &validSubscriber,
+		},
+		want: apis.ErrDisallowedFields("reply"),
+	}, {
+		name: "discard replies disabled",
+		ctx:  context.TODO(),
+		ts: &TriggerSpec{
+			Broker:         "test_broker",
+			Subscriber:     validSubscriber,
+			DiscardReplies: &discard,
+		},
+		want: apis.ErrDisallowedFields("discardReplies"),
+	}, {
+		name: "valid reply",
+		ctx:  triggerReplyEnabledCtx,
+		ts: &TriggerSpec{
+			Broker:     "test_broker",
+			Subscriber: validSubscriber,
+			Reply:      &validSubscriber,
+		},
+	}, {
+		name: "valid discard replies",
+		ctx:  triggerReplyEnabledCtx,
+		ts: &TriggerSpec{
+			Broker:         "test_broker",
+			Subscriber:     validSubscriber,
+			DiscardReplies: &discard,
+		},
+	}, {
+		name: "reply without discarding replies",
+		ctx:  triggerReplyEnabledCtx,
+		ts: &TriggerSpec{
+			Broker:         "test_broker",
+			Subscriber:     validSubscriber,
+			Reply:          &validSubscriber,
+			DiscardReplies: &keep,
+		},
+	}, {
+		name: "reply and discard replies",
+		ctx:  triggerReplyEnabledCtx,
+		ts: &TriggerSpec{
+			Broker:         "test_broker",
+			Subscriber:     validSubscriber,
+			Reply:          &validSubscriber,
+			DiscardReplies: &discard,
+		},
+		want: apis.ErrMultipleOneOf("reply", "discardReplies"),
+	}, {
+		name: "invalid reply",
+		ctx:  triggerReplyEnabledCtx,
+		ts: &TriggerSpec{
+			Broker:     "test_broker",
+			Subscriber: validSubscriber,
+			Reply:      &invalidSubscriber,
+		},
+		want: apis.ErrMissingField("reply.ref.name"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.ts.Validate(test.ctx)
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("Validate TriggerSpec (-want, +got) =\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestFilterSpecValidation(t *testing.T) {
 	newTriggerFiltersEnabledCtx := feature.ToContext(context.TODO(), feature.Flags{
 		feature.NewTriggerFilters: feature.Enabled,
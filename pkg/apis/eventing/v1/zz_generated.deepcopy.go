@@ -134,62 +134,6 @@ func (in *BrokerStatus) DeepCopy() *BrokerStatus {
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SubscriptionsAPIFilter) DeepCopyInto(out *SubscriptionsAPIFilter) {
-	*out = *in
-	if in.All != nil {
-		in, out := &in.All, &out.All
-		*out = make([]SubscriptionsAPIFilter, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Any != nil {
-		in, out := &in.Any, &out.Any
-		*out = make([]SubscriptionsAPIFilter, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Not != nil {
-		in, out := &in.Not, &out.Not
-		*out = new(SubscriptionsAPIFilter)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Exact != nil {
-		in, out := &in.Exact, &out.Exact
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Prefix != nil {
-		in, out := &in.Prefix, &out.Prefix
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Suffix != nil {
-		in, out := &in.Suffix, &out.Suffix
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionsAPIFilter.
-func (in *SubscriptionsAPIFilter) DeepCopy() *SubscriptionsAPIFilter {
-	if in == nil {
-		return nil
-	}
-	out := new(SubscriptionsAPIFilter)
-	in.DeepCopyInto(out)
-	return out
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Trigger) DeepCopyInto(out *Trigger) {
 	*out = *in
@@ -317,6 +261,16 @@ func (in *TriggerSpec) DeepCopyInto(out *TriggerSpec) {
 		*out = new(apisduckv1.DeliverySpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Reply != nil {
+		in, out := &in.Reply, &out.Reply
+		*out = new(duckv1.Destination)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DiscardReplies != nil {
+		in, out := &in.DiscardReplies, &out.DiscardReplies
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -339,6 +293,11 @@ func (in *TriggerStatus) DeepCopyInto(out *TriggerStatus) {
 		*out = new(apis.URL)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ReplyURI != nil {
+		in, out := &in.ReplyURI, &out.ReplyURI
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
 	in.DeliveryStatus.DeepCopyInto(&out.DeliveryStatus)
 	return
 }
@@ -17,10 +17,23 @@ limitations under the License.
 package feature
 
 const (
-	KReferenceGroup     = "kreference-group"
-	DeliveryRetryAfter  = "delivery-retryafter"
-	DeliveryTimeout     = "delivery-timeout"
-	KReferenceMapping   = "kreference-mapping"
-	NewTriggerFilters   = "new-trigger-filters"
-	TransportEncryption = "transport-encryption"
+	KReferenceGroup           = "kreference-group"
+	DeliveryRetryAfter        = "delivery-retryafter"
+	DeliveryTimeout           = "delivery-timeout"
+	KReferenceMapping         = "kreference-mapping"
+	NewTriggerFilters         = "new-trigger-filters"
+	TransportEncryption       = "transport-encryption"
+	DeliveryOrdering          = "delivery-ordering"
+	TriggerReply              = "trigger-reply"
+	IMCDispatchLimits         = "imc-dispatch-limits"
+	DeliveryCircuitBreaker    = "delivery-circuit-breaker"
+	DeliveryBackoffLimits     = "delivery-backoff-limits"
+	DeliveryRetryStatusCodes  = "delivery-retry-status-codes"
+	DeliveryDeadLetterPayload = "delivery-dead-letter-payload"
+	SubscriptionFilters       = "subscription-filters"
+	PingSourceCatchUp         = "pingsource-catch-up"
+	SourceDelivery            = "source-delivery"
+	PingSourceDataTemplate    = "pingsource-data-template"
+	PingSourceIntervalAt      = "pingsource-interval-at"
+	ApiServerSourceFilters    = "apiserversource-filters"
 )
@@ -23,6 +23,22 @@ const (
 	// SubscribableDuckVersionAnnotation is the annotation we use to declare
 	// which Subscribable duck version type we conform to.
 	SubscribableDuckVersionAnnotation = "messaging.knative.dev/subscribable"
+
+	// BufferingAnnotationKey is the annotation key on InMemoryChannels to
+	// select how the dispatcher buffers the accepted events.
+	BufferingAnnotationKey = GroupName + "/buffering"
+	// BufferingMemory buffers the accepted events in memory, they are lost
+	// when the dispatcher restarts. This is the default.
+	BufferingMemory = "memory"
+	// BufferingPersistent writes the accepted events to a write-ahead log on
+	// the local disk of the dispatcher before acknowledging them, the events
+	// not yet dispatched are replayed when the dispatcher restarts.
+	BufferingPersistent = "persistent"
+
+	// SubscriptionFiltersAnnotationKey is the annotation key on channels to
+	// declare that they deliver to each subscriber only the events matching
+	// its filters, see the Filters of the Subscriptions.
+	SubscriptionFiltersAnnotationKey = GroupName + "/subscription-filters"
 )
 
 var (
@@ -36,6 +36,8 @@ func (imc *InMemoryChannel) SetDefaults(ctx context.Context) {
 	if _, ok := imc.Annotations[messaging.SubscribableDuckVersionAnnotation]; !ok {
 		imc.Annotations[messaging.SubscribableDuckVersionAnnotation] = "v1"
 	}
+	// The dispatcher evaluates the filters of the subscribers.
+	imc.Annotations[messaging.SubscriptionFiltersAnnotationKey] = "true"
 
 	ctx = apis.WithinParent(ctx, imc.ObjectMeta)
 	imc.Spec.SetDefaults(ctx)
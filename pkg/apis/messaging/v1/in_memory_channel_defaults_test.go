@@ -37,15 +37,15 @@ func TestInMemoryChannelSetDefaults(t *testing.T) {
 	}{
 		"nil gets annotations": {
 			initial:  InMemoryChannel{},
-			expected: InMemoryChannel{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"messaging.knative.dev/subscribable": "v1"}}},
+			expected: InMemoryChannel{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"messaging.knative.dev/subscribable": "v1", "messaging.knative.dev/subscription-filters": "true"}}},
 		},
 		"empty gets annotations": {
 			initial:  InMemoryChannel{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}}},
-			expected: InMemoryChannel{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"messaging.knative.dev/subscribable": "v1"}}},
+			expected: InMemoryChannel{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"messaging.knative.dev/subscribable": "v1", "messaging.knative.dev/subscription-filters": "true"}}},
 		},
 		"non-empty gets added ChannelDefaulter": {
 			initial:  InMemoryChannel{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"somethingelse": "yup"}}},
-			expected: InMemoryChannel{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"messaging.knative.dev/subscribable": "v1", "somethingelse": "yup"}}},
+			expected: InMemoryChannel{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"messaging.knative.dev/subscribable": "v1", "messaging.knative.dev/subscription-filters": "true", "somethingelse": "yup"}}},
 		},
 		"deadLetterSink.ref.namespace gets defaulted": {
 			initial: InMemoryChannel{
@@ -54,7 +54,7 @@ func TestInMemoryChannelSetDefaults(t *testing.T) {
 					Namespace:   "custom",
 					Annotations: map[string]string{"messaging.knative.dev/subscribable": "v1"},
 				},
-				Spec: InMemoryChannelSpec{eventingduckv1.ChannelableSpec{
+				Spec: InMemoryChannelSpec{ChannelableSpec: eventingduckv1.ChannelableSpec{
 					Delivery: &eventingduckv1.DeliverySpec{
 						DeadLetterSink: &duckv1.Destination{
 							Ref: &duckv1.KReference{
@@ -68,9 +68,9 @@ func TestInMemoryChannelSetDefaults(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name:        "imc",
 					Namespace:   "custom",
-					Annotations: map[string]string{"messaging.knative.dev/subscribable": "v1"},
+					Annotations: map[string]string{"messaging.knative.dev/subscribable": "v1", "messaging.knative.dev/subscription-filters": "true"},
 				},
-				Spec: InMemoryChannelSpec{eventingduckv1.ChannelableSpec{
+				Spec: InMemoryChannelSpec{ChannelableSpec: eventingduckv1.ChannelableSpec{
 					Delivery: &eventingduckv1.DeliverySpec{
 						DeadLetterSink: &duckv1.Destination{
 							Ref: &duckv1.KReference{
@@ -128,6 +128,16 @@ func (imcs *InMemoryChannelStatus) PropagateDispatcherStatus(ds *appsv1.Deployme
 	}
 }
 
+// PropagateDispatcherStatefulSetStatus marks the dispatcher ready when a replica of the dispatcher
+// StatefulSet, used when the channels are sharded across its replicas, is ready.
+func (imcs *InMemoryChannelStatus) PropagateDispatcherStatefulSetStatus(ss *appsv1.StatefulSetStatus) {
+	if ss.ReadyReplicas > 0 {
+		imcCondSet.Manage(imcs).MarkTrue(InMemoryChannelConditionDispatcherReady)
+	} else {
+		imcs.MarkDispatcherFailed("DispatcherStatefulSetNotReady", "There are no ready replicas of the Dispatcher StatefulSet")
+	}
+}
+
 func (imcs *InMemoryChannelStatus) MarkServiceFailed(reason, messageFormat string, messageA ...interface{}) {
 	imcCondSet.Manage(imcs).MarkFalse(InMemoryChannelConditionServiceReady, reason, messageFormat, messageA...)
 }
@@ -148,7 +148,7 @@ func TestInMemoryChannelInitializeConditions(t *testing.T) {
 	}, {
 		name: "one false",
 		cs: &InMemoryChannelStatus{
-			eventingduckv1.ChannelableStatus{
+			ChannelableStatus: eventingduckv1.ChannelableStatus{
 				Status: duckv1.Status{
 					Conditions: []apis.Condition{{
 						Type:   InMemoryChannelConditionDispatcherReady,
@@ -158,7 +158,7 @@ func TestInMemoryChannelInitializeConditions(t *testing.T) {
 			},
 		},
 		want: &InMemoryChannelStatus{
-			eventingduckv1.ChannelableStatus{
+			ChannelableStatus: eventingduckv1.ChannelableStatus{
 				Status: duckv1.Status{
 					Conditions: []apis.Condition{{
 						Type:   InMemoryChannelConditionAddressable,
@@ -440,3 +440,29 @@ func ReadyBrokerStatusWithoutDLS() *InMemoryChannelStatus {
 	imcs.MarkServiceTrue()
 	return imcs
 }
+
+func TestInMemoryChannelStatus_PropagateDispatcherStatefulSetStatus(t *testing.T) {
+	tests := map[string]struct {
+		ss   *appsv1.StatefulSetStatus
+		want corev1.ConditionStatus
+	}{
+		"ready replicas": {
+			ss:   &appsv1.StatefulSetStatus{Replicas: 2, ReadyReplicas: 1},
+			want: corev1.ConditionTrue,
+		},
+		"no ready replicas": {
+			ss:   &appsv1.StatefulSetStatus{Replicas: 2},
+			want: corev1.ConditionFalse,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			cs := &InMemoryChannelStatus{}
+			cs.InitializeConditions()
+			cs.PropagateDispatcherStatefulSetStatus(tc.ss)
+			if got := cs.GetCondition(InMemoryChannelConditionDispatcherReady).Status; got != tc.want {
+				t.Errorf("DispatcherReady = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
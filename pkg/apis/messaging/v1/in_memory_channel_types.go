@@ -19,7 +19,9 @@ package v1
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	eventingduckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmeta"
@@ -67,12 +69,38 @@ var (
 type InMemoryChannelSpec struct {
 	// Channel conforms to Duck type Channelable.
 	eventingduckv1.ChannelableSpec `json:",inline"`
+
+	// Dispatch overrides the limits of the dispatcher, configured in the
+	// config-imc-event-dispatcher ConfigMap, for this channel.
+	// +optional
+	Dispatch *InMemoryChannelDispatchSpec `json:"dispatch,omitempty"`
+}
+
+// InMemoryChannelDispatchSpec bounds the events dispatched concurrently by an InMemoryChannel.
+type InMemoryChannelDispatchSpec struct {
+	// MaxInFlight is the maximum number of events accepted by the channel and not yet
+	// dispatched. Beyond it, the channel rejects new events with 429 Too Many Requests
+	// and a Retry-After header.
+	// +optional
+	MaxInFlight *int32 `json:"maxInFlight,omitempty"`
+
+	// Workers is the maximum number of events dispatched concurrently to each subscriber.
+	// +optional
+	Workers *int32 `json:"workers,omitempty"`
+
+	// QueueSize is the maximum number of events waiting to be dispatched to each subscriber.
+	// +optional
+	QueueSize *int32 `json:"queueSize,omitempty"`
 }
 
 // ChannelStatus represents the current state of a Channel.
 type InMemoryChannelStatus struct {
 	// Channel conforms to Duck type ChannelableStatus.
 	eventingduckv1.ChannelableStatus `json:",inline"`
+
+	// Placeable is the replica of the dispatcher the channel is placed on, when the
+	// channels are sharded across the replicas of the imc-dispatcher StatefulSet.
+	eventingduckv1alpha1.Placeable `json:",inline"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -89,3 +117,26 @@ type InMemoryChannelList struct {
 func (t *InMemoryChannel) GetStatus() *duckv1.Status {
 	return &t.Status.Status
 }
+
+// GetKey returns the namespace and name of the InMemoryChannel. Implements the scheduler.VPod interface.
+func (t *InMemoryChannel) GetKey() types.NamespacedName {
+	return types.NamespacedName{Namespace: t.Namespace, Name: t.Name}
+}
+
+// GetVReplicas returns the number of dispatcher replicas programming the InMemoryChannel,
+// a channel is placed on a single replica. Implements the scheduler.VPod interface.
+func (t *InMemoryChannel) GetVReplicas() int32 {
+	return 1
+}
+
+// GetPlacements returns the dispatcher replicas the InMemoryChannel is placed on.
+// Implements the scheduler.VPod interface.
+func (t *InMemoryChannel) GetPlacements() []eventingduckv1alpha1.Placement {
+	return t.Status.Placements
+}
+
+// GetResourceVersion returns the resource version of the InMemoryChannel.
+// Implements the scheduler.VPod interface.
+func (t *InMemoryChannel) GetResourceVersion() string {
+	return t.ResourceVersion
+}
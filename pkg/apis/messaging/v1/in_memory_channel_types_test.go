@@ -16,7 +16,15 @@ limitations under the License.
 
 package v1
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	eventingduckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
+)
 
 func TestInMemoryChannelGetStatus(t *testing.T) {
 	r := &InMemoryChannel{
@@ -34,3 +42,25 @@ func TestInMemoryChannelGetGroupVersionKind(t *testing.T) {
 		t.Errorf("Should be InMemoryChannel.")
 	}
 }
+
+func TestInMemoryChannelVPod(t *testing.T) {
+	placements := []eventingduckv1alpha1.Placement{{PodName: "imc-dispatcher-1", VReplicas: 1}}
+	imc := &InMemoryChannel{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "channel", ResourceVersion: "42"},
+		Status: InMemoryChannelStatus{
+			Placeable: eventingduckv1alpha1.Placeable{Placements: placements},
+		},
+	}
+	if got, want := imc.GetKey(), (types.NamespacedName{Namespace: "ns", Name: "channel"}); got != want {
+		t.Errorf("GetKey() = %v, want %v", got, want)
+	}
+	if got := imc.GetVReplicas(); got != 1 {
+		t.Errorf("GetVReplicas() = %d, want 1", got)
+	}
+	if diff := cmp.Diff(placements, imc.GetPlacements()); diff != "" {
+		t.Error("unexpected placements (-want, +got):", diff)
+	}
+	if got := imc.GetResourceVersion(); got != "42" {
+		t.Errorf("GetResourceVersion() = %q, want 42", got)
+	}
+}
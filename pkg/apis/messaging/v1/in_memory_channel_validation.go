@@ -23,6 +23,8 @@ import (
 	"knative.dev/pkg/apis"
 
 	"knative.dev/eventing/pkg/apis/eventing"
+	"knative.dev/eventing/pkg/apis/feature"
+	"knative.dev/eventing/pkg/apis/messaging"
 )
 
 func (imc *InMemoryChannel) Validate(ctx context.Context) *apis.FieldError {
@@ -37,6 +39,13 @@ func (imc *InMemoryChannel) Validate(ctx context.Context) *apis.FieldError {
 				errs = errs.Also(iv.ViaFieldKey("annotations", eventing.ScopeAnnotationKey).ViaField("metadata"))
 			}
 		}
+		if buffering, ok := imc.Annotations[messaging.BufferingAnnotationKey]; ok {
+			if buffering != messaging.BufferingMemory && buffering != messaging.BufferingPersistent {
+				iv := apis.ErrInvalidValue(buffering, "")
+				iv.Details = "expected either 'memory' or 'persistent'"
+				errs = errs.Also(iv.ViaFieldKey("annotations", messaging.BufferingAnnotationKey).ViaField("metadata"))
+			}
+		}
 	}
 
 	return errs
@@ -52,5 +61,27 @@ func (imcs *InMemoryChannelSpec) Validate(ctx context.Context) *apis.FieldError
 		}
 	}
 
+	if imcs.Dispatch != nil {
+		if !feature.FromContext(ctx).IsEnabled(feature.IMCDispatchLimits) {
+			errs = errs.Also(apis.ErrDisallowedFields("dispatch"))
+		} else {
+			errs = errs.Also(imcs.Dispatch.Validate(ctx).ViaField("dispatch"))
+		}
+	}
+
+	return errs
+}
+
+func (ds *InMemoryChannelDispatchSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	if ds.MaxInFlight != nil && *ds.MaxInFlight < 1 {
+		errs = errs.Also(apis.ErrInvalidValue(*ds.MaxInFlight, "maxInFlight"))
+	}
+	if ds.Workers != nil && *ds.Workers < 1 {
+		errs = errs.Also(apis.ErrInvalidValue(*ds.Workers, "workers"))
+	}
+	if ds.QueueSize != nil && *ds.QueueSize < 1 {
+		errs = errs.Also(apis.ErrInvalidValue(*ds.QueueSize, "queueSize"))
+	}
 	return errs
 }
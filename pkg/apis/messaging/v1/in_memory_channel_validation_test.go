@@ -17,13 +17,18 @@ limitations under the License.
 package v1
 
 import (
+	"context"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	"knative.dev/pkg/apis"
 
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/apis/eventing"
+	"knative.dev/eventing/pkg/apis/feature"
+	"knative.dev/eventing/pkg/apis/messaging"
 )
 
 func TestInMemoryChannelValidation(t *testing.T) {
@@ -101,7 +106,81 @@ func TestInMemoryChannelValidation(t *testing.T) {
 			fe.Details = "expected either 'cluster' or 'namespace'"
 			return fe
 		}(),
+	}, {
+		name: "valid buffering annotation",
+		cr: &InMemoryChannel{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					messaging.BufferingAnnotationKey: messaging.BufferingPersistent,
+				},
+			},
+			Spec: InMemoryChannelSpec{},
+		},
+		want: nil,
+	}, {
+		name: "invalid buffering annotation",
+		cr: &InMemoryChannel{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					messaging.BufferingAnnotationKey: "disk",
+				},
+			},
+			Spec: InMemoryChannelSpec{},
+		},
+		want: func() *apis.FieldError {
+			fe := apis.ErrInvalidValue("disk", "metadata.annotations.[messaging.knative.dev/buffering]")
+			fe.Details = "expected either 'memory' or 'persistent'"
+			return fe
+		}(),
 	}}
 
 	doValidateTest(t, tests)
 }
+
+func TestInMemoryChannelDispatchValidation(t *testing.T) {
+	enabled := feature.ToContext(context.TODO(), feature.Flags{
+		feature.IMCDispatchLimits: feature.Enabled,
+	})
+	tests := []struct {
+		name string
+		ctx  context.Context
+		spec *InMemoryChannelDispatchSpec
+		want *apis.FieldError
+	}{{
+		name: "valid dispatch limits",
+		ctx:  enabled,
+		spec: &InMemoryChannelDispatchSpec{
+			MaxInFlight: pointer.Int32(100),
+			Workers:     pointer.Int32(4),
+			QueueSize:   pointer.Int32(10),
+		},
+	}, {
+		name: "feature disabled",
+		ctx:  context.TODO(),
+		spec: &InMemoryChannelDispatchSpec{
+			MaxInFlight: pointer.Int32(100),
+		},
+		want: apis.ErrDisallowedFields("spec.dispatch"),
+	}, {
+		name: "invalid dispatch limits",
+		ctx:  enabled,
+		spec: &InMemoryChannelDispatchSpec{
+			MaxInFlight: pointer.Int32(0),
+			Workers:     pointer.Int32(-1),
+			QueueSize:   pointer.Int32(0),
+		},
+		want: apis.ErrInvalidValue(0, "spec.dispatch.maxInFlight").
+			Also(apis.ErrInvalidValue(-1, "spec.dispatch.workers")).
+			Also(apis.ErrInvalidValue(0, "spec.dispatch.queueSize")),
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			imc := &InMemoryChannel{Spec: InMemoryChannelSpec{Dispatch: tt.spec}}
+			got := imc.Validate(tt.ctx)
+			if diff := cmp.Diff(tt.want.Error(), got.Error()); diff != "" {
+				t.Error("Validate (-want, +got) =", diff)
+			}
+		})
+	}
+}
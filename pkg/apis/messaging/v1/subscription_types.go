@@ -103,6 +103,14 @@ type SubscriptionSpec struct {
 	// Delivery configuration
 	// +optional
 	Delivery *eventingduckv1.DeliverySpec `json:"delivery,omitempty"`
+
+	// Filters is an experimental field that conforms to the CNCF CloudEvents Subscriptions
+	// API. It's an array of filter expressions that evaluate to true or false.
+	// If any filter expression in the array evaluates to false, the event is not
+	// delivered to the subscriber. The filters are evaluated by the channel, the
+	// Subscription isn't added to a channel that doesn't support them.
+	// +optional
+	Filters []eventingduckv1.SubscriptionsAPIFilter `json:"filters,omitempty"`
 }
 
 // SubscriptionStatus (computed) for a subscription
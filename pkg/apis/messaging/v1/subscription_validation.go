@@ -24,6 +24,9 @@ import (
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmp"
+
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/apis/feature"
 )
 
 func (s *Subscription) Validate(ctx context.Context) *apis.FieldError {
@@ -71,6 +74,14 @@ func (ss *SubscriptionSpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 
+	if ss.Filters != nil {
+		if !feature.FromContext(ctx).IsEnabled(feature.SubscriptionFilters) {
+			errs = errs.Also(apis.ErrDisallowedFields("filters"))
+		} else if fe := eventingduckv1.ValidateSubscriptionAPIFiltersList(ctx, ss.Filters); fe != nil {
+			errs = errs.Also(fe.ViaField("filters"))
+		}
+	}
+
 	return errs
 }
 
@@ -83,8 +94,8 @@ func (s *Subscription) CheckImmutableFields(ctx context.Context, original *Subsc
 		return nil
 	}
 
-	// Only Subscriber and Reply are mutable.
-	ignoreArguments := cmpopts.IgnoreFields(SubscriptionSpec{}, "Subscriber", "Reply", "Delivery")
+	// Only Subscriber, Reply, Delivery and Filters are mutable.
+	ignoreArguments := cmpopts.IgnoreFields(SubscriptionSpec{}, "Subscriber", "Reply", "Delivery", "Filters")
 	if diff, err := kmp.ShortDiff(original.Spec, s.Spec, ignoreArguments); err != nil {
 		return &apis.FieldError{
 			Message: "Failed to diff Subscription",
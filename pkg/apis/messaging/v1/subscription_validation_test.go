@@ -445,6 +445,65 @@ func TestSubscriptionSpecValidationWithKRefGroupFeatureEnabled(t *testing.T) {
 	}
 }
 
+func TestSubscriptionSpecValidationFilters(t *testing.T) {
+	tests := []struct {
+		name    string
+		filters []eventingduckv1.SubscriptionsAPIFilter
+		flag    feature.Flag
+		want    *apis.FieldError
+	}{{
+		name: "valid filters",
+		filters: []eventingduckv1.SubscriptionsAPIFilter{{
+			Exact: map[string]string{"type": "dev.knative.example"},
+		}, {
+			CESQL: "source LIKE '%knative%'",
+		}},
+		flag: feature.Enabled,
+	}, {
+		name: "filters with the feature disabled",
+		filters: []eventingduckv1.SubscriptionsAPIFilter{{
+			Exact: map[string]string{"type": "dev.knative.example"},
+		}},
+		flag: feature.Disabled,
+		want: apis.ErrDisallowedFields("filters"),
+	}, {
+		name: "invalid attribute name",
+		filters: []eventingduckv1.SubscriptionsAPIFilter{{
+			Prefix: map[string]string{"invalid_attr": "dev.knative"},
+		}},
+		flag: feature.Enabled,
+		want: apis.ErrInvalidKeyName("invalid_attr", apis.CurrentField,
+			"Attribute name must start with a letter and can only contain lowercase alphanumeric").
+			ViaKey("invalid_attr").ViaField("prefix").ViaIndex(0).ViaField("filters"),
+	}, {
+		name: "multiple dialects",
+		filters: []eventingduckv1.SubscriptionsAPIFilter{{
+			Exact:  map[string]string{"type": "dev.knative.example"},
+			Suffix: map[string]string{"source": "example"},
+		}},
+		flag: feature.Enabled,
+		want: apis.ErrGeneric("multiple dialects found, filters can have only one dialect set").
+			ViaIndex(0).ViaField("filters"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := feature.ToContext(context.TODO(), feature.Flags{
+				feature.SubscriptionFilters: test.flag,
+			})
+			ss := &SubscriptionSpec{
+				Channel:    getValidChannelRef(),
+				Subscriber: getValidDestination(),
+				Filters:    test.filters,
+			}
+			got := ss.Validate(ctx)
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Error("SubscriptionSpec.Validate (-want, +got) =", diff)
+			}
+		})
+	}
+}
+
 func TestSubscriptionImmutable(t *testing.T) {
 	newChannel := getValidChannelRef()
 	newChannel.Name = "newChannel"
@@ -191,6 +191,37 @@ func (in *InMemoryChannel) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InMemoryChannelDispatchSpec) DeepCopyInto(out *InMemoryChannelDispatchSpec) {
+	*out = *in
+	if in.MaxInFlight != nil {
+		in, out := &in.MaxInFlight, &out.MaxInFlight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Workers != nil {
+		in, out := &in.Workers, &out.Workers
+		*out = new(int32)
+		**out = **in
+	}
+	if in.QueueSize != nil {
+		in, out := &in.QueueSize, &out.QueueSize
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InMemoryChannelDispatchSpec.
+func (in *InMemoryChannelDispatchSpec) DeepCopy() *InMemoryChannelDispatchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InMemoryChannelDispatchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InMemoryChannelList) DeepCopyInto(out *InMemoryChannelList) {
 	*out = *in
@@ -228,6 +259,11 @@ func (in *InMemoryChannelList) DeepCopyObject() runtime.Object {
 func (in *InMemoryChannelSpec) DeepCopyInto(out *InMemoryChannelSpec) {
 	*out = *in
 	in.ChannelableSpec.DeepCopyInto(&out.ChannelableSpec)
+	if in.Dispatch != nil {
+		in, out := &in.Dispatch, &out.Dispatch
+		*out = new(InMemoryChannelDispatchSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -245,6 +281,7 @@ func (in *InMemoryChannelSpec) DeepCopy() *InMemoryChannelSpec {
 func (in *InMemoryChannelStatus) DeepCopyInto(out *InMemoryChannelStatus) {
 	*out = *in
 	in.ChannelableStatus.DeepCopyInto(&out.ChannelableStatus)
+	in.Placeable.DeepCopyInto(&out.Placeable)
 	return
 }
 
@@ -338,6 +375,13 @@ func (in *SubscriptionSpec) DeepCopyInto(out *SubscriptionSpec) {
 		*out = new(apisduckv1.DeliverySpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]apisduckv1.SubscriptionsAPIFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
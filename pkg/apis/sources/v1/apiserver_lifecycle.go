@@ -89,6 +89,17 @@ func (s *ApiServerSourceStatus) MarkNoSink(reason, messageFormat string, message
 	apiserverCondSet.Manage(s).MarkFalse(ApiServerConditionSinkProvided, reason, messageFormat, messageA...)
 }
 
+// MarkDeadLetterSink sets the resolved URI of the dead letter sink, nil when no dead letter sink is configured.
+func (s *ApiServerSourceStatus) MarkDeadLetterSink(uri *apis.URL) {
+	s.DeadLetterSinkURI = uri
+}
+
+// MarkNoDeadLetterSink sets the condition that the dead letter sink of the source could not be resolved.
+func (s *ApiServerSourceStatus) MarkNoDeadLetterSink(reason, messageFormat string, messageA ...interface{}) {
+	s.DeadLetterSinkURI = nil
+	apiserverCondSet.Manage(s).MarkFalse(ApiServerConditionSinkProvided, reason, messageFormat, messageA...)
+}
+
 // PropagateDeploymentAvailability uses the availability of the provided Deployment to determine if
 // ApiServerConditionDeployed should be marked as true or false.
 func (s *ApiServerSourceStatus) PropagateDeploymentAvailability(d *appsv1.Deployment) {
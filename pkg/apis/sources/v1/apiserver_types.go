@@ -22,6 +22,8 @@ import (
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmeta"
+
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 )
 
 // +genclient
@@ -85,6 +87,23 @@ type ApiServerSourceSpec struct {
 	// should be watched by the source.
 	// +optional
 	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Delivery contains the retries and the dead letter sink of the events
+	// sent to the sink.
+	// +optional
+	Delivery *eventingduckv1.DeliverySpec `json:"delivery,omitempty"`
+
+	// Filters is an experimental field that conforms to the CNCF CloudEvents Subscriptions
+	// API. It's an array of filter expressions that evaluate to true or false.
+	// If any filter expression in the array evaluates to false, the event is not
+	// sent to the sink.
+	// +optional
+	Filters []eventingduckv1.SubscriptionsAPIFilter `json:"filters,omitempty"`
+
+	// OnlyStatusChanges sends the update events of the resources only when their
+	// status changed, ignoring the updates of their metadata or spec.
+	// +optional
+	OnlyStatusChanges bool `json:"onlyStatusChanges,omitempty"`
 }
 
 // ApiServerSourceStatus defines the observed state of ApiServerSource
@@ -100,6 +119,9 @@ type ApiServerSourceStatus struct {
 
 	// Namespaces show the namespaces currently watched by the ApiServerSource
 	Namespaces []string `json:"namespaces"`
+
+	// DeliveryStatus contains the resolved URL of the dead letter sink.
+	eventingduckv1.DeliveryStatus `json:",inline"`
 }
 
 // APIVersionKind is an APIVersion and Kind tuple.
@@ -126,6 +148,13 @@ type APIVersionKindSelector struct {
 	// More info: http://kubernetes.io/docs/concepts/overview/working-with-objects/labels/#label-selectors
 	// +optional
 	LabelSelector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// FieldSelector filters this source to the resources matching the field
+	// selector, for example `status.phase=Running`. The fields supported
+	// depend on the kind of the resource.
+	// More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/field-selectors/
+	// +optional
+	FieldSelector string `json:"fieldSelector,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
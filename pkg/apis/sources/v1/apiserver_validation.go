@@ -20,9 +20,13 @@ import (
 	"context"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"knative.dev/pkg/apis"
+
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/apis/feature"
 )
 
 const (
@@ -61,6 +65,9 @@ func (cs *ApiServerSourceSpec) Validate(ctx context.Context) *apis.FieldError {
 		if strings.TrimSpace(res.Kind) == "" {
 			errs = errs.Also(apis.ErrMissingField("kind").ViaFieldIndex("resources", i))
 		}
+		if res.FieldSelector != "" {
+			errs = errs.Also(validateFieldSelector(ctx, res.FieldSelector).ViaFieldIndex("resources", i))
+		}
 	}
 
 	if cs.ResourceOwner != nil {
@@ -72,6 +79,29 @@ func (cs *ApiServerSourceSpec) Validate(ctx context.Context) *apis.FieldError {
 			errs = errs.Also(apis.ErrMissingField("kind").ViaField("owner"))
 		}
 	}
+	errs = errs.Also(validateDelivery(ctx, cs.Delivery))
+
+	if cs.Filters != nil {
+		if !feature.FromContext(ctx).IsEnabled(feature.ApiServerSourceFilters) {
+			errs = errs.Also(apis.ErrDisallowedFields("filters"))
+		} else if fe := eventingduckv1.ValidateSubscriptionAPIFiltersList(ctx, cs.Filters); fe != nil {
+			errs = errs.Also(fe.ViaField("filters"))
+		}
+	}
+	if cs.OnlyStatusChanges && !feature.FromContext(ctx).IsEnabled(feature.ApiServerSourceFilters) {
+		errs = errs.Also(apis.ErrDisallowedFields("onlyStatusChanges"))
+	}
 	errs = errs.Also(cs.SourceSpec.Validate(ctx))
 	return errs
 }
+
+// validateFieldSelector validates the field selector of a resource watched by the source.
+func validateFieldSelector(ctx context.Context, selector string) *apis.FieldError {
+	if !feature.FromContext(ctx).IsEnabled(feature.ApiServerSourceFilters) {
+		return apis.ErrDisallowedFields("fieldSelector")
+	}
+	if _, err := fields.ParseSelector(selector); err != nil {
+		return apis.ErrInvalidValue(selector, "fieldSelector", err.Error())
+	}
+	return nil
+}
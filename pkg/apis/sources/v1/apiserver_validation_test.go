@@ -26,7 +26,11 @@ import (
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 
 	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/pointer"
 	"knative.dev/pkg/apis"
+
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/apis/feature"
 )
 
 func TestAPIServerValidation(t *testing.T) {
@@ -246,6 +250,141 @@ func TestAPIServerValidation(t *testing.T) {
 	}
 }
 
+func TestAPIServerValidationDelivery(t *testing.T) {
+	spec := func(retry int32) ApiServerSourceSpec {
+		return ApiServerSourceSpec{
+			EventMode: "Resource",
+			Resources: []APIVersionKindSelector{{
+				APIVersion: "v1",
+				Kind:       "Foo",
+			}},
+			Delivery: &eventingduckv1.DeliverySpec{
+				Retry: pointer.Int32(retry),
+			},
+			SourceSpec: duckv1.SourceSpec{
+				Sink: duckv1.Destination{
+					Ref: &duckv1.KReference{
+						APIVersion: "v1",
+						Kind:       "broker",
+						Name:       "default",
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name string
+		spec ApiServerSourceSpec
+		flag feature.Flag
+		want *apis.FieldError
+	}{{
+		name: "valid delivery",
+		spec: spec(3),
+		flag: feature.Enabled,
+	}, {
+		name: "delivery with the feature disabled",
+		spec: spec(3),
+		flag: feature.Disabled,
+		want: apis.ErrDisallowedFields("delivery"),
+	}, {
+		name: "invalid delivery",
+		spec: spec(-1),
+		flag: feature.Enabled,
+		want: apis.ErrInvalidValue(-1, "delivery.retry"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := feature.ToContext(context.TODO(), feature.Flags{feature.SourceDelivery: test.flag})
+			got := test.spec.Validate(ctx)
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Error("APIServerSourceSpec.Validate (-want, +got) =", diff)
+			}
+		})
+	}
+}
+
+func TestAPIServerValidationFilters(t *testing.T) {
+	spec := func(fieldSelector string, filters []eventingduckv1.SubscriptionsAPIFilter, onlyStatusChanges bool) ApiServerSourceSpec {
+		return ApiServerSourceSpec{
+			EventMode: "Resource",
+			Resources: []APIVersionKindSelector{{
+				APIVersion:    "v1",
+				Kind:          "Pod",
+				FieldSelector: fieldSelector,
+			}},
+			Filters:           filters,
+			OnlyStatusChanges: onlyStatusChanges,
+			SourceSpec: duckv1.SourceSpec{
+				Sink: duckv1.Destination{
+					Ref: &duckv1.KReference{
+						APIVersion: "v1",
+						Kind:       "broker",
+						Name:       "default",
+					},
+				},
+			},
+		}
+	}
+	exact := []eventingduckv1.SubscriptionsAPIFilter{{
+		Exact: map[string]string{"type": "dev.knative.apiserver.resource.update"},
+	}}
+
+	tests := []struct {
+		name string
+		spec ApiServerSourceSpec
+		flag feature.Flag
+		want *apis.FieldError
+	}{{
+		name: "valid field selector, filters and status changes",
+		spec: spec("status.phase=Running,spec.nodeName!=node-1", append(exact, eventingduckv1.SubscriptionsAPIFilter{
+			CESQL: "subject LIKE '%my-pod%'",
+		}), true),
+		flag: feature.Enabled,
+	}, {
+		name: "field selector with the feature disabled",
+		spec: spec("status.phase=Running", nil, false),
+		flag: feature.Disabled,
+		want: apis.ErrDisallowedFields("resources[0].fieldSelector"),
+	}, {
+		name: "filters with the feature disabled",
+		spec: spec("", exact, false),
+		flag: feature.Disabled,
+		want: apis.ErrDisallowedFields("filters"),
+	}, {
+		name: "status changes with the feature disabled",
+		spec: spec("", nil, true),
+		flag: feature.Disabled,
+		want: apis.ErrDisallowedFields("onlyStatusChanges"),
+	}, {
+		name: "invalid field selector",
+		spec: spec("status.phase", nil, false),
+		flag: feature.Enabled,
+		want: apis.ErrInvalidValue("status.phase", "resources[0].fieldSelector",
+			`invalid selector: 'status.phase'; can't understand 'status.phase'`),
+	}, {
+		name: "multiple dialects",
+		spec: spec("", []eventingduckv1.SubscriptionsAPIFilter{{
+			Exact:  map[string]string{"type": "dev.knative.apiserver.resource.update"},
+			Suffix: map[string]string{"source": "example"},
+		}}, false),
+		flag: feature.Enabled,
+		want: apis.ErrGeneric("multiple dialects found, filters can have only one dialect set").
+			ViaIndex(0).ViaField("filters"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := feature.ToContext(context.TODO(), feature.Flags{feature.ApiServerSourceFilters: test.flag})
+			got := test.spec.Validate(ctx)
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Error("APIServerSourceSpec.Validate (-want, +got) =", diff)
+			}
+		})
+	}
+}
+
 func TestAPIServerValidationCallsSpecValidation(t *testing.T) {
 	source := ApiServerSource{
 		Spec: ApiServerSourceSpec{
@@ -29,7 +29,7 @@ func (s *PingSource) SetDefaults(ctx context.Context) {
 }
 
 func (ss *PingSourceSpec) SetDefaults(ctx context.Context) {
-	if ss.Schedule == "" {
+	if ss.Schedule == "" && ss.Interval == nil && ss.At == nil {
 		ss.Schedule = defaultSchedule
 	}
 }
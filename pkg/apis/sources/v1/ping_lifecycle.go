@@ -34,6 +34,10 @@ const (
 
 	// PingSourceConditionDeployed has status True when the PingSource has had it's receive adapter deployment created.
 	PingSourceConditionDeployed apis.ConditionType = "Deployed"
+
+	// PingSourceConditionCompleted has status True when the one-shot PingSource has sent its event.
+	// It is not set for the PingSources sending events on a schedule or at an interval.
+	PingSourceConditionCompleted apis.ConditionType = "Completed"
 )
 
 var PingSourceCondSet = apis.NewLivingConditionSet(
@@ -100,6 +104,32 @@ func (s *PingSourceStatus) MarkNoSink(reason, messageFormat string, messageA ...
 	PingSourceCondSet.Manage(s).MarkFalse(PingSourceConditionSinkProvided, reason, messageFormat, messageA...)
 }
 
+// MarkDeadLetterSink sets the resolved URI of the dead letter sink, nil when no dead letter sink is configured.
+func (s *PingSourceStatus) MarkDeadLetterSink(uri *apis.URL) {
+	s.DeadLetterSinkURI = uri
+}
+
+// MarkNoDeadLetterSink sets the condition that the dead letter sink of the source could not be resolved.
+func (s *PingSourceStatus) MarkNoDeadLetterSink(reason, messageFormat string, messageA ...interface{}) {
+	s.DeadLetterSinkURI = nil
+	PingSourceCondSet.Manage(s).MarkFalse(PingSourceConditionSinkProvided, reason, messageFormat, messageA...)
+}
+
+// MarkCompleted sets the condition that the one-shot source has sent its event.
+func (s *PingSourceStatus) MarkCompleted() {
+	PingSourceCondSet.Manage(s).MarkTrue(PingSourceConditionCompleted)
+}
+
+// MarkNotCompleted sets the condition that the one-shot source has not sent its event yet.
+func (s *PingSourceStatus) MarkNotCompleted(reason, messageFormat string, messageA ...interface{}) {
+	PingSourceCondSet.Manage(s).MarkFalse(PingSourceConditionCompleted, reason, messageFormat, messageA...)
+}
+
+// ClearCompleted removes the completion condition of the sources that are not one-shot.
+func (s *PingSourceStatus) ClearCompleted() {
+	_ = PingSourceCondSet.Manage(s).ClearCondition(PingSourceConditionCompleted)
+}
+
 // PropagateDeploymentAvailability uses the availability of the provided Deployment to determine if
 // PingSourceConditionDeployed should be marked as true or false.
 func (s *PingSourceStatus) PropagateDeploymentAvailability(d *appsv1.Deployment) {
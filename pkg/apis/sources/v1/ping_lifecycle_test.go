@@ -104,6 +104,54 @@ func TestPingSourceStatusIsReady(t *testing.T) {
 		}(),
 		wantConditionStatus: corev1.ConditionTrue,
 		want:                true,
+	}, {
+		name: "mark sink, dead letter sink and deployed",
+		s: func() *PingSourceStatus {
+			s := &PingSourceStatus{}
+			s.InitializeConditions()
+			s.MarkSink(exampleUri)
+			s.MarkDeadLetterSink(exampleUri)
+			s.PropagateDeploymentAvailability(availableDeployment)
+			return s
+		}(),
+		wantConditionStatus: corev1.ConditionTrue,
+		want:                true,
+	}, {
+		name: "mark sink, no dead letter sink and deployed",
+		s: func() *PingSourceStatus {
+			s := &PingSourceStatus{}
+			s.InitializeConditions()
+			s.MarkSink(exampleUri)
+			s.MarkNoDeadLetterSink("NotFound", "")
+			s.PropagateDeploymentAvailability(availableDeployment)
+			return s
+		}(),
+		wantConditionStatus: corev1.ConditionFalse,
+		want:                false,
+	}, {
+		name: "mark sink, deployed and completed",
+		s: func() *PingSourceStatus {
+			s := &PingSourceStatus{}
+			s.InitializeConditions()
+			s.MarkSink(exampleUri)
+			s.PropagateDeploymentAvailability(availableDeployment)
+			s.MarkCompleted()
+			return s
+		}(),
+		wantConditionStatus: corev1.ConditionTrue,
+		want:                true,
+	}, {
+		name: "mark sink, deployed and not completed",
+		s: func() *PingSourceStatus {
+			s := &PingSourceStatus{}
+			s.InitializeConditions()
+			s.MarkSink(exampleUri)
+			s.PropagateDeploymentAvailability(availableDeployment)
+			s.MarkNotCompleted("Scheduled", "")
+			return s
+		}(),
+		wantConditionStatus: corev1.ConditionTrue,
+		want:                true,
 	}}
 
 	for _, test := range tests {
@@ -245,6 +293,45 @@ func TestPingSourceStatusGetCondition(t *testing.T) {
 			Type:   PingSourceConditionReady,
 			Status: corev1.ConditionUnknown,
 		},
+	}, {
+		name: "mark completed",
+		s: func() *PingSourceStatus {
+			s := &PingSourceStatus{}
+			s.InitializeConditions()
+			s.MarkCompleted()
+			return s
+		}(),
+		condQuery: PingSourceConditionCompleted,
+		want: &apis.Condition{
+			Type:   PingSourceConditionCompleted,
+			Status: corev1.ConditionTrue,
+		},
+	}, {
+		name: "mark not completed",
+		s: func() *PingSourceStatus {
+			s := &PingSourceStatus{}
+			s.InitializeConditions()
+			s.MarkNotCompleted("Scheduled", "The event is scheduled.")
+			return s
+		}(),
+		condQuery: PingSourceConditionCompleted,
+		want: &apis.Condition{
+			Type:    PingSourceConditionCompleted,
+			Status:  corev1.ConditionFalse,
+			Reason:  "Scheduled",
+			Message: "The event is scheduled.",
+		},
+	}, {
+		name: "clear completed",
+		s: func() *PingSourceStatus {
+			s := &PingSourceStatus{}
+			s.InitializeConditions()
+			s.MarkCompleted()
+			s.ClearCompleted()
+			return s
+		}(),
+		condQuery: PingSourceConditionCompleted,
+		want:      nil,
 	}}
 
 	for _, test := range tests {
@@ -0,0 +1,53 @@
+/*
+Copyright 2023 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"bytes"
+	"text/template"
+	"time"
+)
+
+// pingSourceTemplateData is the data the DataTemplate of a PingSource is rendered with at each tick.
+// +k8s:deepcopy-gen=false
+type pingSourceTemplateData struct {
+	// ScheduleTime is the time the tick was scheduled at.
+	ScheduleTime time.Time
+	// FireTime is the time the tick was actually fired at.
+	FireTime time.Time
+	// Sequence is the sequence number of the tick, it increases by one at each tick fired.
+	Sequence int64
+}
+
+// ParseDataTemplate parses the DataTemplate of a PingSource.
+func ParseDataTemplate(dataTemplate string) (*template.Template, error) {
+	return template.New("dataTemplate").Parse(dataTemplate)
+}
+
+// RenderDataTemplate renders the parsed DataTemplate of a PingSource with the data of a tick.
+func RenderDataTemplate(tmpl *template.Template, scheduleTime, fireTime time.Time, sequence int64) ([]byte, error) {
+	data := pingSourceTemplateData{
+		ScheduleTime: scheduleTime,
+		FireTime:     fireTime,
+		Sequence:     sequence,
+	}
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2023 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRenderDataTemplate(t *testing.T) {
+	scheduleTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	fireTime := scheduleTime.Add(250 * time.Millisecond)
+
+	testCases := map[string]struct {
+		template string
+		want     string
+		wantErr  bool
+	}{
+		"static": {
+			template: `{"message": "hello"}`,
+			want:     `{"message": "hello"}`,
+		},
+		"tick data": {
+			template: `{"scheduled": "{{ .ScheduleTime.Format "2006-01-02T15:04:05Z07:00" }}", "fired": "{{ .FireTime.Format "15:04:05.000" }}", "sequence": {{ .Sequence }}}`,
+			want:     `{"scheduled": "2023-01-01T12:00:00Z", "fired": "12:00:00.250", "sequence": 42}`,
+		},
+		"unknown field": {
+			template: `{{ .Unknown }}`,
+			wantErr:  true,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			tmpl, err := ParseDataTemplate(tc.template)
+			if err != nil {
+				t.Fatal("ParseDataTemplate() =", err)
+			}
+			got, err := RenderDataTemplate(tmpl, scheduleTime, fireTime, 42)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("RenderDataTemplate() = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && string(got) != tc.want {
+				t.Errorf("RenderDataTemplate() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
@@ -23,6 +23,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmeta"
+
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 )
 
 // +genclient
@@ -58,29 +60,63 @@ type PingSourceSpec struct {
 	//   and modifications of the event sent to the sink.
 	duckv1.SourceSpec `json:",inline"`
 
-	// Schedule is the cron schedule. Defaults to `* * * * *`.
+	// Schedule is the cron schedule. Defaults to `* * * * *` unless Interval or At is set.
+	// Mutually exclusive with Interval and At.
 	// +optional
 	Schedule string `json:"schedule,omitempty"`
 
+	// Interval is the duration between two ticks, for example `15s`. It must be a whole
+	// number of seconds, at least one second. The ticks are counted from the creation time
+	// of the source.
+	// Mutually exclusive with Schedule and At.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// At is the time, in RFC3339 format, of the single tick of a one-shot PingSource. It
+	// must be in the future when the source is created.
+	// Mutually exclusive with Schedule and Interval.
+	// +optional
+	At *metav1.Time `json:"at,omitempty"`
+
 	// Timezone modifies the actual time relative to the specified timezone.
-	// Defaults to the system time zone.
+	// Defaults to the system time zone. Only applies to Schedule.
 	// More general information about time zones: https://www.iana.org/time-zones
 	// List of valid timezone values: https://en.wikipedia.org/wiki/List_of_tz_database_time_zones
 	Timezone string `json:"timezone,omitempty"`
 
-	// ContentType is the media type of Data or DataBase64. Default is empty.
+	// ContentType is the media type of Data, DataBase64 or DataTemplate. Default is empty.
 	// +optional
 	ContentType string `json:"contentType,omitempty"`
 
 	// Data is data used as the body of the event posted to the sink. Default is empty.
-	// Mutually exclusive with DataBase64.
+	// Mutually exclusive with DataBase64 and DataTemplate.
 	// +optional
 	Data string `json:"data,omitempty"`
 
 	// DataBase64 is the base64-encoded string of the actual event's body posted to the sink. Default is empty.
-	// Mutually exclusive with Data.
+	// Mutually exclusive with Data and DataTemplate.
 	// +optional
 	DataBase64 string `json:"dataBase64,omitempty"`
+
+	// DataTemplate is a Go template rendered at each tick as the body of the event posted
+	// to the sink. The template can refer to .ScheduleTime, the time the tick was scheduled at,
+	// .FireTime, the time the tick was actually fired at, and .Sequence, the sequence number
+	// of the tick. The rendered data is limited to the same size as Data. Default is empty.
+	// Mutually exclusive with Data and DataBase64.
+	// +optional
+	DataTemplate string `json:"dataTemplate,omitempty"`
+
+	// StartingDeadlineSeconds is the deadline in seconds for firing a tick that was
+	// missed, for example because the adapter was restarting. At most the latest missed
+	// tick is fired, as long as it is not older than the deadline.
+	// Defaults to no catch up, missed ticks are skipped.
+	// +optional
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+
+	// Delivery contains the retries and the dead letter sink of the events
+	// sent to the sink.
+	// +optional
+	Delivery *eventingduckv1.DeliverySpec `json:"delivery,omitempty"`
 }
 
 // PingSourceStatus defines the observed state of PingSource.
@@ -93,6 +129,20 @@ type PingSourceStatus struct {
 	// * SinkURI - the current active sink URI that has been configured for the
 	//   Source.
 	duckv1.SourceStatus `json:",inline"`
+
+	// LastScheduleTime is the time of the last tick whose event was delivered. It is only recorded
+	// for the sources catching up on the missed ticks, the one-shot sources and the sources with a
+	// DataTemplate.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// LastSequence is the sequence number of the last tick fired, whether its event was delivered
+	// or not.
+	// +optional
+	LastSequence int64 `json:"lastSequence,omitempty"`
+
+	// DeliveryStatus contains the resolved URL of the dead letter sink.
+	eventingduckv1.DeliveryStatus `json:",inline"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -23,12 +23,15 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 
 	"github.com/robfig/cron/v3"
 	"knative.dev/pkg/apis"
 
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/apis/feature"
 	"knative.dev/eventing/pkg/apis/sources/config"
 )
 
@@ -38,21 +41,25 @@ func (c *PingSource) Validate(ctx context.Context) *apis.FieldError {
 
 func (cs *PingSourceSpec) Validate(ctx context.Context) *apis.FieldError {
 	var errs *apis.FieldError
-	schedule := cs.Schedule
+	if cs.Interval != nil || cs.At != nil {
+		errs = cs.validateIntervalAt(ctx)
+	} else {
+		schedule := cs.Schedule
 
-	errs = validateDescriptor(schedule)
+		errs = validateDescriptor(schedule)
 
-	if cs.Timezone != "" {
-		schedule = "CRON_TZ=" + cs.Timezone + " " + schedule
-	}
+		if cs.Timezone != "" {
+			schedule = "CRON_TZ=" + cs.Timezone + " " + schedule
+		}
 
-	if _, err := cron.ParseStandard(schedule); err != nil {
-		if strings.HasPrefix(err.Error(), "provided bad location") {
-			fe := apis.ErrInvalidValue(err, "timezone")
-			errs = errs.Also(fe)
-		} else {
-			fe := apis.ErrInvalidValue(err, "schedule")
-			errs = errs.Also(fe)
+		if _, err := cron.ParseStandard(schedule); err != nil {
+			if strings.HasPrefix(err.Error(), "provided bad location") {
+				fe := apis.ErrInvalidValue(err, "timezone")
+				errs = errs.Also(fe)
+			} else {
+				fe := apis.ErrInvalidValue(err, "schedule")
+				errs = errs.Also(fe)
+			}
 		}
 	}
 
@@ -94,10 +101,97 @@ func (cs *PingSourceSpec) Validate(ctx context.Context) *apis.FieldError {
 			}
 		}
 	}
+	if cs.DataTemplate != "" {
+		errs = errs.Also(cs.validateDataTemplate(ctx, pingDefaults.DataMaxSize))
+	}
+	if cs.StartingDeadlineSeconds != nil {
+		if !feature.FromContext(ctx).IsEnabled(feature.PingSourceCatchUp) {
+			errs = errs.Also(apis.ErrDisallowedFields("startingDeadlineSeconds"))
+		} else if *cs.StartingDeadlineSeconds < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(*cs.StartingDeadlineSeconds, "startingDeadlineSeconds"))
+		}
+	}
+	errs = errs.Also(validateDelivery(ctx, cs.Delivery))
 	errs = errs.Also(cs.SourceSpec.Validate(ctx))
 	return errs
 }
 
+// validateIntervalAt validates the Interval and At schedules, which replace the cron schedule.
+func (cs *PingSourceSpec) validateIntervalAt(ctx context.Context) *apis.FieldError {
+	if !feature.FromContext(ctx).IsEnabled(feature.PingSourceIntervalAt) {
+		var fields []string
+		if cs.Interval != nil {
+			fields = append(fields, "interval")
+		}
+		if cs.At != nil {
+			fields = append(fields, "at")
+		}
+		return apis.ErrDisallowedFields(fields...)
+	}
+	if cs.Schedule != "" || (cs.Interval != nil && cs.At != nil) {
+		return apis.ErrMultipleOneOf("schedule", "interval", "at")
+	}
+	var errs *apis.FieldError
+	if cs.Timezone != "" {
+		errs = errs.Also(apis.ErrDisallowedFields("timezone"))
+	}
+	if cs.Interval != nil {
+		if cs.Interval.Duration < time.Second {
+			errs = errs.Also(apis.ErrInvalidValue(cs.Interval.Duration.String(), "interval", "the interval must be at least one second"))
+		} else if cs.Interval.Duration%time.Second != 0 {
+			errs = errs.Also(apis.ErrInvalidValue(cs.Interval.Duration.String(), "interval", "the interval must be a whole number of seconds"))
+		}
+	}
+	// The tick of a one-shot source created in the past would never fire.
+	if cs.At != nil && apis.IsInCreate(ctx) && cs.At.Time.Before(time.Now()) {
+		errs = errs.Also(apis.ErrInvalidValue(cs.At.Time.Format(time.RFC3339), "at", "the time must be in the future"))
+	}
+	return errs
+}
+
+// validateDataTemplate validates the DataTemplate by rendering it with the data of a sample tick.
+func (cs *PingSourceSpec) validateDataTemplate(ctx context.Context, dataMaxSize int64) *apis.FieldError {
+	if !feature.FromContext(ctx).IsEnabled(feature.PingSourceDataTemplate) {
+		return apis.ErrDisallowedFields("dataTemplate")
+	}
+	if cs.Data != "" || cs.DataBase64 != "" {
+		return apis.ErrMultipleOneOf("data", "dataBase64", "dataTemplate")
+	}
+	if bsize := int64(len(cs.DataTemplate)); dataMaxSize > -1 && bsize > dataMaxSize {
+		return apis.ErrInvalidValue(fmt.Sprintf("the dataTemplate length of %d bytes exceeds limit set at %d.", bsize, dataMaxSize), "dataTemplate")
+	}
+	tmpl, err := ParseDataTemplate(cs.DataTemplate)
+	if err != nil {
+		return apis.ErrInvalidValue(err, "dataTemplate")
+	}
+	now := time.Now()
+	data, err := RenderDataTemplate(tmpl, now, now, 1)
+	if err != nil {
+		return apis.ErrInvalidValue(err, "dataTemplate")
+	}
+	if bsize := int64(len(data)); dataMaxSize > -1 && bsize > dataMaxSize {
+		return apis.ErrInvalidValue(fmt.Sprintf("the rendered dataTemplate length of %d bytes exceeds limit set at %d.", bsize, dataMaxSize), "dataTemplate")
+	}
+	if cs.ContentType == cloudevents.ApplicationJSON {
+		// validate if the rendered data is valid JSON
+		if err := validateJSON(string(data)); err != nil {
+			return apis.ErrInvalidValue(err, "dataTemplate")
+		}
+	}
+	return nil
+}
+
+// validateDelivery validates the delivery of the events sent by a source.
+func validateDelivery(ctx context.Context, delivery *eventingduckv1.DeliverySpec) *apis.FieldError {
+	if delivery == nil {
+		return nil
+	}
+	if !feature.FromContext(ctx).IsEnabled(feature.SourceDelivery) {
+		return apis.ErrDisallowedFields("delivery")
+	}
+	return delivery.Validate(ctx).ViaField("delivery")
+}
+
 func validateJSON(str string) error {
 	var objmap map[string]interface{}
 	return json.Unmarshal([]byte(str), &objmap)
@@ -21,18 +21,25 @@ import (
 	"encoding/base64"
 	"strings"
 	"testing"
+	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 
 	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	"knative.dev/pkg/apis"
 
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/apis/feature"
 	"knative.dev/eventing/pkg/apis/sources/config"
 )
 
 func TestPingSourceValidation(t *testing.T) {
+	at := metav1.NewTime(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	future := metav1.NewTime(time.Now().Add(time.Hour))
 	tests := []struct {
 		name   string
 		source PingSource
@@ -437,6 +444,437 @@ func TestPingSourceValidation(t *testing.T) {
 				errs = errs.Also(fe)
 				return errs
 			}(),
+		}, {
+			name: "valid starting deadline",
+			source: PingSource{
+				Spec: PingSourceSpec{
+					Schedule:                "*/2 * * * *",
+					StartingDeadlineSeconds: pointer.Int64(300),
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			},
+			ctx:  withCatchUp(feature.Enabled),
+			want: nil,
+		}, {
+			name: "starting deadline with the feature disabled",
+			source: PingSource{
+				Spec: PingSourceSpec{
+					Schedule:                "*/2 * * * *",
+					StartingDeadlineSeconds: pointer.Int64(300),
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			},
+			ctx:  withCatchUp(feature.Disabled),
+			want: apis.ErrDisallowedFields("spec.startingDeadlineSeconds"),
+		}, {
+			name: "negative starting deadline",
+			source: PingSource{
+				Spec: PingSourceSpec{
+					Schedule:                "*/2 * * * *",
+					StartingDeadlineSeconds: pointer.Int64(-1),
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			},
+			ctx:  withCatchUp(feature.Enabled),
+			want: apis.ErrInvalidValue(-1, "spec.startingDeadlineSeconds"),
+		}, {
+			name: "valid delivery",
+			source: PingSource{
+				Spec: PingSourceSpec{
+					Schedule: "*/2 * * * *",
+					Delivery: &eventingduckv1.DeliverySpec{
+						Retry: pointer.Int32(3),
+					},
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			},
+			ctx:  withSourceDelivery(feature.Enabled),
+			want: nil,
+		}, {
+			name: "delivery with the feature disabled",
+			source: PingSource{
+				Spec: PingSourceSpec{
+					Schedule: "*/2 * * * *",
+					Delivery: &eventingduckv1.DeliverySpec{
+						Retry: pointer.Int32(3),
+					},
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			},
+			ctx:  withSourceDelivery(feature.Disabled),
+			want: apis.ErrDisallowedFields("spec.delivery"),
+		}, {
+			name: "invalid delivery",
+			source: PingSource{
+				Spec: PingSourceSpec{
+					Schedule: "*/2 * * * *",
+					Delivery: &eventingduckv1.DeliverySpec{
+						Retry: pointer.Int32(-1),
+					},
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			},
+			ctx:  withSourceDelivery(feature.Enabled),
+			want: apis.ErrInvalidValue(-1, "spec.delivery.retry"),
+		}, {
+			name: "valid data template",
+			source: PingSource{
+				Spec: PingSourceSpec{
+					Schedule:     "*/2 * * * *",
+					ContentType:  cloudevents.ApplicationJSON,
+					DataTemplate: `{"scheduled": "{{ .ScheduleTime.Format "2006-01-02T15:04:05Z07:00" }}", "sequence": {{ .Sequence }}}`,
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			},
+			ctx:  withDataTemplate(feature.Enabled),
+			want: nil,
+		}, {
+			name: "data template with the feature disabled",
+			source: PingSource{
+				Spec: PingSourceSpec{
+					Schedule:     "*/2 * * * *",
+					DataTemplate: `{{ .Sequence }}`,
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			},
+			ctx:  withDataTemplate(feature.Disabled),
+			want: apis.ErrDisallowedFields("spec.dataTemplate"),
+		}, {
+			name: "data template with data",
+			source: PingSource{
+				Spec: PingSourceSpec{
+					Schedule:     "*/2 * * * *",
+					Data:         "some data",
+					DataTemplate: `{{ .Sequence }}`,
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			},
+			ctx:  withDataTemplate(feature.Enabled),
+			want: apis.ErrMultipleOneOf("spec.data", "spec.dataBase64", "spec.dataTemplate"),
+		}, {
+			name: "data template with an unknown field",
+			source: PingSource{
+				Spec: PingSourceSpec{
+					Schedule:     "*/2 * * * *",
+					DataTemplate: `{{ .Unknown }}`,
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			},
+			ctx: withDataTemplate(feature.Enabled),
+			want: func() *apis.FieldError {
+				tmpl, _ := ParseDataTemplate(`{{ .Unknown }}`)
+				_, err := RenderDataTemplate(tmpl, time.Time{}, time.Time{}, 0)
+				return apis.ErrInvalidValue(err, "spec.dataTemplate")
+			}(),
+		}, {
+			name: "data template rendering invalid JSON",
+			source: PingSource{
+				Spec: PingSourceSpec{
+					Schedule:     "*/2 * * * *",
+					ContentType:  cloudevents.ApplicationJSON,
+					DataTemplate: `{"sequence": {{ .Sequence }}`,
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			},
+			ctx:  withDataTemplate(feature.Enabled),
+			want: apis.ErrInvalidValue(validateJSON(`{"sequence": 1`), "spec.dataTemplate"),
+		}, {
+			name: "rendered data template too big",
+			source: PingSource{
+				Spec: PingSourceSpec{
+					Schedule:     "*/2 * * * *",
+					DataTemplate: `{{ printf "%100d" .Sequence }}`,
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			},
+			ctx: func(ctx context.Context) context.Context {
+				ctx = withDataTemplate(feature.Enabled)(ctx)
+				return config.ToContext(ctx, &config.Config{PingDefaults: &config.PingDefaults{DataMaxSize: 80}})
+			},
+			want: apis.ErrInvalidValue("the rendered dataTemplate length of 100 bytes exceeds limit set at 80.", "spec.dataTemplate"),
+		}, {
+			name: "valid interval",
+			source: PingSource{
+				Spec: PingSourceSpec{
+					Interval: &metav1.Duration{Duration: 15 * time.Second},
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			},
+			ctx:  withIntervalAt(feature.Enabled),
+			want: nil,
+		}, {
+			name: "valid at",
+			source: PingSource{
+				Spec: PingSourceSpec{
+					At: &at,
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			},
+			ctx:  withIntervalAt(feature.Enabled),
+			want: nil,
+		}, {
+			name: "interval with the feature disabled",
+			source: PingSource{
+				Spec: PingSourceSpec{
+					Interval: &metav1.Duration{Duration: 15 * time.Second},
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			},
+			ctx:  withIntervalAt(feature.Disabled),
+			want: apis.ErrDisallowedFields("spec.interval"),
+		}, {
+			name: "interval and at",
+			source: PingSource{
+				Spec: PingSourceSpec{
+					Interval: &metav1.Duration{Duration: 15 * time.Second},
+					At:       &at,
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			},
+			ctx:  withIntervalAt(feature.Enabled),
+			want: apis.ErrMultipleOneOf("spec.schedule", "spec.interval", "spec.at"),
+		}, {
+			name: "schedule and at",
+			source: PingSource{
+				Spec: PingSourceSpec{
+					Schedule: "*/2 * * * *",
+					At:       &at,
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			},
+			ctx:  withIntervalAt(feature.Enabled),
+			want: apis.ErrMultipleOneOf("spec.schedule", "spec.interval", "spec.at"),
+		}, {
+			name: "at with a timezone",
+			source: PingSource{
+				Spec: PingSourceSpec{
+					Timezone: "Europe/Paris",
+					At:       &at,
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			},
+			ctx:  withIntervalAt(feature.Enabled),
+			want: apis.ErrDisallowedFields("spec.timezone"),
+		}, {
+			name: "sub-second interval",
+			source: PingSource{
+				Spec: PingSourceSpec{
+					Interval: &metav1.Duration{Duration: 500 * time.Millisecond},
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			},
+			ctx:  withIntervalAt(feature.Enabled),
+			want: apis.ErrInvalidValue("500ms", "spec.interval", "the interval must be at least one second"),
+		}, {
+			name: "interval not a whole number of seconds",
+			source: PingSource{
+				Spec: PingSourceSpec{
+					Interval: &metav1.Duration{Duration: 1500 * time.Millisecond},
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			},
+			ctx:  withIntervalAt(feature.Enabled),
+			want: apis.ErrInvalidValue("1.5s", "spec.interval", "the interval must be a whole number of seconds"),
+		}, {
+			name: "at in the past on create",
+			source: PingSource{
+				Spec: PingSourceSpec{
+					At: &at,
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			},
+			ctx: func(ctx context.Context) context.Context {
+				return apis.WithinCreate(withIntervalAt(feature.Enabled)(ctx))
+			},
+			want: apis.ErrInvalidValue("2023-01-01T12:00:00Z", "spec.at", "the time must be in the future"),
+		}, {
+			name: "at in the future on create",
+			source: PingSource{
+				Spec: PingSourceSpec{
+					At: &future,
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			},
+			ctx: func(ctx context.Context) context.Context {
+				return apis.WithinCreate(withIntervalAt(feature.Enabled)(ctx))
+			},
+			want: nil,
 		},
 	}
 
@@ -453,6 +891,31 @@ func TestPingSourceValidation(t *testing.T) {
 		})
 	}
 }
+
+func withCatchUp(flag feature.Flag) func(ctx context.Context) context.Context {
+	return func(ctx context.Context) context.Context {
+		return feature.ToContext(ctx, feature.Flags{feature.PingSourceCatchUp: flag})
+	}
+}
+
+func withSourceDelivery(flag feature.Flag) func(ctx context.Context) context.Context {
+	return func(ctx context.Context) context.Context {
+		return feature.ToContext(ctx, feature.Flags{feature.SourceDelivery: flag})
+	}
+}
+
+func withDataTemplate(flag feature.Flag) func(ctx context.Context) context.Context {
+	return func(ctx context.Context) context.Context {
+		return feature.ToContext(ctx, feature.Flags{feature.PingSourceDataTemplate: flag})
+	}
+}
+
+func withIntervalAt(flag feature.Flag) func(ctx context.Context) context.Context {
+	return func(ctx context.Context) context.Context {
+		return feature.ToContext(ctx, feature.Flags{feature.PingSourceIntervalAt: flag})
+	}
+}
+
 func bigString() string {
 	var b strings.Builder
 	b.Grow(5000)
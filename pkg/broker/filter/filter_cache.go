@@ -0,0 +1,121 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/eventfilter"
+	"knative.dev/eventing/pkg/eventfilter/attributes"
+	"knative.dev/eventing/pkg/eventfilter/subscriptionsapi"
+)
+
+// triggerFilters holds the materialized filters of a Trigger at a given generation.
+type triggerFilters struct {
+	generation int64
+	// subscriptionsAPI is the materialized Trigger.Spec.Filters, nil if the Trigger has none.
+	subscriptionsAPI eventfilter.Filter
+	// attributes is the materialized Trigger.Spec.Filter, nil if the Trigger has none.
+	attributes eventfilter.Filter
+}
+
+// filterCache caches the materialized filters of Triggers, keyed by Trigger UID.
+// An entry is only valid for the Trigger generation it was built from.
+type filterCache struct {
+	mu      sync.RWMutex
+	filters map[types.UID]*triggerFilters
+}
+
+func newFilterCache() *filterCache {
+	return &filterCache{
+		filters: make(map[types.UID]*triggerFilters),
+	}
+}
+
+// Get returns the materialized filters of the given Trigger, building and caching them
+// if they are missing or were built from a different generation.
+func (c *filterCache) Get(ctx context.Context, t *eventingv1.Trigger) *triggerFilters {
+	c.mu.RLock()
+	f, ok := c.filters[t.UID]
+	c.mu.RUnlock()
+	if ok && f.generation == t.Generation {
+		return f
+	}
+	return c.Set(ctx, t)
+}
+
+// Set materializes the filters of the given Trigger and stores them in the cache.
+func (c *filterCache) Set(ctx context.Context, t *eventingv1.Trigger) *triggerFilters {
+	f := materializeTriggerFilters(ctx, t)
+	c.mu.Lock()
+	c.filters[t.UID] = f
+	c.mu.Unlock()
+	return f
+}
+
+// Delete removes the filters of the Trigger with the given UID from the cache.
+func (c *filterCache) Delete(uid types.UID) {
+	c.mu.Lock()
+	delete(c.filters, uid)
+	c.mu.Unlock()
+}
+
+// EventHandler returns an informer event handler keeping the cache in sync with the Triggers.
+func (c *filterCache) EventHandler(ctx context.Context) cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if t, ok := obj.(*eventingv1.Trigger); ok {
+				c.Set(ctx, t)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			t, ok := newObj.(*eventingv1.Trigger)
+			if !ok {
+				return
+			}
+			if old, ok := oldObj.(*eventingv1.Trigger); ok && old.Generation == t.Generation && old.UID == t.UID {
+				// Status only update, the filters didn't change.
+				return
+			}
+			c.Set(ctx, t)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if t, ok := obj.(*eventingv1.Trigger); ok {
+				c.Delete(t.UID)
+			}
+		},
+	}
+}
+
+func materializeTriggerFilters(ctx context.Context, t *eventingv1.Trigger) *triggerFilters {
+	f := &triggerFilters{generation: t.Generation}
+	if len(t.Spec.Filters) > 0 {
+		f.subscriptionsAPI = subscriptionsapi.CreateSubscriptionsAPIFilters(ctx, t.Spec.Filters)
+	}
+	if t.Spec.Filter != nil {
+		f.attributes = attributes.NewAttributesFilter(t.Spec.Filter.Attributes)
+	}
+	return f
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/eventfilter"
+)
+
+func TestFilterCache(t *testing.T) {
+	ctx := context.Background()
+	c := newFilterCache()
+
+	trigger := &eventingv1.Trigger{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  testNS,
+			Name:       triggerName,
+			UID:        triggerUID,
+			Generation: 1,
+		},
+		Spec: eventingv1.TriggerSpec{
+			Filters: []eventingv1.SubscriptionsAPIFilter{{CESQL: "type = '" + eventType + "'"}},
+		},
+	}
+
+	first := c.Get(ctx, trigger)
+	if first.subscriptionsAPI == nil {
+		t.Fatal("Expected subscriptions API filter to be materialized")
+	}
+	if first.attributes != nil {
+		t.Error("Expected no attributes filter, got", first.attributes)
+	}
+	if got := first.subscriptionsAPI.Filter(ctx, *makeEvent()); got != eventfilter.PassFilter {
+		t.Errorf("Filter() = %v, want %v", got, eventfilter.PassFilter)
+	}
+	if cached := c.Get(ctx, trigger); cached != first {
+		t.Error("Expected the cached filters for the same generation")
+	}
+
+	updated := trigger.DeepCopy()
+	updated.Generation = 2
+	updated.Spec.Filters = []eventingv1.SubscriptionsAPIFilter{{CESQL: "type = 'some.other.type'"}}
+	second := c.Get(ctx, updated)
+	if second == first {
+		t.Fatal("Expected the filters to be materialized again for a new generation")
+	}
+	if got := second.subscriptionsAPI.Filter(ctx, *makeEvent()); got != eventfilter.FailFilter {
+		t.Errorf("Filter() = %v, want %v", got, eventfilter.FailFilter)
+	}
+}
+
+func TestFilterCacheEventHandler(t *testing.T) {
+	ctx := context.Background()
+	c := newFilterCache()
+	h := c.EventHandler(ctx)
+
+	trigger := &eventingv1.Trigger{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  testNS,
+			Name:       triggerName,
+			UID:        triggerUID,
+			Generation: 1,
+		},
+		Spec: eventingv1.TriggerSpec{
+			Filter: &eventingv1.TriggerFilter{
+				Attributes: map[string]string{"type": eventType},
+			},
+		},
+	}
+
+	h.OnAdd(trigger)
+	if f, ok := c.filters[trigger.UID]; !ok || f.attributes == nil {
+		t.Fatal("Expected attributes filter to be cached on add")
+	}
+
+	updated := trigger.DeepCopy()
+	updated.Generation = 2
+	updated.Spec.Filter = nil
+	h.OnUpdate(trigger, updated)
+	if f, ok := c.filters[trigger.UID]; !ok || f.generation != 2 || f.attributes != nil {
+		t.Fatalf("Expected filters to be refreshed on update, got %+v", f)
+	}
+
+	h.OnDelete(cache.DeletedFinalStateUnknown{Key: testNS + "/" + triggerName, Obj: updated})
+	if _, ok := c.filters[trigger.UID]; ok {
+		t.Error("Expected filters to be removed on delete")
+	}
+}
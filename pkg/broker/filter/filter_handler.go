@@ -18,6 +18,7 @@ package filter
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -37,13 +38,13 @@ import (
 	channelAttributes "knative.dev/eventing/pkg/channel/attributes"
 	"knative.dev/pkg/logging"
 
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
 	"knative.dev/eventing/pkg/apis/feature"
 	broker "knative.dev/eventing/pkg/broker"
+	eventinginformers "knative.dev/eventing/pkg/client/informers/externalversions/eventing/v1"
 	eventinglisters "knative.dev/eventing/pkg/client/listers/eventing/v1"
 	"knative.dev/eventing/pkg/eventfilter"
-	"knative.dev/eventing/pkg/eventfilter/attributes"
-	"knative.dev/eventing/pkg/eventfilter/subscriptionsapi"
 	"knative.dev/eventing/pkg/kncloudevents"
 	"knative.dev/eventing/pkg/reconciler/sugar/trigger/path"
 	"knative.dev/eventing/pkg/tracing"
@@ -64,6 +65,10 @@ const (
 const (
 	// NoResponse signals the step that send event to trigger's subscriber hasn't started
 	NoResponse = -1
+
+	// maxFailedResponseBodySize is the maximum size of the body of a failed response read to be
+	// reported in a dead letter event.
+	maxFailedResponseBodySize = 1024 * 1024
 )
 
 // ErrHandler handle the different errors of filter dispatch process
@@ -73,8 +78,10 @@ type ErrHandler struct {
 	err          error
 }
 
-// HeaderProxyAllowList contains the headers that are proxied from the reply; other than the CloudEvents headers.
-// Other headers are not proxied because of security concerns.
+// HeaderProxyAllowList contains the headers that are proxied from the reply by default; other than the CloudEvents headers.
+//
+// Deprecated: the headers proxied from the reply are configured with the config-headers ConfigMap and this
+// list is no longer used, see utils.GetHeadersPolicy.
 var HeaderProxyAllowList = map[string]struct{}{
 	strings.ToLower("Retry-After"): {},
 }
@@ -88,7 +95,12 @@ type Handler struct {
 	// reporter reports stats of status code and dispatch time
 	reporter StatsReporter
 
-	triggerLister      eventinglisters.TriggerLister
+	triggerLister eventinglisters.TriggerLister
+	// filters caches the materialized filters of the Triggers
+	filters *filterCache
+	// ordering serializes the dispatch of events sharing the same partition key
+	ordering *keyedQueue
+
 	logger             *zap.Logger
 	withContext        func(ctx context.Context) context.Context
 	ignoreResponseBody bool
@@ -96,7 +108,9 @@ type Handler struct {
 
 // NewHandler creates a new Handler and its associated MessageReceiver. The caller is responsible for
 // Start()ing the returned Handler.
-func NewHandler(logger *zap.Logger, triggerLister eventinglisters.TriggerLister, reporter StatsReporter, port int, wc func(ctx context.Context) context.Context, ignoreResponseBody bool) (*Handler, error) {
+//
+// The Handler keeps its cache of Trigger filters up to date using the events of the given triggerInformer.
+func NewHandler(logger *zap.Logger, triggerInformer eventinginformers.TriggerInformer, reporter StatsReporter, port int, wc func(ctx context.Context) context.Context, ignoreResponseBody bool) (*Handler, error) {
 	kncloudevents.ConfigureConnectionArgs(&kncloudevents.ConnectionArgs{
 		MaxIdleConns:        defaultMaxIdleConnections,
 		MaxIdleConnsPerHost: defaultMaxIdleConnectionsPerHost,
@@ -107,11 +121,16 @@ func NewHandler(logger *zap.Logger, triggerLister eventinglisters.TriggerLister,
 		return nil, fmt.Errorf("failed to create message sender: %w", err)
 	}
 
+	filters := newFilterCache()
+	triggerInformer.Informer().AddEventHandler(filters.EventHandler(logging.WithLogger(context.Background(), logger.Sugar())))
+
 	return &Handler{
 		receiver:           kncloudevents.NewHTTPMessageReceiver(port),
 		sender:             sender,
 		reporter:           reporter,
-		triggerLister:      triggerLister,
+		triggerLister:      triggerInformer.Lister(),
+		filters:            filters,
+		ordering:           newKeyedQueue(defaultMaxPendingPerKey),
 		logger:             logger,
 		withContext:        wc,
 		ignoreResponseBody: ignoreResponseBody,
@@ -211,10 +230,17 @@ func (h *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 		_ = h.reporter.ReportEventCount(reportArgs, http.StatusBadRequest)
 		return
 	}
+	if t.Spec.Reply != nil && t.Status.ReplyURI == nil {
+		// The replies must not be sent back to the Broker until the reply destination is resolved,
+		// the channel retries the event until the Trigger is reconciled.
+		writer.WriteHeader(http.StatusServiceUnavailable)
+		_ = h.reporter.ReportEventCount(reportArgs, http.StatusServiceUnavailable)
+		return
+	}
 
 	// Check if the event should be sent.
 	ctx = logging.WithLogger(ctx, h.logger.Sugar().With(zap.String("trigger", fmt.Sprintf("%s/%s", t.GetNamespace(), t.GetName()))))
-	filterResult := filterEvent(ctx, t.Spec, *event)
+	filterResult := filterEvent(ctx, t.Spec, h.filters.Get(ctx, t), *event)
 
 	if filterResult == eventfilter.FailFilter {
 		// We do not count the event. The event will be counted in the broker ingress.
@@ -224,15 +250,131 @@ func (h *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 
 	h.reportArrivalTime(event, reportArgs)
 
-	h.send(ctx, writer, request.Header, subscriberURI.URL(), reportArgs, event, ttl)
+	release, statusCode := h.acquireOrdering(ctx, t, event)
+	if release == nil {
+		writer.WriteHeader(statusCode)
+		_ = h.reporter.ReportEventCount(reportArgs, statusCode)
+		return
+	}
+	defer release()
+
+	h.send(ctx, writer, request.Header, subscriberURI.URL(), h.deliveryFor(t), reportArgs, event, ttl)
+}
+
+// delivery holds how the event is delivered to a Trigger's subscriber.
+type delivery struct {
+	// retryConfig is the retry configuration of the delivery, nil means a single attempt.
+	retryConfig *kncloudevents.RetryConfig
+	// deadLetterSink is where the event is sent when the delivery to the subscriber fails,
+	// nil means the failure is returned to the channel.
+	deadLetterSink *url.URL
+	// subscriber references the Trigger in the events sent to the dead letter sink.
+	subscriber *channelAttributes.SubscriberReference
+	// reply is where the subscriber replies are sent.
+	reply reply
+}
+
+// reply holds where the replies of a Trigger's subscriber are sent.
+type reply struct {
+	// discard drops the replies instead of sending them back to the Broker.
+	discard bool
+	// target is where the replies are sent, nil means they are sent back to the Broker.
+	target *url.URL
+}
+
+// deliveryFor returns how events are delivered to the subscriber of the given Trigger.
+//
+// When the Trigger has its own delivery spec, retries and dead lettering happen here for
+// this Trigger only, instead of being returned to the Broker's channel.
+func (h *Handler) deliveryFor(t *eventingv1.Trigger) delivery {
+	d := delivery{}
+	if t.Spec.DiscardReplies != nil && *t.Spec.DiscardReplies {
+		d.reply.discard = true
+	} else if t.Spec.Reply != nil && t.Status.ReplyURI != nil {
+		d.reply.target = t.Status.ReplyURI.URL()
+	}
+	if t.Spec.Delivery == nil {
+		return d
+	}
+	retryConfig, err := kncloudevents.RetryConfigFromDeliverySpec(*t.Spec.Delivery)
+	if err != nil {
+		h.logger.Warn("Failed to create the retry config from the Trigger's delivery spec", zap.Error(err), zap.String("trigger", fmt.Sprintf("%s/%s", t.Namespace, t.Name)))
+	} else {
+		d.retryConfig = &retryConfig
+	}
+	if t.Status.DeadLetterSinkURI != nil {
+		d.deadLetterSink = t.Status.DeadLetterSinkURI.URL()
+		d.subscriber = &channelAttributes.SubscriberReference{Kind: "Trigger", Namespace: t.Namespace, Name: t.Name, UID: t.UID}
+	}
+	return d
+}
+
+// deadLetterPayload returns what is sent to the dead letter sink of the delivery.
+func (d delivery) deadLetterPayload() eventingduckv1.DeadLetterPayloadType {
+	if d.retryConfig == nil || d.retryConfig.DeadLetterPayload == "" {
+		return eventingduckv1.DeadLetterPayloadOriginal
+	}
+	return d.retryConfig.DeadLetterPayload
 }
 
-func (h *Handler) send(ctx context.Context, writer http.ResponseWriter, headers http.Header, target *url.URL, reportArgs *ReportArgs, event *cloudevents.Event, ttl int32) {
+// acquireOrdering waits until the event can be dispatched according to the ordering of the
+// Trigger's delivery. It returns the function to call once the event has been dispatched, or nil
+// and the status code to reply with when the event can't be dispatched.
+func (h *Handler) acquireOrdering(ctx context.Context, t *eventingv1.Trigger, event *cloudevents.Event) (func(), int) {
+	noop := func() {}
+	if !feature.FromContext(ctx).IsEnabled(feature.DeliveryOrdering) ||
+		t.Spec.Delivery == nil || t.Spec.Delivery.Ordering == nil ||
+		*t.Spec.Delivery.Ordering != eventingduckv1.DeliveryOrderingKeyed {
+		return noop, 0
+	}
+
+	var key string
+	if err := event.ExtensionAs(eventingduckv1.PartitionKeyExtension, &key); err != nil || key == "" {
+		// Events without a partition key are not ordered.
+		return noop, 0
+	}
+
+	release, err := h.ordering.Acquire(ctx, string(t.UID)+"/"+key)
+	if errors.Is(err, errQueueFull) {
+		h.logger.Info("Too many pending events for the partition key", zap.String("partitionKey", key))
+		return nil, http.StatusTooManyRequests
+	}
+	if err != nil {
+		h.logger.Info("Gave up waiting for the partition key", zap.String("partitionKey", key), zap.Error(err))
+		return nil, http.StatusServiceUnavailable
+	}
+	return release, 0
+}
+
+func (h *Handler) send(ctx context.Context, writer http.ResponseWriter, headers http.Header, target *url.URL, d delivery, reportArgs *ReportArgs, event *cloudevents.Event, ttl int32) {
+	// Count the attempts when they are reported to the dead letter sink.
+	retryConfig, attempts := d.retryConfig, func() int { return 1 }
+	if d.deadLetterSink != nil && d.deadLetterPayload() != eventingduckv1.DeadLetterPayloadOriginal {
+		retryConfig, attempts = kncloudevents.CountAttempts(d.retryConfig)
+	}
+	// The knativeerrordata extension is truncated, a dead letter event reports the whole body.
+	maxBodySize := channelAttributes.KnativeErrorDataExtensionMaxLength
+	if d.deadLetterSink != nil && d.deadLetterPayload() != eventingduckv1.DeadLetterPayloadOriginal {
+		maxBodySize = maxFailedResponseBodySize
+	}
+	start := time.Now()
+
 	// send the event to trigger's subscriber
-	response, responseErr := h.sendEvent(ctx, headers, target, event, reportArgs)
+	response, responseErr := h.sendEvent(ctx, headers, target, event, retryConfig, maxBodySize, reportArgs)
 
 	if responseErr.err != nil {
 		h.logger.Error("failed to send event", zap.Error(responseErr.err))
+		if d.deadLetterSink != nil {
+			failure := deliveryFailure{target: target, responseErr: responseErr, attempts: attempts(), start: start}
+			err := h.sendToDeadLetterSink(ctx, headers, d, event, failure)
+			if err == nil {
+				// The event is now owned by the dead letter sink, so the channel must not redeliver it.
+				writer.WriteHeader(http.StatusAccepted)
+				_ = h.reporter.ReportEventCount(reportArgs, responseErr.ResponseCode)
+				return
+			}
+			h.logger.Error("failed to send event to the dead letter sink", zap.Error(err), zap.Any("deadLetterSink", d.deadLetterSink.String()))
+		}
 		// If error is not because of the response, it should respond with http.StatusInternalServerError
 		if responseErr.ResponseCode == NoResponse {
 
@@ -265,14 +407,16 @@ func (h *Handler) send(ctx context.Context, writer http.ResponseWriter, headers
 	h.logger.Debug("Successfully dispatched message", zap.Any("target", target.String()))
 
 	// If there is an event in the response write it to the response
-	statusCode, err := h.writeResponse(ctx, writer, response, ttl, target.String())
+	statusCode, err := h.writeResponse(ctx, writer, headers, response, d, ttl, target.String())
 	if err != nil {
 		h.logger.Error("failed to write response", zap.Error(err))
 	}
 	_ = h.reporter.ReportEventCount(reportArgs, statusCode)
 }
 
-func (h *Handler) sendEvent(ctx context.Context, headers http.Header, target *url.URL, event *cloudevents.Event, reporterArgs *ReportArgs) (*http.Response, ErrHandler) {
+// sendEvent sends the event to the target. The body of a failed response, up to maxBodySize
+// bytes, is read into the returned ErrHandler.
+func (h *Handler) sendEvent(ctx context.Context, headers http.Header, target *url.URL, event *cloudevents.Event, retryConfig *kncloudevents.RetryConfig, maxBodySize int, reporterArgs *ReportArgs) (*http.Response, ErrHandler) {
 	responseErr := ErrHandler{
 		ResponseCode: NoResponse,
 	}
@@ -299,7 +443,7 @@ func (h *Handler) sendEvent(ctx context.Context, headers http.Header, target *ur
 	}
 
 	start := time.Now()
-	resp, err := h.sender.Send(req)
+	resp, err := h.sender.SendWithRetries(req, retryConfig)
 	dispatchTime := time.Since(start)
 	if err != nil {
 		responseErr.ResponseCode = http.StatusInternalServerError
@@ -319,14 +463,12 @@ func (h *Handler) sendEvent(ctx context.Context, headers http.Header, target *ur
 	if resp.StatusCode < http.StatusOK ||
 		resp.StatusCode >= http.StatusMultipleChoices {
 		// Read response body into errHandler for failures
-		body := make([]byte, channelAttributes.KnativeErrorDataExtensionMaxLength)
-
-		readLen, readErr := resp.Body.Read(body)
-		if readErr != nil && readErr != io.EOF {
+		body, readErr := io.ReadAll(io.LimitReader(resp.Body, int64(maxBodySize)))
+		if readErr != nil {
 			h.logger.Error("failed to read response body into DispatchExecutionInfo", zap.Error(readErr))
 			responseErr.ResponseBody = []byte(fmt.Sprintf("dispatch error: %s", readErr.Error()))
 		} else {
-			responseErr.ResponseBody = body[:readLen]
+			responseErr.ResponseBody = body
 		}
 		responseErr.err = fmt.Errorf("unexpected HTTP response, expected 2xx, got %d", resp.StatusCode)
 
@@ -337,8 +479,87 @@ func (h *Handler) sendEvent(ctx context.Context, headers http.Header, target *ur
 	return resp, responseErr
 }
 
+// deliveryFailure describes the failed delivery of an event to target.
+type deliveryFailure struct {
+	target      *url.URL
+	responseErr ErrHandler
+	attempts    int
+	start       time.Time
+}
+
+// sendToDeadLetterSink sends the event to the dead letter sink of the delivery. By default, it is
+// the original event with the knativeerror* extensions describing the failed delivery, otherwise
+// an event wrapping or referencing it.
+func (h *Handler) sendToDeadLetterSink(ctx context.Context, headers http.Header, d delivery, event *cloudevents.Event, failure deliveryFailure) error {
+	payload := d.deadLetterPayload()
+	if payload == eventingduckv1.DeadLetterPayloadOriginal {
+		// Encodes response body as base64, the transformers truncate it to the max extension length.
+		errData := base64.StdEncoding.EncodeToString(failure.responseErr.ResponseBody)
+		transformers := channelAttributes.KnativeErrorTransformers(*failure.target, failure.responseErr.ResponseCode, errData)
+
+		return h.forward(ctx, headers, d.deadLetterSink, event, d.retryConfig, transformers...)
+	}
+
+	deadLetterEvent, err := channelAttributes.NewDeadLetterEvent(event, channelAttributes.DeadLetterEventData{
+		Destination:      failure.target.String(),
+		ResponseCode:     failure.responseErr.ResponseCode,
+		ResponseBody:     string(failure.responseErr.ResponseBody),
+		Attempts:         failure.attempts,
+		FirstAttemptTime: failure.start,
+		LastAttemptTime:  time.Now(),
+		Subscriber:       d.subscriber,
+	}, payload == eventingduckv1.DeadLetterPayloadEnvelope)
+	if err != nil {
+		return fmt.Errorf("failed to create the dead letter event: %w", err)
+	}
+	return h.forward(ctx, headers, d.deadLetterSink, deadLetterEvent, d.retryConfig)
+}
+
+// sendReply sends the reply event of a subscriber to the reply destination of the Trigger.
+func (h *Handler) sendReply(ctx context.Context, headers http.Header, target *url.URL, event *cloudevents.Event, retryConfig *kncloudevents.RetryConfig) error {
+	return h.forward(ctx, headers, target, event, retryConfig)
+}
+
+// forward sends the event to target, ignoring the response body, and fails unless target accepts the event.
+func (h *Handler) forward(ctx context.Context, headers http.Header, target *url.URL, event *cloudevents.Event, retryConfig *kncloudevents.RetryConfig, transformers ...binding.Transformer) error {
+	req, err := h.sender.NewCloudEventRequestWithTarget(ctx, target.String())
+	if err != nil {
+		return fmt.Errorf("failed to create the request: %w", err)
+	}
+
+	message := binding.ToMessage(event)
+	defer message.Finish(nil)
+
+	err = kncloudevents.WriteHTTPRequestWithAdditionalHeaders(ctx, message, req, utils.PassThroughHeaders(headers), transformers...)
+	if err != nil {
+		return fmt.Errorf("failed to write request: %w", err)
+	}
+
+	resp, err := h.sender.SendWithRetries(req, retryConfig)
+	if err != nil {
+		return fmt.Errorf("failed to dispatch message: %w", err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK ||
+		resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected HTTP response, expected 2xx, got %d", resp.StatusCode)
+	}
+	return nil
+}
+
 // The return values are the status
-func (h *Handler) writeResponse(ctx context.Context, writer http.ResponseWriter, resp *http.Response, ttl int32, target string) (int, error) {
+func (h *Handler) writeResponse(ctx context.Context, writer http.ResponseWriter, headers http.Header, resp *http.Response, d delivery, ttl int32, target string) (int, error) {
+	if d.reply.discard {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		proxyHeaders(resp.Header, writer)
+		h.logger.Debug("Discarding the response", zap.Any("target", target))
+		writer.WriteHeader(resp.StatusCode)
+		return resp.StatusCode, nil
+	}
+
 	response := cehttp.NewMessageFromHttpResponse(resp)
 	defer response.Finish(nil)
 
@@ -377,6 +598,17 @@ func (h *Handler) writeResponse(ctx context.Context, writer http.ResponseWriter,
 		return http.StatusInternalServerError, fmt.Errorf("failed to reset TTL: %w", err)
 	}
 
+	if d.reply.target != nil {
+		if err := h.sendReply(ctx, headers, d.reply.target, event, d.retryConfig); err != nil {
+			writer.WriteHeader(http.StatusBadGateway)
+			return http.StatusBadGateway, fmt.Errorf("failed to send the reply: %w", err)
+		}
+		proxyHeaders(resp.Header, writer)
+		h.logger.Debug("Sent the CloudEvent response to the reply destination", zap.Any("target", target), zap.String("reply", d.reply.target.String()))
+		writer.WriteHeader(resp.StatusCode)
+		return resp.StatusCode, nil
+	}
+
 	eventResponse := binding.ToMessage(event)
 	defer eventResponse.Finish(nil)
 
@@ -415,82 +647,20 @@ func (h *Handler) getTrigger(ref path.NamespacedNameUID) (*eventingv1.Trigger, e
 	return t, nil
 }
 
-func filterEvent(ctx context.Context, triggerSpec eventingv1.TriggerSpec, event cloudevents.Event) eventfilter.FilterResult {
+func filterEvent(ctx context.Context, triggerSpec eventingv1.TriggerSpec, filters *triggerFilters, event cloudevents.Event) eventfilter.FilterResult {
 	switch {
-	case feature.FromContext(ctx).IsEnabled(feature.NewTriggerFilters) && len(triggerSpec.Filters) > 0:
+	case feature.FromContext(ctx).IsEnabled(feature.NewTriggerFilters) && filters.subscriptionsAPI != nil:
 		logging.FromContext(ctx).Debugw("New trigger filters feature is enabled. Applying new filters.", zap.Any("filters", triggerSpec.Filters))
-		return applySubscriptionsAPIFilters(ctx, triggerSpec.Filters, event)
-	case triggerSpec.Filter != nil:
+		return filters.subscriptionsAPI.Filter(ctx, event)
+	case filters.attributes != nil:
 		logging.FromContext(ctx).Debugw("Applying attributes filter.", zap.Any("filter", triggerSpec.Filter))
-		return applyAttributesFilter(ctx, triggerSpec.Filter, event)
+		return filters.attributes.Filter(ctx, event)
 	default:
 		logging.FromContext(ctx).Debugw("Found no filters in trigger", zap.Any("triggerSpec", triggerSpec))
 		return eventfilter.NoFilter
 	}
 }
 
-func applySubscriptionsAPIFilters(ctx context.Context, filters []eventingv1.SubscriptionsAPIFilter, event cloudevents.Event) eventfilter.FilterResult {
-	return subscriptionsapi.NewAllFilter(materializeFiltersList(ctx, filters)...).Filter(ctx, event)
-}
-
-func materializeSubscriptionsAPIFilter(ctx context.Context, filter eventingv1.SubscriptionsAPIFilter) eventfilter.Filter {
-	var materializedFilter eventfilter.Filter
-	var err error
-	switch {
-	case len(filter.Exact) > 0:
-		// The webhook validates that this map has only a single key:value pair.
-		materializedFilter, err = subscriptionsapi.NewExactFilter(filter.Exact)
-		if err != nil {
-			logging.FromContext(ctx).Debugw("Invalid exact expression", zap.Any("filters", filter.Exact), zap.Error(err))
-			return nil
-		}
-	case len(filter.Prefix) > 0:
-		// The webhook validates that this map has only a single key:value pair.
-		materializedFilter, err = subscriptionsapi.NewPrefixFilter(filter.Prefix)
-		if err != nil {
-			logging.FromContext(ctx).Debugw("Invalid prefix expression", zap.Any("filters", filter.Exact), zap.Error(err))
-			return nil
-		}
-	case len(filter.Suffix) > 0:
-		// The webhook validates that this map has only a single key:value pair.
-		materializedFilter, err = subscriptionsapi.NewSuffixFilter(filter.Suffix)
-		if err != nil {
-			logging.FromContext(ctx).Debugw("Invalid suffix expression", zap.Any("filters", filter.Exact), zap.Error(err))
-			return nil
-		}
-	case len(filter.All) > 0:
-		materializedFilter = subscriptionsapi.NewAllFilter(materializeFiltersList(ctx, filter.All)...)
-	case len(filter.Any) > 0:
-		materializedFilter = subscriptionsapi.NewAnyFilter(materializeFiltersList(ctx, filter.Any)...)
-	case filter.Not != nil:
-		materializedFilter = subscriptionsapi.NewNotFilter(materializeSubscriptionsAPIFilter(ctx, *filter.Not))
-	case filter.CESQL != "":
-		if materializedFilter, err = subscriptionsapi.NewCESQLFilter(filter.CESQL); err != nil {
-			// This is weird, CESQL expression should be validated when Trigger's are created.
-			logging.FromContext(ctx).Debugw("Found an Invalid CE SQL expression", zap.String("expression", filter.CESQL))
-			return nil
-		}
-	}
-	return materializedFilter
-}
-
-func materializeFiltersList(ctx context.Context, filters []eventingv1.SubscriptionsAPIFilter) []eventfilter.Filter {
-	materializedFilters := make([]eventfilter.Filter, 0, len(filters))
-	for _, f := range filters {
-		f := materializeSubscriptionsAPIFilter(ctx, f)
-		if f == nil {
-			logging.FromContext(ctx).Warnw("Failed to parse filter. Skipping filter.", zap.Any("filter", f))
-			continue
-		}
-		materializedFilters = append(materializedFilters, f)
-	}
-	return materializedFilters
-}
-
-func applyAttributesFilter(ctx context.Context, filter *eventingv1.TriggerFilter, event cloudevents.Event) eventfilter.FilterResult {
-	return attributes.NewAttributesFilter(filter.Attributes).Filter(ctx, event)
-}
-
 // triggerFilterAttribute returns the filter attribute value for a given `attributeName`. If it doesn't not exist,
 // returns the any value filter.
 func triggerFilterAttribute(filter *eventingv1.TriggerFilter, attributeName string) string {
@@ -508,15 +678,10 @@ func triggerFilterAttribute(filter *eventingv1.TriggerFilter, attributeName stri
 func proxyHeaders(httpHeader http.Header, writer http.ResponseWriter) {
 	for headerKey, headerValues := range httpHeader {
 		// *Only* proxy some headers because of security reasons
-		if isInProxyHeaderAllowList(headerKey) {
+		if utils.IsReplyHeaderAllowed(headerKey) {
 			for _, headerValue := range headerValues {
 				writer.Header().Add(headerKey, headerValue)
 			}
 		}
 	}
 }
-
-func isInProxyHeaderAllowList(headerKey string) bool {
-	_, exists := HeaderProxyAllowList[strings.ToLower(headerKey)]
-	return exists
-}
@@ -23,6 +23,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -38,12 +39,18 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
 	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
 	"knative.dev/eventing/pkg/apis/feature"
 	"knative.dev/eventing/pkg/broker"
-	reconcilertesting "knative.dev/eventing/pkg/reconciler/testing/v1"
+	channelAttributes "knative.dev/eventing/pkg/channel/attributes"
+	eventingclientfake "knative.dev/eventing/pkg/client/clientset/versioned/fake"
+	eventinginformers "knative.dev/eventing/pkg/client/informers/externalversions"
+	eventinginformersv1 "knative.dev/eventing/pkg/client/informers/externalversions/eventing/v1"
 )
 
 const (
@@ -63,6 +70,8 @@ const (
 
 var (
 	validPath = fmt.Sprintf("/triggers/%s/%s/%s", testNS, triggerName, triggerUID)
+
+	linearBackoff = eventingduckv1.BackoffPolicyLinear
 )
 
 type TriggerOption func(trigger *eventingv1.Trigger)
@@ -435,11 +444,10 @@ func TestReceiver(t *testing.T) {
 				}
 				correctURI = append(correctURI, trig)
 			}
-			listers := reconcilertesting.NewListers(correctURI)
 			reporter := &mockReporter{}
 			r, err := NewHandler(
 				zaptest.NewLogger(t, zaptest.WrapOptions(zap.AddCaller())),
-				listers.GetTriggerLister(),
+				newTriggerInformer(t, correctURI),
 				reporter,
 				8080,
 				func(ctx context.Context) context.Context {
@@ -536,6 +544,272 @@ func TestReceiver(t *testing.T) {
 	}
 }
 
+func TestReceiver_WithDelivery(t *testing.T) {
+	testCases := map[string]struct {
+		retry                  int32
+		subscriberFailures     int
+		withDeadLetterSink     bool
+		deadLetterSinkFails    bool
+		deadLetterPayload      eventingduckv1.DeadLetterPayloadType
+		expectedAttempts       int
+		expectedStatus         int
+		expectedDeadLetterCode int
+	}{
+		"Retries until success": {
+			retry:              3,
+			subscriberFailures: 2,
+			expectedAttempts:   3,
+			expectedStatus:     http.StatusAccepted,
+		},
+		"Retries exhausted without dead letter sink": {
+			retry:              2,
+			subscriberFailures: 10,
+			expectedAttempts:   3,
+			expectedStatus:     http.StatusServiceUnavailable,
+		},
+		"Retries exhausted with dead letter sink": {
+			retry:                  2,
+			subscriberFailures:     10,
+			withDeadLetterSink:     true,
+			expectedAttempts:       3,
+			expectedStatus:         http.StatusAccepted,
+			expectedDeadLetterCode: http.StatusServiceUnavailable,
+		},
+		"Retries exhausted with dead letter sink envelope": {
+			retry:                  2,
+			subscriberFailures:     10,
+			withDeadLetterSink:     true,
+			deadLetterPayload:      eventingduckv1.DeadLetterPayloadEnvelope,
+			expectedAttempts:       3,
+			expectedStatus:         http.StatusAccepted,
+			expectedDeadLetterCode: http.StatusServiceUnavailable,
+		},
+		"Dead letter sink fails": {
+			retry:                  1,
+			subscriberFailures:     10,
+			withDeadLetterSink:     true,
+			deadLetterSinkFails:    true,
+			expectedAttempts:       2,
+			expectedStatus:         http.StatusServiceUnavailable,
+			expectedDeadLetterCode: http.StatusServiceUnavailable,
+		},
+	}
+	// The body of the failed responses is longer than the knativeerrordata extension.
+	failureBody := strings.Repeat("x", 2*channelAttributes.KnativeErrorDataExtensionMaxLength)
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			attempts := atomic.NewInt32(0)
+			subscriber := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if int(attempts.Inc()) <= tc.subscriberFailures {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					_, _ = w.Write([]byte(failureBody))
+					return
+				}
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer subscriber.Close()
+
+			deadLetterCode := atomic.NewInt32(0)
+			deadLetterSink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				e, err := binding.ToEvent(context.Background(), cehttp.NewMessageFromHttpRequest(r))
+				if err != nil {
+					t.Error("Unable to read the dead lettered event:", err)
+				}
+				if tc.deadLetterPayload == eventingduckv1.DeadLetterPayloadEnvelope {
+					var data channelAttributes.DeadLetterEventData
+					if err := e.DataAs(&data); err != nil {
+						t.Error("Unable to read the dead letter event data:", err)
+					}
+					if data.Attempts != tc.expectedAttempts || data.Subscriber == nil || data.Subscriber.Kind != "Trigger" || len(data.Event) == 0 {
+						t.Errorf("Unexpected dead letter event data %+v", data)
+					}
+					if data.ResponseBody != failureBody {
+						t.Errorf("Unexpected response body of %d bytes in the dead letter event data, want %d bytes", len(data.ResponseBody), len(failureBody))
+					}
+					deadLetterCode.Store(int32(data.ResponseCode))
+				} else {
+					var code string
+					if err := e.ExtensionAs(channelAttributes.KnativeErrorCodeExtensionKey, &code); err != nil {
+						t.Error("Missing the knativeerrorcode extension:", err)
+					}
+					if c, err := strconv.Atoi(code); err == nil {
+						deadLetterCode.Store(int32(c))
+					}
+				}
+				if tc.deadLetterSinkFails {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer deadLetterSink.Close()
+
+			subscriberURL, _ := apis.ParseURL(subscriber.URL)
+			delivery := &eventingduckv1.DeliverySpec{
+				Retry:         pointer.Int32(tc.retry),
+				BackoffPolicy: &linearBackoff,
+				BackoffDelay:  pointer.String("PT0.01S"),
+			}
+			if tc.deadLetterPayload != "" {
+				delivery.DeadLetterPayload = &tc.deadLetterPayload
+			}
+			trigger := makeTrigger(withDelivery(delivery))
+			trigger.Status.SubscriberURI = subscriberURL
+			if tc.withDeadLetterSink {
+				trigger.Status.DeadLetterSinkURI, _ = apis.ParseURL(deadLetterSink.URL)
+			}
+
+			reporter := &mockReporter{}
+			r, err := NewHandler(
+				zaptest.NewLogger(t, zaptest.WrapOptions(zap.AddCaller())),
+				newTriggerInformer(t, []runtime.Object{trigger}),
+				reporter,
+				8080,
+				func(ctx context.Context) context.Context {
+					return ctx
+				},
+				false)
+			if err != nil {
+				t.Fatal("Unable to create receiver:", err)
+			}
+
+			b, err := makeEvent().MarshalJSON()
+			if err != nil {
+				t.Fatal(err)
+			}
+			request := httptest.NewRequest(http.MethodPost, validPath, bytes.NewBuffer(b))
+			request.Header.Set(cehttp.ContentType, event.ApplicationCloudEventsJSON)
+			responseWriter := httptest.NewRecorder()
+			r.ServeHTTP(responseWriter, request)
+
+			if got := responseWriter.Result().StatusCode; got != tc.expectedStatus {
+				t.Errorf("Unexpected status. Expected %v. Actual %v.", tc.expectedStatus, got)
+			}
+			if got := int(attempts.Load()); got != tc.expectedAttempts {
+				t.Errorf("Unexpected subscriber attempts. Expected %v. Actual %v.", tc.expectedAttempts, got)
+			}
+			if got := int(deadLetterCode.Load()); got != tc.expectedDeadLetterCode {
+				t.Errorf("Unexpected knativeerrorcode at the dead letter sink. Expected %v. Actual %v.", tc.expectedDeadLetterCode, got)
+			}
+			if !reporter.eventCountReported {
+				t.Error("Expected the event count to be reported")
+			}
+		})
+	}
+}
+
+func TestReceiver_WithReply(t *testing.T) {
+	testCases := map[string]struct {
+		discardReplies       bool
+		withReplyDestination bool
+		replyUnresolved      bool
+		replyFails           bool
+		expectedStatus       int
+		expectedResponse     bool
+		expectedReplies      int
+	}{
+		"Reply sent back to the broker": {
+			expectedStatus:   http.StatusAccepted,
+			expectedResponse: true,
+		},
+		"Reply discarded": {
+			discardReplies: true,
+			expectedStatus: http.StatusAccepted,
+		},
+		"Reply sent to the reply destination": {
+			withReplyDestination: true,
+			expectedStatus:       http.StatusAccepted,
+			expectedReplies:      1,
+		},
+		"Reply destination not resolved yet": {
+			withReplyDestination: true,
+			replyUnresolved:      true,
+			expectedStatus:       http.StatusServiceUnavailable,
+		},
+		"Reply destination fails": {
+			withReplyDestination: true,
+			replyFails:           true,
+			expectedStatus:       http.StatusBadGateway,
+			expectedReplies:      1,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			subscriber := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				reply := makeDifferentEvent()
+				_ = broker.DeleteTTL(reply.Context)
+				w.Header().Set(cehttp.ContentType, event.ApplicationCloudEventsJSON)
+				w.WriteHeader(http.StatusAccepted)
+				b, _ := reply.MarshalJSON()
+				_, _ = w.Write(b)
+			}))
+			defer subscriber.Close()
+
+			replies := atomic.NewInt32(0)
+			replyDestination := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				replies.Inc()
+				e, err := binding.ToEvent(context.Background(), cehttp.NewMessageFromHttpRequest(r))
+				if err != nil {
+					t.Error("Unable to read the reply:", err)
+				} else if e.ID() != makeDifferentEvent().ID() {
+					t.Errorf("Unexpected reply id %q", e.ID())
+				}
+				if tc.replyFails {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer replyDestination.Close()
+
+			trigger := makeTrigger()
+			trigger.Status.SubscriberURI, _ = apis.ParseURL(subscriber.URL)
+			if tc.discardReplies {
+				trigger.Spec.DiscardReplies = pointer.Bool(true)
+			}
+			if tc.withReplyDestination {
+				trigger.Status.ReplyURI, _ = apis.ParseURL(replyDestination.URL)
+				trigger.Spec.Reply = &duckv1.Destination{URI: trigger.Status.ReplyURI}
+				if tc.replyUnresolved {
+					trigger.Status.ReplyURI = nil
+				}
+			}
+
+			r, err := NewHandler(
+				zaptest.NewLogger(t, zaptest.WrapOptions(zap.AddCaller())),
+				newTriggerInformer(t, []runtime.Object{trigger}),
+				&mockReporter{},
+				8080,
+				func(ctx context.Context) context.Context {
+					return ctx
+				},
+				false)
+			if err != nil {
+				t.Fatal("Unable to create receiver:", err)
+			}
+
+			b, err := makeEvent().MarshalJSON()
+			if err != nil {
+				t.Fatal(err)
+			}
+			request := httptest.NewRequest(http.MethodPost, validPath, bytes.NewBuffer(b))
+			request.Header.Set(cehttp.ContentType, event.ApplicationCloudEventsJSON)
+			responseWriter := httptest.NewRecorder()
+			r.ServeHTTP(responseWriter, request)
+
+			if got := responseWriter.Result().StatusCode; got != tc.expectedStatus {
+				t.Errorf("Unexpected status. Expected %v. Actual %v.", tc.expectedStatus, got)
+			}
+			if got := responseWriter.Header().Get("Ce-Id") != ""; got != tc.expectedResponse {
+				t.Errorf("Unexpected response event. Expected %v. Actual %v.", tc.expectedResponse, got)
+			}
+			if got := int(replies.Load()); got != tc.expectedReplies {
+				t.Errorf("Unexpected replies sent to the reply destination. Expected %v. Actual %v.", tc.expectedReplies, got)
+			}
+		})
+	}
+}
+
 func TestReceiver_WithSubscriptionsAPI(t *testing.T) {
 	testCases := map[string]struct {
 		triggers                  []*eventingv1.Trigger
@@ -607,11 +881,10 @@ func TestReceiver_WithSubscriptionsAPI(t *testing.T) {
 				}
 				correctURI = append(correctURI, trig)
 			}
-			listers := reconcilertesting.NewListers(correctURI)
 			reporter := &mockReporter{}
 			r, err := NewHandler(
 				zaptest.NewLogger(t, zaptest.WrapOptions(zap.AddCaller())),
-				listers.GetTriggerLister(),
+				newTriggerInformer(t, correctURI),
 				reporter,
 				8080, func(ctx context.Context) context.Context {
 					return feature.ToContext(context.TODO(), feature.Flags{
@@ -813,6 +1086,12 @@ func withAttributesFilter(filter *eventingv1.TriggerFilter) TriggerOption {
 	}
 }
 
+func withDelivery(delivery *eventingduckv1.DeliverySpec) TriggerOption {
+	return func(t *eventingv1.Trigger) {
+		t.Spec.Delivery = delivery
+	}
+}
+
 func withoutSubscriberURI() TriggerOption {
 	return func(t *eventingv1.Trigger) {
 		t.Status.SubscriberURI = nil
@@ -907,3 +1186,13 @@ func makeEmptyResponse(status int) *http.Response {
 	}
 	return r
 }
+
+func newTriggerInformer(t *testing.T, objs []runtime.Object) eventinginformersv1.TriggerInformer {
+	triggerInformer := eventinginformers.NewSharedInformerFactory(eventingclientfake.NewSimpleClientset(), 0).Eventing().V1().Triggers()
+	for _, obj := range objs {
+		if err := triggerInformer.Informer().GetIndexer().Add(obj); err != nil {
+			t.Fatal("Unable to add object to the informer:", err)
+		}
+	}
+	return triggerInformer
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+const (
+	// defaultMaxPendingPerKey is the maximum number of events waiting to be dispatched, or
+	// being dispatched, for a single partition key.
+	defaultMaxPendingPerKey = 100
+)
+
+// errQueueFull is returned when too many events are pending for a partition key.
+var errQueueFull = errors.New("too many pending events for the partition key")
+
+// keyedQueue serializes the dispatch of events sharing the same key, in the order they
+// acquire the queue, while events with different keys are dispatched concurrently.
+//
+// The ordering is best-effort: each filter replica has its own queue, and the channel
+// fanout and its retries can reorder the events before they acquire the queue.
+type keyedQueue struct {
+	mu         sync.Mutex
+	waiters    map[string][]chan struct{}
+	maxPending int
+}
+
+func newKeyedQueue(maxPending int) *keyedQueue {
+	return &keyedQueue{
+		waiters:    make(map[string][]chan struct{}),
+		maxPending: maxPending,
+	}
+}
+
+// Acquire blocks until it is the turn of the caller to dispatch an event for key. The returned
+// function must be called once the dispatch is done to let the next event for key through.
+//
+// It returns errQueueFull without blocking if there are already too many events pending for key,
+// and the context error if the context is done while waiting.
+func (q *keyedQueue) Acquire(ctx context.Context, key string) (func(), error) {
+	q.mu.Lock()
+	if len(q.waiters[key]) >= q.maxPending {
+		q.mu.Unlock()
+		return nil, errQueueFull
+	}
+	turn := make(chan struct{})
+	q.waiters[key] = append(q.waiters[key], turn)
+	if len(q.waiters[key]) == 1 {
+		close(turn)
+	}
+	q.mu.Unlock()
+
+	release := func() { q.release(key) }
+
+	select {
+	case <-turn:
+		return release, nil
+	case <-ctx.Done():
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	waiters := q.waiters[key]
+	if waiters[0] == turn {
+		// It became our turn while the context was being cancelled, let the next one through.
+		q.releaseLocked(key)
+		return nil, ctx.Err()
+	}
+	for i, w := range waiters {
+		if w == turn {
+			q.waiters[key] = append(waiters[:i], waiters[i+1:]...)
+			break
+		}
+	}
+	return nil, ctx.Err()
+}
+
+func (q *keyedQueue) release(key string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.releaseLocked(key)
+}
+
+func (q *keyedQueue) releaseLocked(key string) {
+	waiters := q.waiters[key][1:]
+	if len(waiters) == 0 {
+		delete(q.waiters, key)
+		return
+	}
+	q.waiters[key] = waiters
+	close(waiters[0])
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestKeyedQueueSameKeyInOrder(t *testing.T) {
+	q := newKeyedQueue(10)
+	ctx := context.Background()
+
+	first, err := q.Acquire(ctx, "key")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	order := make(chan int, 2)
+	for i := 1; i <= 2; i++ {
+		i := i
+		go func() {
+			release, err := q.Acquire(ctx, "key")
+			if err != nil {
+				t.Error("Unexpected error:", err)
+				return
+			}
+			order <- i
+			release()
+		}()
+		// Wait for the event to be queued, so that the events queue up in order.
+		waitForPending(t, q, "key", i+1)
+	}
+
+	select {
+	case i := <-order:
+		t.Fatalf("Event %d dispatched before the previous one was released", i)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	first()
+	for want := 1; want <= 2; want++ {
+		select {
+		case got := <-order:
+			if got != want {
+				t.Errorf("Unexpected dispatch order, want %d got %d", want, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Timed out waiting for the dispatch")
+		}
+	}
+}
+
+func TestKeyedQueueDifferentKeysConcurrently(t *testing.T) {
+	q := newKeyedQueue(10)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	release1, err := q.Acquire(ctx, "key1")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	defer release1()
+
+	release2, err := q.Acquire(ctx, "key2")
+	if err != nil {
+		t.Fatal("Expected a different key not to wait, got:", err)
+	}
+	release2()
+}
+
+func TestKeyedQueueFull(t *testing.T) {
+	q := newKeyedQueue(1)
+
+	release, err := q.Acquire(context.Background(), "key")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if _, err := q.Acquire(context.Background(), "key"); !errors.Is(err, errQueueFull) {
+		t.Errorf("Expected %v, got %v", errQueueFull, err)
+	}
+
+	release()
+	if len(q.waiters) != 0 {
+		t.Errorf("Expected no pending keys, got %d", len(q.waiters))
+	}
+}
+
+func TestKeyedQueueContextDone(t *testing.T) {
+	q := newKeyedQueue(10)
+
+	release, err := q.Acquire(context.Background(), "key")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.Acquire(ctx, "key"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	if got := len(q.waiters["key"]); got != 1 {
+		t.Errorf("Expected the cancelled waiter to be removed, got %d waiters", got)
+	}
+
+	release()
+	if len(q.waiters) != 0 {
+		t.Errorf("Expected no pending keys, got %d", len(q.waiters))
+	}
+}
+
+func waitForPending(t *testing.T, q *keyedQueue, key string, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		q.mu.Lock()
+		pending := len(q.waiters[key])
+		q.mu.Unlock()
+		if pending == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("Timed out waiting for %d pending events", n)
+}
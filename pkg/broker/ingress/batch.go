@@ -0,0 +1,115 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"go.uber.org/zap"
+)
+
+// maxBatchSize is the maximum size, in bytes, of the body of a batch request.
+const maxBatchSize = 10 * 1024 * 1024
+
+// batchEventStatus is the status of an event of a batch, returned in the response to a batch request.
+type batchEventStatus struct {
+	ID         string `json:"id,omitempty"`
+	Source     string `json:"source,omitempty"`
+	StatusCode int    `json:"statusCode"`
+	RetryAfter string `json:"retryAfter,omitempty"`
+}
+
+// isBatch returns true if the request contains a batch of events in the JSON batch format.
+func isBatch(request *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(request.Header.Get("Content-Type"))
+	return err == nil && mediaType == cloudevents.ApplicationCloudEventsBatchJSON
+}
+
+// serveBatch sends each event of a batch to the broker, in the order of the batch, and responds with
+// the status of each event. The response status code is 202 when all the events are accepted, 207 when
+// some of them are accepted, and the status code of the events when none of them is accepted, so that
+// the sender retries the batch.
+func (h *Handler) serveBatch(ctx context.Context, writer http.ResponseWriter, request *http.Request, brokerNamespace, brokerName string) {
+	var events []cloudevents.Event
+	if err := json.NewDecoder(http.MaxBytesReader(writer, request.Body, maxBatchSize)).Decode(&events); err != nil {
+		h.Logger.Warn("failed to extract events from batch request", zap.Error(err))
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writer.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	accepted := 0
+	statuses := make([]batchEventStatus, 0, len(events))
+	for i := range events {
+		event := &events[i]
+		eventStatusCode, retryAfter := h.handleEvent(ctx, request.Header, event, brokerNamespace, brokerName)
+		if eventStatusCode >= http.StatusOK && eventStatusCode < http.StatusMultipleChoices {
+			accepted++
+		}
+		statuses = append(statuses, batchEventStatus{
+			ID:         event.ID(),
+			Source:     event.Source(),
+			StatusCode: eventStatusCode,
+			RetryAfter: retryAfter,
+		})
+	}
+
+	statusCode := http.StatusAccepted
+	switch {
+	case accepted == len(statuses):
+	case accepted > 0:
+		statusCode = http.StatusMultiStatus
+	default:
+		statusCode = failedBatchStatus(writer, statuses)
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(statusCode)
+	if err := json.NewEncoder(writer).Encode(statuses); err != nil {
+		h.Logger.Warn("failed to write the batch response", zap.Error(err))
+	}
+}
+
+// failedBatchStatus returns the status code of a batch whose events all failed: the status code of the
+// events if they all failed with the same one, otherwise 500. For throttled batches, it sets the
+// Retry-After header to the longest delay of the events.
+func failedBatchStatus(writer http.ResponseWriter, statuses []batchEventStatus) int {
+	statusCode := statuses[0].StatusCode
+	retryAfter := 0
+	for _, s := range statuses {
+		if s.StatusCode != statusCode {
+			return http.StatusInternalServerError
+		}
+		if d, err := strconv.Atoi(s.RetryAfter); err == nil && d > retryAfter {
+			retryAfter = d
+		}
+	}
+	if statusCode == http.StatusTooManyRequests && retryAfter > 0 {
+		writer.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	}
+	return statusCode
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"encoding/json"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"knative.dev/eventing/pkg/apis/eventing"
+	"knative.dev/eventing/pkg/broker"
+	"knative.dev/eventing/pkg/kncloudevents"
+	reconcilertestingv1 "knative.dev/eventing/pkg/reconciler/testing/v1"
+)
+
+func TestHandler_ServeBatch(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		contentType  string
+		statusCode   int
+		statuses     []batchEventStatus
+		eventsSent   []string
+		eventsCounts map[int]int
+	}{{
+		name:        "all events accepted",
+		body:        `[{"specversion": "1.0", "id": "1", "source": "source", "type": "type"}, {"specversion": "1.0", "id": "2", "source": "source", "type": "type"}]`,
+		contentType: event.ApplicationCloudEventsBatchJSON,
+		statusCode:  nethttp.StatusAccepted,
+		statuses: []batchEventStatus{
+			{ID: "1", Source: "source", StatusCode: senderResponseStatusCode},
+			{ID: "2", Source: "source", StatusCode: senderResponseStatusCode},
+		},
+		eventsSent:   []string{"1", "2"},
+		eventsCounts: map[int]int{senderResponseStatusCode: 2},
+	}, {
+		name:        "content type parameters",
+		body:        `[{"specversion": "1.0", "id": "1", "source": "source", "type": "type"}]`,
+		contentType: event.ApplicationCloudEventsBatchJSON + "; charset=utf-8",
+		statusCode:  nethttp.StatusAccepted,
+		statuses: []batchEventStatus{
+			{ID: "1", Source: "source", StatusCode: senderResponseStatusCode},
+		},
+		eventsSent:   []string{"1"},
+		eventsCounts: map[int]int{senderResponseStatusCode: 1},
+	}, {
+		name:        "some events rejected",
+		body:        `[{"specversion": "1.0", "id": "1", "source": "source", "type": "type"}, {"specversion": "1.0", "id": "2", "source": "source", "type": "type", "knativebrokerttl": "0"}]`,
+		contentType: event.ApplicationCloudEventsBatchJSON,
+		statusCode:  nethttp.StatusMultiStatus,
+		statuses: []batchEventStatus{
+			{ID: "1", Source: "source", StatusCode: senderResponseStatusCode},
+			{ID: "2", Source: "source", StatusCode: nethttp.StatusBadRequest},
+		},
+		eventsSent:   []string{"1"},
+		eventsCounts: map[int]int{senderResponseStatusCode: 1, nethttp.StatusBadRequest: 1},
+	}, {
+		name:        "invalid event",
+		body:        `[{"specversion": "1.0", "id": "1", "type": "type"}]`,
+		contentType: event.ApplicationCloudEventsBatchJSON,
+		statusCode:  nethttp.StatusBadRequest,
+		statuses: []batchEventStatus{
+			{ID: "1", StatusCode: nethttp.StatusBadRequest},
+		},
+		eventsCounts: map[int]int{},
+	}, {
+		name:         "malformed batch",
+		body:         `{"specversion": "1.0", "id": "1", "source": "source", "type": "type"}`,
+		contentType:  event.ApplicationCloudEventsBatchJSON,
+		statusCode:   nethttp.StatusBadRequest,
+		eventsCounts: map[int]int{},
+	}, {
+		name:        "all events rejected",
+		body:        `[{"specversion": "1.0", "id": "1", "type": "type"}, {"specversion": "1.0", "id": "2", "source": "source", "type": "type", "knativebrokerttl": "0"}]`,
+		contentType: event.ApplicationCloudEventsBatchJSON,
+		statusCode:  nethttp.StatusBadRequest,
+		statuses: []batchEventStatus{
+			{ID: "1", StatusCode: nethttp.StatusBadRequest},
+			{ID: "2", Source: "source", StatusCode: nethttp.StatusBadRequest},
+		},
+		eventsCounts: map[int]int{nethttp.StatusBadRequest: 1},
+	}, {
+		name:         "batch too large",
+		body:         "[" + strings.Repeat(" ", maxBatchSize) + "]",
+		contentType:  event.ApplicationCloudEventsBatchJSON,
+		statusCode:   nethttp.StatusRequestEntityTooLarge,
+		eventsCounts: map[int]int{},
+	}, {
+		name:         "empty batch",
+		body:         `[]`,
+		contentType:  event.ApplicationCloudEventsBatchJSON,
+		statusCode:   nethttp.StatusAccepted,
+		statuses:     []batchEventStatus{},
+		eventsCounts: map[int]int{},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var sent []string
+			s := httptest.NewServer(nethttp.HandlerFunc(func(writer nethttp.ResponseWriter, request *nethttp.Request) {
+				mu.Lock()
+				sent = append(sent, request.Header.Get("ce-id"))
+				mu.Unlock()
+				writer.WriteHeader(senderResponseStatusCode)
+			}))
+			defer s.Close()
+
+			b := makeBroker("name", "ns")
+			b.Status.Annotations[eventing.BrokerChannelAddressStatusAnnotationKey] = s.URL
+			listers := reconcilertestingv1.NewListers([]runtime.Object{b})
+			sender, _ := kncloudevents.NewHTTPMessageSenderWithTarget("")
+			reporter := &countingReporter{EventCounts: map[int]int{}}
+			h := &Handler{
+				Sender:       sender,
+				Defaulter:    broker.TTLDefaulter(zap.NewNop(), 100),
+				Reporter:     reporter,
+				Logger:       zap.NewNop(),
+				BrokerLister: listers.GetBrokerLister(),
+			}
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(nethttp.MethodPost, "/ns/name", strings.NewReader(tc.body))
+			request.Header.Set(cehttp.ContentType, tc.contentType)
+			h.ServeHTTP(recorder, request)
+
+			result := recorder.Result()
+			if result.StatusCode != tc.statusCode {
+				t.Errorf("expected status code %d got %d", tc.statusCode, result.StatusCode)
+			}
+			if tc.statuses != nil {
+				var statuses []batchEventStatus
+				if err := json.NewDecoder(result.Body).Decode(&statuses); err != nil {
+					t.Fatal("Failed to decode the batch response:", err)
+				}
+				if diff := cmp.Diff(tc.statuses, statuses); diff != "" {
+					t.Error("Unexpected batch response (-want +got)", diff)
+				}
+			}
+			if diff := cmp.Diff(tc.eventsSent, sent); diff != "" {
+				t.Error("Unexpected events sent to the channel (-want +got)", diff)
+			}
+			if diff := cmp.Diff(tc.eventsCounts, reporter.EventCounts); diff != "" {
+				t.Error("Unexpected event counts (-want +got)", diff)
+			}
+		})
+	}
+}
+
+func TestFailedBatchStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		statuses   []batchEventStatus
+		statusCode int
+		retryAfter string
+	}{{
+		name:       "same status code",
+		statuses:   []batchEventStatus{{StatusCode: nethttp.StatusBadRequest}, {StatusCode: nethttp.StatusBadRequest}},
+		statusCode: nethttp.StatusBadRequest,
+	}, {
+		name:       "different status codes",
+		statuses:   []batchEventStatus{{StatusCode: nethttp.StatusBadRequest}, {StatusCode: nethttp.StatusTooManyRequests, RetryAfter: "2"}},
+		statusCode: nethttp.StatusInternalServerError,
+	}, {
+		name:       "throttled",
+		statuses:   []batchEventStatus{{StatusCode: nethttp.StatusTooManyRequests, RetryAfter: "2"}, {StatusCode: nethttp.StatusTooManyRequests, RetryAfter: "5"}},
+		statusCode: nethttp.StatusTooManyRequests,
+		retryAfter: "5",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			if got := failedBatchStatus(recorder, tc.statuses); got != tc.statusCode {
+				t.Errorf("failedBatchStatus() = %d, want %d", got, tc.statusCode)
+			}
+			if got := recorder.Header().Get("Retry-After"); got != tc.retryAfter {
+				t.Errorf("Retry-After = %q, want %q", got, tc.retryAfter)
+			}
+		})
+	}
+}
+
+// countingReporter counts the events reported for each status code.
+type countingReporter struct {
+	mockReporter
+	EventCounts map[int]int
+}
+
+func (r *countingReporter) ReportEventCount(_ *ReportArgs, responseCode int) error {
+	r.EventCounts[responseCode]++
+	return nil
+}
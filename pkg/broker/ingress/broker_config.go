@@ -0,0 +1,77 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+)
+
+// getBrokerConfig returns the ingress settings of the Broker, keyed by their ConfigMap key. The
+// annotations map the Broker annotations to the ConfigMap keys of the settings to read.
+// The settings are read from the ConfigMap referenced by the Broker config, and the Broker
+// annotations take precedence over the ConfigMap.
+func (h *Handler) getBrokerConfig(b *eventingv1.Broker, annotations map[string]string) map[string]string {
+	values := make(map[string]string, len(annotations))
+	if h.ConfigMapLister != nil && b.Spec.Config != nil && b.Spec.Config.Kind == "ConfigMap" {
+		cm, err := h.ConfigMapLister.ConfigMaps(b.Spec.Config.Namespace).Get(b.Spec.Config.Name)
+		if err != nil {
+			h.warnings.warn(h.Logger, b, "Failed to get the Broker config", err)
+		} else {
+			h.warnings.resolve(b, "Failed to get the Broker config")
+			for _, key := range annotations {
+				if v, ok := cm.Data[key]; ok {
+					values[key] = v
+				}
+			}
+		}
+	}
+	for annotation, key := range annotations {
+		if v, ok := b.GetAnnotations()[annotation]; ok {
+			values[key] = v
+		}
+	}
+	return values
+}
+
+// configWarnings remembers the invalid Broker settings already logged, so that they are logged
+// once rather than for every event sent to the Broker. The zero value is ready to use.
+type configWarnings struct {
+	logged sync.Map
+}
+
+// warn logs msg for the Broker, unless it was already logged with the same error.
+func (w *configWarnings) warn(logger *zap.Logger, b *eventingv1.Broker, msg string, err error) {
+	key := fmt.Sprintf("%s/%s/%s", b.Namespace, b.Name, msg)
+	if previous, ok := w.logged.Load(key); ok && previous == err.Error() {
+		return
+	}
+	w.logged.Store(key, err.Error())
+	logger.Warn(msg, zap.Error(err), zap.String("namespace", b.Namespace), zap.String("broker", b.Name))
+}
+
+// resolve forgets the warning logged with msg for the Broker, once the setting is valid again.
+func (w *configWarnings) resolve(b *eventingv1.Broker, msg string) {
+	key := fmt.Sprintf("%s/%s/%s", b.Namespace, b.Name, msg)
+	if _, ok := w.logged.Load(key); ok {
+		w.logged.Delete(key)
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	lru "github.com/hashicorp/golang-lru"
+
+	"knative.dev/eventing/pkg/apis/eventing"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+)
+
+const (
+	// dedupWindowConfigKey is the key of the deduplication window in the ConfigMap referenced by the Broker config.
+	dedupWindowConfigKey = "ingress.dedup-window"
+
+	// defaultMaxDedupEntries is the maximum number of events remembered by the ingress for
+	// deduplication, the least recently seen events are forgotten first.
+	defaultMaxDedupEntries = 100000
+)
+
+var dedupAnnotations = map[string]string{
+	eventing.BrokerDedupWindowAnnotationKey: dedupWindowConfigKey,
+}
+
+// getDedupWindow returns the deduplication window of the Broker, or zero if the Broker doesn't
+// deduplicate events. An invalid window is ignored.
+func (h *Handler) getDedupWindow(b *eventingv1.Broker) time.Duration {
+	v, ok := h.getBrokerConfig(b, dedupAnnotations)[dedupWindowConfigKey]
+	if !ok {
+		return 0
+	}
+	window, err := time.ParseDuration(v)
+	if err == nil && window < 0 {
+		err = fmt.Errorf("the window must not be negative, was: %q", v)
+	}
+	if err != nil {
+		h.warnings.warn(h.Logger, b, "Invalid Broker deduplication window", err)
+		return 0
+	}
+	h.warnings.resolve(b, "Invalid Broker deduplication window")
+	return window
+}
+
+// dedupKey identifies an event sent to a Broker.
+type dedupKey struct {
+	namespace string
+	name      string
+	source    string
+	id        string
+}
+
+func newDedupKey(b *eventingv1.Broker, event *cloudevents.Event) dedupKey {
+	return dedupKey{
+		namespace: b.Namespace,
+		name:      b.Name,
+		source:    event.Source(),
+		id:        event.ID(),
+	}
+}
+
+// dedupResult is the result of the reservation of an event for deduplication.
+type dedupResult int
+
+const (
+	// dedupReserved means the event is new and reserved for dispatch.
+	dedupReserved dedupResult = iota
+	// dedupDuplicate means the event was accepted less than the window ago.
+	dedupDuplicate
+	// dedupInFlight means a copy of the event is being dispatched.
+	dedupInFlight
+)
+
+// dedupEntry is an event being dispatched, or accepted at acceptedAt.
+type dedupEntry struct {
+	inFlight   bool
+	acceptedAt time.Time
+}
+
+// dedupCache remembers the events being dispatched and when the events were accepted by the
+// Brokers. Each ingress replica has its own cache, so the duplicates sent to different replicas
+// are not dropped. The zero value is ready to use.
+type dedupCache struct {
+	once  sync.Once
+	mu    sync.Mutex
+	cache *lru.Cache
+}
+
+func (c *dedupCache) init() {
+	c.once.Do(func() {
+		c.cache, _ = lru.New(defaultMaxDedupEntries)
+	})
+}
+
+// Reserve atomically checks whether the event identified by key is being dispatched or was
+// accepted less than window ago and, if it's not, reserves it for dispatch. A reserved event
+// must then be either accepted or released.
+func (c *dedupCache) Reserve(key dedupKey, window time.Duration, now time.Time) dedupResult {
+	c.init()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if v, ok := c.cache.Get(key); ok {
+		e := v.(dedupEntry)
+		if e.inFlight {
+			return dedupInFlight
+		}
+		if now.Sub(e.acceptedAt) < window {
+			return dedupDuplicate
+		}
+	}
+	c.cache.Add(key, dedupEntry{inFlight: true})
+	return dedupReserved
+}
+
+// Accept remembers that the reserved event identified by key was accepted at the given time.
+func (c *dedupCache) Accept(key dedupKey, now time.Time) {
+	c.init()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache.Add(key, dedupEntry{acceptedAt: now})
+}
+
+// Release forgets the reserved event identified by key, which was not accepted, so that it can be retried.
+func (c *dedupCache) Release(key dedupKey) {
+	c.init()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if v, ok := c.cache.Peek(key); ok && v.(dedupEntry).inFlight {
+		c.cache.Remove(key)
+	}
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	nethttp "net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"knative.dev/eventing/pkg/apis/eventing"
+	"knative.dev/eventing/pkg/broker"
+	"knative.dev/eventing/pkg/kncloudevents"
+	reconcilertestingv1 "knative.dev/eventing/pkg/reconciler/testing/v1"
+)
+
+func TestDedupCache(t *testing.T) {
+	var c dedupCache
+	key := dedupKey{namespace: "ns", name: "name", source: "source", id: "1"}
+	now := time.Now()
+
+	if got := c.Reserve(key, time.Minute, now); got != dedupReserved {
+		t.Fatalf("Reserve() = %v, want the new event to be reserved", got)
+	}
+	if got := c.Reserve(key, time.Minute, now); got != dedupInFlight {
+		t.Fatalf("Reserve() = %v, want the event to be in flight", got)
+	}
+	c.Release(key)
+	if got := c.Reserve(key, time.Minute, now); got != dedupReserved {
+		t.Fatalf("Reserve() = %v, want the released event to be reserved again", got)
+	}
+	c.Accept(key, now)
+	if got := c.Reserve(key, time.Minute, now.Add(30*time.Second)); got != dedupDuplicate {
+		t.Errorf("Reserve() = %v, want the event to be a duplicate within the window", got)
+	}
+	other := key
+	other.id = "2"
+	if got := c.Reserve(other, time.Minute, now); got != dedupReserved {
+		t.Errorf("Reserve() = %v, want an event with another id to be reserved", got)
+	}
+	if got := c.Reserve(key, time.Minute, now.Add(time.Minute)); got != dedupReserved {
+		t.Errorf("Reserve() = %v, want the event to be reserved after the window", got)
+	}
+}
+
+func TestGetDedupWindow(t *testing.T) {
+	tests := []struct {
+		name   string
+		window string
+		want   time.Duration
+	}{{
+		name: "no window",
+	}, {
+		name:   "window",
+		window: "5m",
+		want:   5 * time.Minute,
+	}, {
+		name:   "invalid window",
+		window: "five minutes",
+	}, {
+		name:   "negative window",
+		window: "-1m",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b := makeBroker("name", "ns")
+			if tc.window != "" {
+				b.Annotations = map[string]string{eventing.BrokerDedupWindowAnnotationKey: tc.window}
+			}
+			h := &Handler{Logger: zap.NewNop()}
+			if got := h.getDedupWindow(b); got != tc.want {
+				t.Errorf("getDedupWindow() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHandler_Dedup(t *testing.T) {
+	var sent int32
+	channelStatusCode := int32(nethttp.StatusInternalServerError)
+	s := httptest.NewServer(nethttp.HandlerFunc(func(writer nethttp.ResponseWriter, request *nethttp.Request) {
+		atomic.AddInt32(&sent, 1)
+		writer.WriteHeader(int(atomic.LoadInt32(&channelStatusCode)))
+	}))
+	defer s.Close()
+
+	b := makeBroker("name", "ns")
+	b.Annotations = map[string]string{eventing.BrokerDedupWindowAnnotationKey: "1h"}
+	b.Status.Annotations[eventing.BrokerChannelAddressStatusAnnotationKey] = s.URL
+	listers := reconcilertestingv1.NewListers([]runtime.Object{b})
+	sender, _ := kncloudevents.NewHTTPMessageSenderWithTarget("")
+	reporter := &mockReporter{}
+	h := &Handler{
+		Sender:       sender,
+		Defaulter:    broker.TTLDefaulter(zap.NewNop(), 100),
+		Reporter:     reporter,
+		Logger:       zap.NewNop(),
+		BrokerLister: listers.GetBrokerLister(),
+	}
+
+	post := func() int {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(nethttp.MethodPost, "/ns/name", getValidEvent())
+		request.Header.Add(cehttp.ContentType, event.ApplicationCloudEventsJSON)
+		h.ServeHTTP(recorder, request)
+		return recorder.Result().StatusCode
+	}
+
+	// The events not accepted by the channel can be retried.
+	if got := post(); got != nethttp.StatusInternalServerError {
+		t.Fatalf("Expected status code %d, got %d", nethttp.StatusInternalServerError, got)
+	}
+	atomic.StoreInt32(&channelStatusCode, senderResponseStatusCode)
+	if got := post(); got != senderResponseStatusCode {
+		t.Fatalf("Expected status code %d, got %d", senderResponseStatusCode, got)
+	}
+	if reporter.EventDuplicate {
+		t.Fatal("Expected the retried event not to be reported as duplicate")
+	}
+
+	if got := post(); got != nethttp.StatusAccepted {
+		t.Fatalf("Expected status code %d, got %d", nethttp.StatusAccepted, got)
+	}
+	if !reporter.EventDuplicate {
+		t.Error("Expected the duplicate event to be reported")
+	}
+	if got := atomic.LoadInt32(&sent); got != 2 {
+		t.Errorf("Expected 2 events sent to the channel, got %d", got)
+	}
+}
+
+func TestHandler_DedupInFlight(t *testing.T) {
+	var sent int32
+	received, release := make(chan struct{}), make(chan struct{})
+	s := httptest.NewServer(nethttp.HandlerFunc(func(writer nethttp.ResponseWriter, request *nethttp.Request) {
+		atomic.AddInt32(&sent, 1)
+		close(received)
+		<-release
+		writer.WriteHeader(senderResponseStatusCode)
+	}))
+	defer s.Close()
+
+	b := makeBroker("name", "ns")
+	b.Annotations = map[string]string{eventing.BrokerDedupWindowAnnotationKey: "1h"}
+	b.Status.Annotations[eventing.BrokerChannelAddressStatusAnnotationKey] = s.URL
+	listers := reconcilertestingv1.NewListers([]runtime.Object{b})
+	sender, _ := kncloudevents.NewHTTPMessageSenderWithTarget("")
+	h := &Handler{
+		Sender:       sender,
+		Defaulter:    broker.TTLDefaulter(zap.NewNop(), 100),
+		Reporter:     &mockReporter{},
+		Logger:       zap.NewNop(),
+		BrokerLister: listers.GetBrokerLister(),
+	}
+
+	post := func() int {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(nethttp.MethodPost, "/ns/name", getValidEvent())
+		request.Header.Add(cehttp.ContentType, event.ApplicationCloudEventsJSON)
+		h.ServeHTTP(recorder, request)
+		return recorder.Result().StatusCode
+	}
+
+	first := make(chan int)
+	go func() { first <- post() }()
+	<-received
+
+	// A copy arriving while the first one is being dispatched must be retried by the producer.
+	if got := post(); got != nethttp.StatusConflict {
+		t.Errorf("Expected status code %d, got %d", nethttp.StatusConflict, got)
+	}
+	close(release)
+	if got := <-first; got != senderResponseStatusCode {
+		t.Errorf("Expected status code %d, got %d", senderResponseStatusCode, got)
+	}
+	if got := atomic.LoadInt32(&sent); got != 1 {
+		t.Errorf("Expected 1 event sent to the channel, got %d", got)
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"fmt"
+	"sync"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	"knative.dev/eventing/pkg/apis/eventing"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/apis/eventing/v1beta1"
+	eventinglistersv1beta1 "knative.dev/eventing/pkg/client/listers/eventing/v1beta1"
+)
+
+// eventTypeIndex caches the EventTypes referring to each Broker, keyed by Broker namespace and name,
+// so that the EventTypes of the namespace aren't listed for every event. The zero value is ready to use.
+type eventTypeIndex struct {
+	mu sync.RWMutex
+	// version is incremented by every invalidation, so that EventTypes listed before an
+	// invalidation aren't cached.
+	version uint64
+	brokers map[types.NamespacedName][]*v1beta1.EventType
+}
+
+// Get returns the EventTypes referring to the given Broker, listing and caching them if they are missing.
+func (i *eventTypeIndex) Get(lister eventinglistersv1beta1.EventTypeLister, b *eventingv1.Broker) ([]*v1beta1.EventType, error) {
+	key := types.NamespacedName{Namespace: b.Namespace, Name: b.Name}
+	i.mu.RLock()
+	eventTypes, ok := i.brokers[key]
+	version := i.version
+	i.mu.RUnlock()
+	if ok {
+		return eventTypes, nil
+	}
+
+	all, err := lister.EventTypes(b.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	eventTypes = make([]*v1beta1.EventType, 0)
+	for _, et := range all {
+		if et.Spec.Broker == b.Name {
+			eventTypes = append(eventTypes, et)
+		}
+	}
+
+	i.mu.Lock()
+	if i.version == version {
+		if i.brokers == nil {
+			i.brokers = make(map[types.NamespacedName][]*v1beta1.EventType)
+		}
+		i.brokers[key] = eventTypes
+	}
+	i.mu.Unlock()
+	return eventTypes, nil
+}
+
+// Invalidate removes the cached EventTypes of the Broker the given EventType refers to.
+func (i *eventTypeIndex) Invalidate(et *v1beta1.EventType) {
+	i.mu.Lock()
+	i.version++
+	delete(i.brokers, types.NamespacedName{Namespace: et.Namespace, Name: et.Spec.Broker})
+	i.mu.Unlock()
+}
+
+// isEventTypePolicyEnforced returns true if the Broker only accepts the events matching one of its EventTypes.
+func isEventTypePolicyEnforced(b *eventingv1.Broker) bool {
+	return b.GetAnnotations()[eventing.EventTypePolicyAnnotationKey] == eventing.EventTypePolicyEnforced
+}
+
+// isEventTypeAllowed returns true if the event is allowed by the EventType policy of the given Broker.
+// When the policy is enforced, the event type and source must match an EventType referring to the Broker.
+// It returns an error wrapping errEventTypesUnavailable if the EventTypes can't be listed.
+func (h *Handler) isEventTypeAllowed(event *cloudevents.Event, b *eventingv1.Broker) (bool, error) {
+	if h.EventTypeLister == nil || !isEventTypePolicyEnforced(b) {
+		return true, nil
+	}
+
+	eventTypes, err := h.eventTypes.Get(h.EventTypeLister, b)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", errEventTypesUnavailable, err)
+	}
+	for _, et := range eventTypes {
+		if eventTypeMatches(et, event, b.Name) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// eventTypeMatches returns true if the EventType refers to the Broker and describes the event.
+// An EventType without a source matches events from any source.
+func eventTypeMatches(et *v1beta1.EventType, event *cloudevents.Event, brokerName string) bool {
+	if et.Spec.Broker != brokerName || et.Spec.Type != event.Type() {
+		return false
+	}
+	return et.Spec.Source == nil || et.Spec.Source.String() == event.Source()
+}
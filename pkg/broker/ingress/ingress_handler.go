@@ -18,6 +18,8 @@ package ingress
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -32,13 +34,16 @@ import (
 	"go.opencensus.io/trace"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/types"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 
 	"knative.dev/pkg/network"
 
 	"knative.dev/eventing/pkg/apis/eventing"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
 	"knative.dev/eventing/pkg/broker"
+	channelattributes "knative.dev/eventing/pkg/channel/attributes"
 	eventinglisters "knative.dev/eventing/pkg/client/listers/eventing/v1"
+	eventinglistersv1beta1 "knative.dev/eventing/pkg/client/listers/eventing/v1beta1"
 	"knative.dev/eventing/pkg/kncloudevents"
 	"knative.dev/eventing/pkg/tracing"
 	"knative.dev/eventing/pkg/utils"
@@ -60,6 +65,24 @@ type Handler struct {
 	Reporter StatsReporter
 	// BrokerLister gets broker objects
 	BrokerLister eventinglisters.BrokerLister
+	// ConfigMapLister gets the broker config maps holding the rate limits and deduplication window,
+	// only the config maps labelled with eventing.BrokerIngressConfigLabelKey need to be listed
+	ConfigMapLister corev1listers.ConfigMapLister
+	// EventTypeLister gets the event types used to enforce the broker event type policy
+	// and to validate the event data, when set the events sent to a broker that can't be
+	// resolved are rejected
+	EventTypeLister eventinglistersv1beta1.EventTypeLister
+
+	// schemas caches the compiled schemas of the event types
+	schemas schemaCache
+	// eventTypes caches the event types of each broker
+	eventTypes eventTypeIndex
+	// limiters keeps the token buckets of the brokers and event sources
+	limiters rateLimiters
+	// dedup remembers the accepted events for deduplication
+	dedup dedupCache
+	// warnings remembers the invalid broker settings already logged
+	warnings configWarnings
 
 	Logger *zap.Logger
 }
@@ -82,11 +105,7 @@ func guessChannelAddress(name, namespace, domain string) string {
 	return url.String()
 }
 
-func (h *Handler) getChannelAddress(name, namespace string) (string, error) {
-	broker, err := h.getBroker(name, namespace)
-	if err != nil {
-		return "", err
-	}
+func getChannelAddress(broker *eventingv1.Broker) (string, error) {
 	if broker.Status.Annotations == nil {
 		return "", fmt.Errorf("Broker status annotations uninitialized")
 	}
@@ -106,7 +125,7 @@ func (h *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 	// validate request method
 	if request.Method == http.MethodOptions {
 		writer.Header().Set("WebHook-Allowed-Origin", "*") // Accept from any Origin:
-		writer.Header().Set("WebHook-Allowed-Rate", "*")   // Unlimited requests/minute
+		writer.Header().Set("WebHook-Allowed-Rate", h.allowedRateFor(request.RequestURI))
 		writer.WriteHeader(http.StatusOK)
 		return
 	}
@@ -128,8 +147,16 @@ func (h *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 
+	brokerNamespace := nsBrokerName[1]
+	brokerName := nsBrokerName[2]
+
 	ctx := request.Context()
 
+	if isBatch(request) {
+		h.serveBatch(ctx, writer, request, brokerNamespace, brokerName)
+		return
+	}
+
 	message := cehttp.NewMessageFromHttpRequest(request)
 	defer message.Finish(nil)
 
@@ -140,16 +167,23 @@ func (h *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 
+	statusCode, retryAfter := h.handleEvent(ctx, request.Header, event, brokerNamespace, brokerName)
+	if retryAfter != "" {
+		writer.Header().Set("Retry-After", retryAfter)
+	}
+	writer.WriteHeader(statusCode)
+}
+
+// handleEvent validates, throttles and sends an event to the broker, and reports its metrics.
+// It returns the status code for the event and, when the event is throttled, the Retry-After value.
+func (h *Handler) handleEvent(ctx context.Context, headers http.Header, event *cloudevents.Event, brokerNamespace, brokerName string) (int, string) {
 	// run validation for the extracted event
 	validationErr := event.Validate()
 	if validationErr != nil {
 		h.Logger.Warn("failed to validate extracted event", zap.Error(validationErr))
-		writer.WriteHeader(http.StatusBadRequest)
-		return
+		return http.StatusBadRequest, ""
 	}
 
-	brokerNamespace := nsBrokerName[1]
-	brokerName := nsBrokerName[2]
 	brokerNamespacedName := types.NamespacedName{
 		Name:      brokerName,
 		Namespace: brokerNamespace,
@@ -174,13 +208,51 @@ func (h *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 		eventType: event.Type(),
 	}
 
-	statusCode, dispatchTime := h.receive(ctx, request.Header, event, brokerNamespace, brokerName)
+	var dedupWindow time.Duration
+	var key dedupKey
+	if b, err := h.BrokerLister.Brokers(brokerNamespace).Get(brokerName); err == nil {
+		if dedupWindow = h.getDedupWindow(b); dedupWindow > 0 {
+			key = newDedupKey(b, event)
+			switch h.dedup.Reserve(key, dedupWindow, time.Now()) {
+			case dedupDuplicate:
+				h.Logger.Debug("dropping duplicate event", zap.String("event.source", event.Source()), zap.String("event.id", event.ID()))
+				_ = h.Reporter.ReportEventDuplicate(reporterArgs)
+				_ = h.Reporter.ReportEventCount(reporterArgs, http.StatusAccepted)
+				return http.StatusAccepted, ""
+			case dedupInFlight:
+				// The copy being dispatched may still fail, so the producer must retry this one.
+				h.Logger.Debug("rejecting event while a copy is being dispatched", zap.String("event.source", event.Source()), zap.String("event.id", event.ID()))
+				_ = h.Reporter.ReportEventCount(reporterArgs, http.StatusConflict)
+				return http.StatusConflict, ""
+			}
+		}
+		if delay := h.throttle(b, h.getRateLimits(b), event.Source()); delay > 0 {
+			if dedupWindow > 0 {
+				h.dedup.Release(key)
+			}
+			h.Logger.Debug("throttling event exceeding the broker rate limit", zap.String("event.source", event.Source()), zap.String("event.id", event.ID()))
+			_ = h.Reporter.ReportEventThrottled(reporterArgs)
+			_ = h.Reporter.ReportEventCount(reporterArgs, http.StatusTooManyRequests)
+			return http.StatusTooManyRequests, retryAfter(delay)
+		}
+	}
+
+	statusCode, dispatchTime := h.receive(ctx, headers, event, brokerNamespace, brokerName)
 	if dispatchTime > noDuration {
 		_ = h.Reporter.ReportEventDispatchTime(reporterArgs, statusCode, dispatchTime)
 	}
 	_ = h.Reporter.ReportEventCount(reporterArgs, statusCode)
 
-	writer.WriteHeader(statusCode)
+	// Only remember the accepted events, so that the producers can retry the others.
+	if dedupWindow > 0 {
+		if statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices {
+			h.dedup.Accept(key, time.Now())
+		} else {
+			h.dedup.Release(key)
+		}
+	}
+
+	return statusCode, ""
 }
 
 func (h *Handler) receive(ctx context.Context, headers http.Header, event *cloudevents.Event, brokerNamespace, brokerName string) (int, time.Duration) {
@@ -197,7 +269,37 @@ func (h *Handler) receive(ctx context.Context, headers http.Header, event *cloud
 		return http.StatusBadRequest, noDuration
 	}
 
-	channelAddress, err := h.getChannelAddress(brokerName, brokerNamespace)
+	var channelAddress string
+	b, err := h.getBroker(brokerName, brokerNamespace)
+	if err != nil && h.EventTypeLister != nil {
+		// The EventType policy of a Broker that can't be resolved can't be checked, let the sender retry.
+		h.Logger.Warn("Failed to get the broker, rejecting the event", zap.Error(err),
+			zap.String("broker.namespace", brokerNamespace), zap.String("broker.name", brokerName))
+		return http.StatusServiceUnavailable, noDuration
+	}
+	if err == nil {
+		if allowed, policyErr := h.isEventTypeAllowed(event, b); policyErr != nil {
+			h.Logger.Warn("Failed to check the EventType policy", zap.Error(policyErr), zap.String("event.id", event.ID()))
+			return http.StatusServiceUnavailable, noDuration
+		} else if !allowed {
+			h.Logger.Debug("dropping event not matching any EventType of the broker", zap.String("event.type", event.Type()), zap.String("event.source", event.Source()))
+			return http.StatusForbidden, noDuration
+		}
+		if mode := eventTypeValidation(b); mode != "" {
+			if err := h.validateSchema(event, b); errors.Is(err, errEventTypesUnavailable) {
+				h.Logger.Warn("Failed to validate the event", zap.Error(err), zap.String("event.id", event.ID()))
+				return http.StatusServiceUnavailable, noDuration
+			} else if err != nil {
+				h.Logger.Debug("event doesn't conform to the EventType schema", zap.String("event.type", event.Type()), zap.String("event.id", event.ID()), zap.Error(err))
+				_ = h.Reporter.ReportEventValidationFailure(&ReportArgs{ns: brokerNamespace, broker: brokerName, eventType: event.Type()})
+				if mode == eventing.EventTypeValidationDeadLetter && b.Status.DeadLetterSinkURI != nil {
+					return h.sendToDeadLetterSink(ctx, headers, event, b, err)
+				}
+				return http.StatusBadRequest, noDuration
+			}
+		}
+		channelAddress, err = getChannelAddress(b)
+	}
 	if err != nil {
 		h.Logger.Warn("Failed to get channel address, falling back on guess", zap.Error(err))
 		channelAddress = guessChannelAddress(brokerName, brokerNamespace, network.GetClusterDomainName())
@@ -206,7 +308,24 @@ func (h *Handler) receive(ctx context.Context, headers http.Header, event *cloud
 	return h.send(ctx, headers, event, channelAddress)
 }
 
-func (h *Handler) send(ctx context.Context, headers http.Header, event *cloudevents.Event, target string) (int, time.Duration) {
+// sendToDeadLetterSink sends the event to the dead letter sink of the Broker, with the knativeerror*
+// extensions describing why the event was not accepted.
+func (h *Handler) sendToDeadLetterSink(ctx context.Context, headers http.Header, event *cloudevents.Event, b *eventingv1.Broker, reason error) (int, time.Duration) {
+	destination := url.URL{}
+	if b.Status.Address != nil && b.Status.Address.URL != nil {
+		destination = *b.Status.Address.URL.URL()
+	}
+	errData := base64.StdEncoding.EncodeToString([]byte(reason.Error()))
+	statusCode, dispatchTime := h.send(ctx, headers, event, b.Status.DeadLetterSinkURI.String(),
+		channelattributes.KnativeErrorTransformers(destination, http.StatusBadRequest, errData)...)
+	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+		h.Logger.Warn("failed to send event to the dead letter sink", zap.Int("statusCode", statusCode), zap.String("event.id", event.ID()))
+		return http.StatusBadRequest, dispatchTime
+	}
+	return http.StatusAccepted, dispatchTime
+}
+
+func (h *Handler) send(ctx context.Context, headers http.Header, event *cloudevents.Event, target string, transformers ...binding.Transformer) (int, time.Duration) {
 
 	request, err := h.Sender.NewCloudEventRequestWithTarget(ctx, target)
 	if err != nil {
@@ -218,7 +337,7 @@ func (h *Handler) send(ctx context.Context, headers http.Header, event *cloudeve
 	defer message.Finish(nil)
 
 	additionalHeaders := utils.PassThroughHeaders(headers)
-	err = kncloudevents.WriteHTTPRequestWithAdditionalHeaders(ctx, message, request, additionalHeaders)
+	err = kncloudevents.WriteHTTPRequestWithAdditionalHeaders(ctx, message, request, additionalHeaders, transformers...)
 	if err != nil {
 		h.Logger.Error("failed to write request additionalHeaders.", zap.Error(err))
 		return http.StatusInternalServerError, noDuration
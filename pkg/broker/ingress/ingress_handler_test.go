@@ -33,10 +33,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
+	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 
 	"knative.dev/eventing/pkg/apis/eventing"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	eventingv1beta1 "knative.dev/eventing/pkg/apis/eventing/v1beta1"
 	"knative.dev/eventing/pkg/broker"
 	"knative.dev/eventing/pkg/kncloudevents"
 	reconcilertestingv1 "knative.dev/eventing/pkg/reconciler/testing/v1"
@@ -44,6 +46,12 @@ import (
 
 const (
 	senderResponseStatusCode = nethttp.StatusAccepted
+
+	testSchema = `{
+		"type": "object",
+		"properties": {"id": {"type": "integer"}},
+		"required": ["id"]
+	}`
 )
 
 func TestHandler_ServeHTTP(t *testing.T) {
@@ -63,6 +71,7 @@ func TestHandler_ServeHTTP(t *testing.T) {
 		reporter        StatsReporter
 		defaulter       client.EventDefaulter
 		brokers         []*eventingv1.Broker
+		eventTypes      []*eventingv1beta1.EventType
 	}{
 		{
 			name:       "invalid method PATCH",
@@ -249,6 +258,159 @@ func TestHandler_ServeHTTP(t *testing.T) {
 				makeBroker("name", "ns"),
 			},
 		},
+		{
+			name:       "event type policy enforced, matching event type",
+			method:     nethttp.MethodPost,
+			uri:        "/ns/name",
+			body:       getValidEvent(),
+			statusCode: senderResponseStatusCode,
+			handler:    handler(),
+			reporter:   &mockReporter{StatusCode: senderResponseStatusCode, EventDispatchTimeReported: true},
+			defaulter:  broker.TTLDefaulter(logger, 100),
+			brokers: []*eventingv1.Broker{
+				withEventTypePolicyEnforced(makeBroker("name", "ns")),
+			},
+			eventTypes: []*eventingv1beta1.EventType{
+				makeEventType("et", "ns", "name", "type", "source"),
+			},
+		},
+		{
+			name:       "event type policy enforced, matching event type without source",
+			method:     nethttp.MethodPost,
+			uri:        "/ns/name",
+			body:       getValidEvent(),
+			statusCode: senderResponseStatusCode,
+			handler:    handler(),
+			reporter:   &mockReporter{StatusCode: senderResponseStatusCode, EventDispatchTimeReported: true},
+			defaulter:  broker.TTLDefaulter(logger, 100),
+			brokers: []*eventingv1.Broker{
+				withEventTypePolicyEnforced(makeBroker("name", "ns")),
+			},
+			eventTypes: []*eventingv1beta1.EventType{
+				makeEventType("et", "ns", "name", "type", ""),
+			},
+		},
+		{
+			name:       "broker not found",
+			method:     nethttp.MethodPost,
+			uri:        "/ns/name",
+			body:       getValidEvent(),
+			statusCode: nethttp.StatusServiceUnavailable,
+			handler:    handler(),
+			reporter:   &mockReporter{StatusCode: nethttp.StatusServiceUnavailable},
+			defaulter:  broker.TTLDefaulter(logger, 100),
+		},
+		{
+			name:       "event type policy enforced, no matching event type",
+			method:     nethttp.MethodPost,
+			uri:        "/ns/name",
+			body:       getValidEvent(),
+			statusCode: nethttp.StatusForbidden,
+			handler:    handler(),
+			reporter:   &mockReporter{StatusCode: nethttp.StatusForbidden},
+			defaulter:  broker.TTLDefaulter(logger, 100),
+			brokers: []*eventingv1.Broker{
+				withEventTypePolicyEnforced(makeBroker("name", "ns")),
+			},
+			eventTypes: []*eventingv1beta1.EventType{
+				makeEventType("other-broker", "ns", "other", "type", "source"),
+				makeEventType("other-source", "ns", "name", "type", "other-source"),
+				makeEventType("other-type", "ns", "name", "other-type", "source"),
+			},
+		},
+		{
+			name:       "event type validation, conforming event",
+			method:     nethttp.MethodPost,
+			uri:        "/ns/name",
+			body:       getValidEventWithData(`{"id": 1}`),
+			statusCode: senderResponseStatusCode,
+			handler:    handler(),
+			reporter:   &mockReporter{StatusCode: senderResponseStatusCode, EventDispatchTimeReported: true},
+			defaulter:  broker.TTLDefaulter(logger, 100),
+			brokers: []*eventingv1.Broker{
+				withEventTypeValidation(makeBroker("name", "ns"), eventing.EventTypeValidationReject),
+			},
+			eventTypes: []*eventingv1beta1.EventType{
+				withSchemaData(makeEventType("et", "ns", "name", "type", "source"), testSchema),
+			},
+		},
+		{
+			name:       "event type validation, not conforming event rejected",
+			method:     nethttp.MethodPost,
+			uri:        "/ns/name",
+			body:       getValidEventWithData(`{"id": "1"}`),
+			statusCode: nethttp.StatusBadRequest,
+			handler:    handler(),
+			reporter:   &mockReporter{StatusCode: nethttp.StatusBadRequest, ValidationFailureReported: true},
+			defaulter:  broker.TTLDefaulter(logger, 100),
+			brokers: []*eventingv1.Broker{
+				withEventTypeValidation(makeBroker("name", "ns"), eventing.EventTypeValidationReject),
+			},
+			eventTypes: []*eventingv1beta1.EventType{
+				withSchemaData(makeEventType("et", "ns", "name", "type", "source"), testSchema),
+			},
+		},
+		{
+			name:       "event type validation, not conforming event sent to the dead letter sink",
+			method:     nethttp.MethodPost,
+			uri:        "/ns/name",
+			body:       getValidEventWithData(`{"id": "1"}`),
+			statusCode: nethttp.StatusAccepted,
+			handler:    handler(),
+			reporter:   &mockReporter{StatusCode: nethttp.StatusAccepted, EventDispatchTimeReported: true, ValidationFailureReported: true},
+			defaulter:  broker.TTLDefaulter(logger, 100),
+			brokers: []*eventingv1.Broker{
+				withDeadLetterSink(withEventTypeValidation(makeBroker("name", "ns"), eventing.EventTypeValidationDeadLetter)),
+			},
+			eventTypes: []*eventingv1beta1.EventType{
+				withSchemaData(makeEventType("et", "ns", "name", "type", "source"), testSchema),
+			},
+		},
+		{
+			name:       "event type validation, dead letter mode without dead letter sink",
+			method:     nethttp.MethodPost,
+			uri:        "/ns/name",
+			body:       getValidEventWithData(`{"id": "1"}`),
+			statusCode: nethttp.StatusBadRequest,
+			handler:    handler(),
+			reporter:   &mockReporter{StatusCode: nethttp.StatusBadRequest, ValidationFailureReported: true},
+			defaulter:  broker.TTLDefaulter(logger, 100),
+			brokers: []*eventingv1.Broker{
+				withEventTypeValidation(makeBroker("name", "ns"), eventing.EventTypeValidationDeadLetter),
+			},
+			eventTypes: []*eventingv1beta1.EventType{
+				withSchemaData(makeEventType("et", "ns", "name", "type", "source"), testSchema),
+			},
+		},
+		{
+			name:       "event type validation, no event type schema",
+			method:     nethttp.MethodPost,
+			uri:        "/ns/name",
+			body:       getValidEventWithData(`{"id": "1"}`),
+			statusCode: senderResponseStatusCode,
+			handler:    handler(),
+			reporter:   &mockReporter{StatusCode: senderResponseStatusCode, EventDispatchTimeReported: true},
+			defaulter:  broker.TTLDefaulter(logger, 100),
+			brokers: []*eventingv1.Broker{
+				withEventTypeValidation(makeBroker("name", "ns"), eventing.EventTypeValidationReject),
+			},
+			eventTypes: []*eventingv1beta1.EventType{
+				makeEventType("et", "ns", "name", "type", "source"),
+			},
+		},
+		{
+			name:       "event type policy not enforced, no matching event type",
+			method:     nethttp.MethodPost,
+			uri:        "/ns/name",
+			body:       getValidEvent(),
+			statusCode: senderResponseStatusCode,
+			handler:    handler(),
+			reporter:   &mockReporter{StatusCode: senderResponseStatusCode, EventDispatchTimeReported: true},
+			defaulter:  broker.TTLDefaulter(logger, 100),
+			brokers: []*eventingv1.Broker{
+				makeBroker("name", "ns"),
+			},
+		},
 	}
 
 	for _, tc := range tt {
@@ -278,16 +440,24 @@ func TestHandler_ServeHTTP(t *testing.T) {
 						}
 					}
 				}
+				// Point the dead letter sink to the test server
+				if b.Status.DeadLetterSinkURI != nil {
+					b.Status.DeadLetterSinkURI, _ = apis.ParseURL(s.URL)
+				}
 				brokers = append(brokers, b)
 			}
+			for _, et := range tc.eventTypes {
+				brokers = append(brokers, et)
+			}
 			listers := reconcilertestingv1.NewListers(brokers)
 			sender, _ := kncloudevents.NewHTTPMessageSenderWithTarget("")
 			h := &Handler{
-				Sender:       sender,
-				Defaulter:    tc.defaulter,
-				Reporter:     &mockReporter{},
-				Logger:       logger,
-				BrokerLister: listers.GetBrokerLister(),
+				Sender:          sender,
+				Defaulter:       tc.defaulter,
+				Reporter:        &mockReporter{},
+				Logger:          logger,
+				BrokerLister:    listers.GetBrokerLister(),
+				EventTypeLister: listers.GetEventTypeLister(),
 			}
 
 			h.ServeHTTP(recorder, request)
@@ -332,6 +502,9 @@ func handler() nethttp.Handler {
 type mockReporter struct {
 	StatusCode                int
 	EventDispatchTimeReported bool
+	ValidationFailureReported bool
+	EventThrottled            bool
+	EventDuplicate            bool
 }
 
 func (r *mockReporter) ReportEventCount(_ *ReportArgs, responseCode int) error {
@@ -344,6 +517,21 @@ func (r *mockReporter) ReportEventDispatchTime(_ *ReportArgs, _ int, _ time.Dura
 	return nil
 }
 
+func (r *mockReporter) ReportEventValidationFailure(_ *ReportArgs) error {
+	r.ValidationFailureReported = true
+	return nil
+}
+
+func (r *mockReporter) ReportEventThrottled(_ *ReportArgs) error {
+	r.EventThrottled = true
+	return nil
+}
+
+func (r *mockReporter) ReportEventDuplicate(_ *ReportArgs) error {
+	r.EventDuplicate = true
+	return nil
+}
+
 func getValidEvent() io.Reader {
 	e := event.New()
 	e.SetType("type")
@@ -353,6 +541,16 @@ func getValidEvent() io.Reader {
 	return bytes.NewBuffer(b)
 }
 
+func getValidEventWithData(data string) io.Reader {
+	e := event.New()
+	e.SetType("type")
+	e.SetSource("source")
+	e.SetID("1234")
+	_ = e.SetData(event.ApplicationJSON, []byte(data))
+	b, _ := e.MarshalJSON()
+	return bytes.NewBuffer(b)
+}
+
 func getInvalidEvent() io.Reader {
 	e := event.New()
 	e.SetType("type")
@@ -384,3 +582,44 @@ func withUninitializedAnnotations(b *eventingv1.Broker) *eventingv1.Broker {
 	b.Status.Annotations = nil
 	return b
 }
+
+func withEventTypePolicyEnforced(b *eventingv1.Broker) *eventingv1.Broker {
+	b.Annotations = map[string]string{
+		eventing.EventTypePolicyAnnotationKey: eventing.EventTypePolicyEnforced,
+	}
+	return b
+}
+
+func withEventTypeValidation(b *eventingv1.Broker, mode string) *eventingv1.Broker {
+	b.Annotations = map[string]string{
+		eventing.EventTypeValidationAnnotationKey: mode,
+	}
+	return b
+}
+
+func withDeadLetterSink(b *eventingv1.Broker) *eventingv1.Broker {
+	b.Status.DeadLetterSinkURI = apis.HTTP("dls")
+	return b
+}
+
+func withSchemaData(et *eventingv1beta1.EventType, schema string) *eventingv1beta1.EventType {
+	et.Spec.SchemaData = schema
+	return et
+}
+
+func makeEventType(name, namespace, brokerName, eventType, source string) *eventingv1beta1.EventType {
+	et := &eventingv1beta1.EventType{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: eventingv1beta1.EventTypeSpec{
+			Type:   eventType,
+			Broker: brokerName,
+		},
+	}
+	if source != "" {
+		et.Spec.Source, _ = apis.ParseURL(source)
+	}
+	return et
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"golang.org/x/time/rate"
+
+	"knative.dev/eventing/pkg/apis/eventing"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+)
+
+const (
+	// Keys of the rate limits in the ConfigMap referenced by the Broker config.
+	rateLimitConfigKey            = "ingress.rate-limit"
+	rateLimitBurstConfigKey       = "ingress.rate-limit-burst"
+	sourceRateLimitConfigKey      = "ingress.source-rate-limit"
+	sourceRateLimitBurstConfigKey = "ingress.source-rate-limit-burst"
+
+	// defaultMaxRateLimiters is the maximum number of source token buckets kept by the ingress,
+	// the least recently used buckets are evicted first.
+	defaultMaxRateLimiters = 10000
+)
+
+var rateLimitAnnotations = map[string]string{
+	eventing.BrokerRateLimitAnnotationKey:            rateLimitConfigKey,
+	eventing.BrokerRateLimitBurstAnnotationKey:       rateLimitBurstConfigKey,
+	eventing.BrokerSourceRateLimitAnnotationKey:      sourceRateLimitConfigKey,
+	eventing.BrokerSourceRateLimitBurstAnnotationKey: sourceRateLimitBurstConfigKey,
+}
+
+// rateLimit is the configuration of a token bucket, a zero limit means unlimited.
+type rateLimit struct {
+	limit rate.Limit
+	burst int
+}
+
+func (l rateLimit) unlimited() bool {
+	return l.limit <= 0
+}
+
+// rateLimits are the rate limits of a Broker, for all the events and for the events of each source.
+type rateLimits struct {
+	broker rateLimit
+	source rateLimit
+}
+
+// getRateLimits returns the rate limits of the Broker, from its annotations or from the ConfigMap
+// referenced by its config. Invalid rate limits are ignored.
+func (h *Handler) getRateLimits(b *eventingv1.Broker) rateLimits {
+	values := h.getBrokerConfig(b, rateLimitAnnotations)
+
+	var limits rateLimits
+	var err error
+	if limits.broker, err = parseRateLimit(values, rateLimitConfigKey, rateLimitBurstConfigKey); err != nil {
+		h.warnings.warn(h.Logger, b, "Invalid Broker rate limit", err)
+	} else {
+		h.warnings.resolve(b, "Invalid Broker rate limit")
+	}
+	if limits.source, err = parseRateLimit(values, sourceRateLimitConfigKey, sourceRateLimitBurstConfigKey); err != nil {
+		h.warnings.warn(h.Logger, b, "Invalid source rate limit", err)
+	} else {
+		h.warnings.resolve(b, "Invalid source rate limit")
+	}
+	return limits
+}
+
+// parseRateLimit parses a rate limit in events per second and its burst, which defaults to the
+// rate limit rounded up.
+func parseRateLimit(values map[string]string, limitKey, burstKey string) (rateLimit, error) {
+	v, ok := values[limitKey]
+	if !ok {
+		return rateLimit{}, nil
+	}
+	limit, err := strconv.ParseFloat(v, 64)
+	if err != nil || limit <= 0 || math.IsInf(limit, 0) {
+		return rateLimit{}, fmt.Errorf("%s must be a positive number of events per second, was: %q", limitKey, v)
+	}
+	burst := int(math.Ceil(limit))
+	if v, ok := values[burstKey]; ok {
+		if burst, err = strconv.Atoi(v); err != nil || burst < 1 {
+			return rateLimit{}, fmt.Errorf("%s must be a positive integer, was: %q", burstKey, v)
+		}
+	}
+	return rateLimit{limit: rate.Limit(limit), burst: burst}, nil
+}
+
+// allowedRate returns the WebHook-Allowed-Rate, in requests per minute, of a sender
+// given the rate limits of a Broker.
+func allowedRate(limits rateLimits) string {
+	l := limits.broker
+	if l.unlimited() || (!limits.source.unlimited() && limits.source.limit < l.limit) {
+		l = limits.source
+	}
+	if l.unlimited() {
+		return "*"
+	}
+	perMinute := int(float64(l.limit) * 60)
+	if perMinute < 1 {
+		perMinute = 1
+	}
+	return strconv.Itoa(perMinute)
+}
+
+// allowedRateFor returns the WebHook-Allowed-Rate for the Broker addressed by the request URI.
+func (h *Handler) allowedRateFor(requestURI string) string {
+	nsBrokerName := strings.Split(strings.TrimSuffix(requestURI, "/"), "/")
+	if len(nsBrokerName) != 3 || h.BrokerLister == nil {
+		return "*"
+	}
+	b, err := h.BrokerLister.Brokers(nsBrokerName[1]).Get(nsBrokerName[2])
+	if err != nil {
+		return "*"
+	}
+	return allowedRate(h.getRateLimits(b))
+}
+
+// limiterKey identifies the token bucket of a Broker, or of a source of a Broker.
+type limiterKey struct {
+	namespace string
+	name      string
+	perSource bool
+	source    string
+}
+
+// rateLimiters keeps the token buckets of the Brokers and of their sources. The buckets of the
+// Brokers are never evicted, so that many distinct sources can't reset the Broker buckets.
+// The zero value is ready to use.
+type rateLimiters struct {
+	once    sync.Once
+	mu      sync.Mutex
+	brokers map[limiterKey]*rate.Limiter
+	sources *lru.Cache
+}
+
+// get returns the token bucket for key, creating it if missing, and updates it to the given rate limit.
+func (r *rateLimiters) get(key limiterKey, l rateLimit) *rate.Limiter {
+	r.once.Do(func() {
+		r.brokers = make(map[limiterKey]*rate.Limiter)
+		r.sources, _ = lru.New(defaultMaxRateLimiters)
+	})
+	var limiter *rate.Limiter
+	if key.perSource {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		if previous, ok, _ := r.sources.PeekOrAdd(key, limiter); ok {
+			limiter = previous.(*rate.Limiter)
+			r.sources.Get(key) // mark as recently used
+		}
+	} else {
+		r.mu.Lock()
+		if limiter = r.brokers[key]; limiter == nil {
+			limiter = rate.NewLimiter(l.limit, l.burst)
+			r.brokers[key] = limiter
+		}
+		r.mu.Unlock()
+	}
+	if limiter.Limit() != l.limit {
+		limiter.SetLimit(l.limit)
+	}
+	if limiter.Burst() != l.burst {
+		limiter.SetBurst(l.burst)
+	}
+	return limiter
+}
+
+// throttle takes a token for the event from the token buckets of the Broker and of the event source.
+// When the event exceeds one of the rate limits, no token is taken and throttle returns how long
+// the sender should wait before retrying, otherwise it returns zero.
+func (h *Handler) throttle(b *eventingv1.Broker, limits rateLimits, source string) time.Duration {
+	now := time.Now()
+	reservations := make([]*rate.Reservation, 0, 2)
+	if !limits.broker.unlimited() {
+		key := limiterKey{namespace: b.Namespace, name: b.Name}
+		reservations = append(reservations, h.limiters.get(key, limits.broker).ReserveN(now, 1))
+	}
+	if !limits.source.unlimited() {
+		key := limiterKey{namespace: b.Namespace, name: b.Name, perSource: true, source: source}
+		reservations = append(reservations, h.limiters.get(key, limits.source).ReserveN(now, 1))
+	}
+
+	var delay time.Duration
+	for _, r := range reservations {
+		d := time.Second
+		if r.OK() {
+			d = r.DelayFrom(now)
+		}
+		if d > delay {
+			delay = d
+		}
+	}
+	if delay > 0 {
+		for _, r := range reservations {
+			r.CancelAt(now)
+		}
+	}
+	return delay
+}
+
+// retryAfter formats a delay as the value of a Retry-After header, in seconds rounded up.
+func retryAfter(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,261 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"fmt"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"knative.dev/eventing/pkg/apis/eventing"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/broker"
+	"knative.dev/eventing/pkg/kncloudevents"
+	reconcilertestingv1 "knative.dev/eventing/pkg/reconciler/testing/v1"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  map[string]string
+		want    rateLimit
+		wantErr bool
+	}{{
+		name:   "no rate limit",
+		values: map[string]string{},
+	}, {
+		name:   "default burst",
+		values: map[string]string{rateLimitConfigKey: "2.5"},
+		want:   rateLimit{limit: 2.5, burst: 3},
+	}, {
+		name:   "burst",
+		values: map[string]string{rateLimitConfigKey: "10", rateLimitBurstConfigKey: "50"},
+		want:   rateLimit{limit: 10, burst: 50},
+	}, {
+		name:    "invalid rate limit",
+		values:  map[string]string{rateLimitConfigKey: "fast"},
+		wantErr: true,
+	}, {
+		name:    "negative rate limit",
+		values:  map[string]string{rateLimitConfigKey: "-1"},
+		wantErr: true,
+	}, {
+		name:    "invalid burst",
+		values:  map[string]string{rateLimitConfigKey: "10", rateLimitBurstConfigKey: "0"},
+		wantErr: true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseRateLimit(tc.values, rateLimitConfigKey, rateLimitBurstConfigKey)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("parseRateLimit() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("parseRateLimit() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetRateLimits(t *testing.T) {
+	b := withRateLimits(makeBroker("name", "ns"), map[string]string{
+		eventing.BrokerRateLimitAnnotationKey: "100",
+	})
+	b.Spec.Config = &duckv1.KReference{
+		Kind:       "ConfigMap",
+		APIVersion: "v1",
+		Namespace:  "ns",
+		Name:       "config-br",
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "config-br"},
+		Data: map[string]string{
+			rateLimitConfigKey:       "10",
+			sourceRateLimitConfigKey: "1",
+		},
+	}
+	listers := reconcilertestingv1.NewListers([]runtime.Object{cm})
+	h := &Handler{
+		Logger:          zap.NewNop(),
+		ConfigMapLister: listers.GetConfigMapLister(),
+	}
+
+	want := rateLimits{
+		broker: rateLimit{limit: 100, burst: 100},
+		source: rateLimit{limit: 1, burst: 1},
+	}
+	if got := h.getRateLimits(b); got != want {
+		t.Errorf("getRateLimits() = %+v, want %+v", got, want)
+	}
+}
+
+func TestAllowedRate(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits rateLimits
+		want   string
+	}{{
+		name: "unlimited",
+		want: "*",
+	}, {
+		name:   "broker rate limit",
+		limits: rateLimits{broker: rateLimit{limit: 10, burst: 10}},
+		want:   "600",
+	}, {
+		name:   "source rate limit",
+		limits: rateLimits{source: rateLimit{limit: 0.5, burst: 1}},
+		want:   "30",
+	}, {
+		name: "lowest rate limit",
+		limits: rateLimits{
+			broker: rateLimit{limit: 10, burst: 10},
+			source: rateLimit{limit: 2, burst: 2},
+		},
+		want: "120",
+	}, {
+		name:   "less than one request per minute",
+		limits: rateLimits{broker: rateLimit{limit: 0.001, burst: 1}},
+		want:   "1",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := allowedRate(tc.limits); got != tc.want {
+				t.Errorf("allowedRate() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	h := &Handler{Logger: zap.NewNop()}
+	b := makeBroker("name", "ns")
+	limits := rateLimits{
+		broker: rateLimit{limit: rate.Every(time.Hour), burst: 3},
+		source: rateLimit{limit: rate.Every(time.Hour), burst: 1},
+	}
+
+	if d := h.throttle(b, limits, "source1"); d != 0 {
+		t.Fatalf("Expected the first event of source1 to be accepted, got delay %v", d)
+	}
+	if d := h.throttle(b, limits, "source1"); d <= 0 {
+		t.Fatal("Expected the second event of source1 to be throttled")
+	}
+	// The throttled event of source1 must not take a token of the Broker.
+	if d := h.throttle(b, limits, "source2"); d != 0 {
+		t.Fatalf("Expected the first event of source2 to be accepted, got delay %v", d)
+	}
+	if d := h.throttle(b, limits, "source3"); d != 0 {
+		t.Fatalf("Expected the first event of source3 to be accepted, got delay %v", d)
+	}
+	if d := h.throttle(b, limits, "source4"); d <= 0 {
+		t.Fatal("Expected the event of source4 to exceed the Broker rate limit")
+	}
+}
+
+func TestThrottle_ManySources(t *testing.T) {
+	h := &Handler{Logger: zap.NewNop()}
+	b := makeBroker("name", "ns")
+	other := makeBroker("other", "ns")
+	limits := rateLimits{
+		broker: rateLimit{limit: rate.Every(time.Hour), burst: 1},
+	}
+	otherLimits := rateLimits{
+		source: rateLimit{limit: rate.Every(time.Hour), burst: 1},
+	}
+
+	if d := h.throttle(b, limits, "source"); d != 0 {
+		t.Fatalf("Expected the first event to be accepted, got delay %v", d)
+	}
+	// Sending from more sources than the source buckets kept must not evict the Broker bucket.
+	for i := 0; i <= defaultMaxRateLimiters; i++ {
+		if d := h.throttle(other, otherLimits, fmt.Sprintf("source%d", i)); d != 0 {
+			t.Fatalf("Expected the event of source%d to be accepted, got delay %v", i, d)
+		}
+	}
+	if d := h.throttle(b, limits, "source"); d <= 0 {
+		t.Fatal("Expected the second event to exceed the Broker rate limit")
+	}
+}
+
+func TestHandler_RateLimit(t *testing.T) {
+	s := httptest.NewServer(handler())
+	defer s.Close()
+
+	b := withRateLimits(makeBroker("name", "ns"), map[string]string{
+		eventing.BrokerRateLimitAnnotationKey:      "0.5",
+		eventing.BrokerRateLimitBurstAnnotationKey: "1",
+	})
+	b.Status.Annotations[eventing.BrokerChannelAddressStatusAnnotationKey] = s.URL
+	listers := reconcilertestingv1.NewListers([]runtime.Object{b})
+	sender, _ := kncloudevents.NewHTTPMessageSenderWithTarget("")
+	reporter := &mockReporter{}
+	h := &Handler{
+		Sender:       sender,
+		Defaulter:    broker.TTLDefaulter(zap.NewNop(), 100),
+		Reporter:     reporter,
+		Logger:       zap.NewNop(),
+		BrokerLister: listers.GetBrokerLister(),
+	}
+
+	options := httptest.NewRecorder()
+	h.ServeHTTP(options, httptest.NewRequest(nethttp.MethodOptions, "/ns/name", strings.NewReader("")))
+	if got := options.Result().Header.Get("WebHook-Allowed-Rate"); got != "30" {
+		t.Errorf("Expected WebHook-Allowed-Rate 30, got %q", got)
+	}
+
+	post := func() *nethttp.Response {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(nethttp.MethodPost, "/ns/name", getValidEvent())
+		request.Header.Add(cehttp.ContentType, event.ApplicationCloudEventsJSON)
+		h.ServeHTTP(recorder, request)
+		return recorder.Result()
+	}
+
+	if got := post().StatusCode; got != senderResponseStatusCode {
+		t.Fatalf("Expected status code %d, got %d", senderResponseStatusCode, got)
+	}
+	result := post()
+	if result.StatusCode != nethttp.StatusTooManyRequests {
+		t.Fatalf("Expected status code %d, got %d", nethttp.StatusTooManyRequests, result.StatusCode)
+	}
+	if got := result.Header.Get("Retry-After"); got != "2" {
+		t.Errorf("Expected Retry-After 2, got %q", got)
+	}
+	want := &mockReporter{StatusCode: nethttp.StatusTooManyRequests, EventDispatchTimeReported: true, EventThrottled: true}
+	if diff := cmp.Diff(want, reporter); diff != "" {
+		t.Error("Unexpected reporter state (-want +got)", diff)
+	}
+}
+
+func withRateLimits(b *eventingv1.Broker, annotations map[string]string) *eventingv1.Broker {
+	b.Annotations = annotations
+	return b
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+	"sync"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	"knative.dev/eventing/pkg/apis/eventing"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/apis/eventing/v1beta1"
+)
+
+var (
+	// errNoRemoteSchemas is returned when a schema refers to a schema outside of the EventType.
+	errNoRemoteSchemas = errors.New("loading schemas outside of the EventType is not supported")
+	// errEventTypesUnavailable is returned when the EventTypes of the Broker can't be listed.
+	errEventTypesUnavailable = errors.New("failed to list the EventTypes")
+)
+
+// eventTypeValidation returns how the Broker handles events not conforming to their EventType schema,
+// or an empty string if the Broker doesn't validate events.
+func eventTypeValidation(b *eventingv1.Broker) string {
+	switch v := b.GetAnnotations()[eventing.EventTypeValidationAnnotationKey]; v {
+	case eventing.EventTypeValidationReject, eventing.EventTypeValidationDeadLetter:
+		return v
+	default:
+		return ""
+	}
+}
+
+// compiledSchema is the compiled SchemaData of an EventType at a given generation.
+type compiledSchema struct {
+	uid        types.UID
+	generation int64
+	// schema is nil if the EventType has no SchemaData.
+	schema *jsonschema.Schema
+	// err is the compilation error of the SchemaData.
+	err error
+}
+
+// schemaCache caches the compiled schemas of EventTypes, keyed by EventType namespace and name.
+// The zero value is ready to use.
+type schemaCache struct {
+	mu      sync.RWMutex
+	schemas map[types.NamespacedName]*compiledSchema
+}
+
+// Get returns the compiled schema of the given EventType, compiling and caching it if it is
+// missing or was compiled from a different EventType or generation.
+func (c *schemaCache) Get(et *v1beta1.EventType) *compiledSchema {
+	key := types.NamespacedName{Namespace: et.Namespace, Name: et.Name}
+	c.mu.RLock()
+	s, ok := c.schemas[key]
+	c.mu.RUnlock()
+	if ok && s.uid == et.UID && s.generation == et.Generation {
+		return s
+	}
+
+	s = compileSchema(et)
+	c.mu.Lock()
+	if c.schemas == nil {
+		c.schemas = make(map[types.NamespacedName]*compiledSchema)
+	}
+	c.schemas[key] = s
+	c.mu.Unlock()
+	return s
+}
+
+// Evict removes the compiled schema of the EventType with the given namespace and name.
+func (c *schemaCache) Evict(key types.NamespacedName) {
+	c.mu.Lock()
+	delete(c.schemas, key)
+	c.mu.Unlock()
+}
+
+// EventTypeEventHandler returns the handler evicting the cached EventTypes of the Brokers and the
+// compiled schemas of the EventTypes that were added, whose spec changed or that were deleted,
+// to be added to the EventType informer.
+func (h *Handler) EventTypeEventHandler() cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if et, ok := obj.(*v1beta1.EventType); ok {
+				h.eventTypes.Invalidate(et)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldET, ok := oldObj.(*v1beta1.EventType)
+			if !ok {
+				return
+			}
+			newET, ok := newObj.(*v1beta1.EventType)
+			if ok && newET.UID == oldET.UID && newET.Generation == oldET.Generation {
+				return
+			}
+			h.eventTypes.Invalidate(oldET)
+			if ok {
+				h.eventTypes.Invalidate(newET)
+			}
+			h.schemas.Evict(types.NamespacedName{Namespace: oldET.Namespace, Name: oldET.Name})
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if et, ok := obj.(*v1beta1.EventType); ok {
+				h.eventTypes.Invalidate(et)
+				h.schemas.Evict(types.NamespacedName{Namespace: et.Namespace, Name: et.Name})
+			}
+		},
+	}
+}
+
+func compileSchema(et *v1beta1.EventType) *compiledSchema {
+	s := &compiledSchema{uid: et.UID, generation: et.Generation}
+	if et.Spec.SchemaData == "" {
+		return s
+	}
+	url := fmt.Sprintf("eventtype:///%s/%s.json", et.Namespace, et.Name)
+	compiler := jsonschema.NewCompiler()
+	compiler.LoadURL = func(string) (io.ReadCloser, error) {
+		return nil, errNoRemoteSchemas
+	}
+	if err := compiler.AddResource(url, bytes.NewReader([]byte(et.Spec.SchemaData))); err != nil {
+		s.err = err
+		return s
+	}
+	s.schema, s.err = compiler.Compile(url)
+	return s
+}
+
+// validateSchema validates the event data against the schemas of the EventTypes of the Broker
+// matching the event. The event is valid if it conforms to at least one of these schemas, or if
+// none of the matching EventTypes has a schema. It returns an error wrapping errEventTypesUnavailable
+// if the EventTypes can't be listed.
+func (h *Handler) validateSchema(event *cloudevents.Event, b *eventingv1.Broker) error {
+	if h.EventTypeLister == nil {
+		return nil
+	}
+	eventTypes, err := h.eventTypes.Get(h.EventTypeLister, b)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errEventTypesUnavailable, err)
+	}
+
+	var data interface{}
+	var dataErr error
+	decoded := false
+	var validationErr error
+	for _, et := range eventTypes {
+		if !eventTypeMatches(et, event, b.Name) {
+			continue
+		}
+		s := h.schemas.Get(et)
+		if s.err != nil {
+			h.Logger.Warn("Invalid EventType schema", zap.Error(s.err), zap.String("eventType", et.Name))
+			continue
+		}
+		if s.schema == nil {
+			continue
+		}
+		if !decoded {
+			data, dataErr = decodeData(event)
+			decoded = true
+		}
+		if dataErr != nil {
+			return dataErr
+		}
+		if validationErr = s.schema.Validate(data); validationErr == nil {
+			return nil
+		}
+	}
+	return validationErr
+}
+
+// decodeData decodes the JSON data of the event, the data of any other content type can't be
+// validated against a JSON schema.
+func decodeData(event *cloudevents.Event) (interface{}, error) {
+	if len(event.Data()) == 0 {
+		return nil, nil
+	}
+	if !isJSON(event.DataContentType()) {
+		return nil, fmt.Errorf("event data content type %q is not JSON", event.DataContentType())
+	}
+	decoder := json.NewDecoder(bytes.NewReader(event.Data()))
+	decoder.UseNumber()
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		return nil, fmt.Errorf("event data is not valid JSON: %w", err)
+	}
+	return data, nil
+}
+
+// isJSON returns true if the media type is JSON, an event without datacontenttype holds JSON data.
+func isJSON(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == event.ApplicationJSON || mediaType == event.TextJSON || strings.HasSuffix(mediaType, "+json")
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	eventingv1beta1 "knative.dev/eventing/pkg/apis/eventing/v1beta1"
+	eventinglistersv1beta1 "knative.dev/eventing/pkg/client/listers/eventing/v1beta1"
+	reconcilertestingv1 "knative.dev/eventing/pkg/reconciler/testing/v1"
+)
+
+func TestValidateSchema(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		contentType string
+		eventTypes  []*eventingv1beta1.EventType
+		wantErr     bool
+	}{{
+		name: "no event types",
+		data: `{"id": "1"}`,
+	}, {
+		name: "conforming data",
+		data: `{"id": 1}`,
+		eventTypes: []*eventingv1beta1.EventType{
+			withSchemaData(makeEventType("et", "ns", "name", "type", "source"), testSchema),
+		},
+	}, {
+		name: "not conforming data",
+		data: `{"id": "1"}`,
+		eventTypes: []*eventingv1beta1.EventType{
+			withSchemaData(makeEventType("et", "ns", "name", "type", "source"), testSchema),
+		},
+		wantErr: true,
+	}, {
+		name: "conforming to one of the schemas",
+		data: `{"id": "1"}`,
+		eventTypes: []*eventingv1beta1.EventType{
+			withSchemaData(makeEventType("et1", "ns", "name", "type", "source"), testSchema),
+			withSchemaData(makeEventType("et2", "ns", "name", "type", ""), `{"type": "object"}`),
+		},
+	}, {
+		name: "schema of a non matching event type",
+		data: `{"id": "1"}`,
+		eventTypes: []*eventingv1beta1.EventType{
+			withSchemaData(makeEventType("et", "ns", "name", "other-type", "source"), testSchema),
+		},
+	}, {
+		name: "data is not JSON",
+		data: `not json`,
+		eventTypes: []*eventingv1beta1.EventType{
+			withSchemaData(makeEventType("et", "ns", "name", "type", "source"), testSchema),
+		},
+		wantErr: true,
+	}, {
+		name:        "data content type is not JSON",
+		data:        `{"id": 1}`,
+		contentType: "text/plain",
+		eventTypes: []*eventingv1beta1.EventType{
+			withSchemaData(makeEventType("et", "ns", "name", "type", "source"), testSchema),
+		},
+		wantErr: true,
+	}, {
+		name:        "data content type with a JSON suffix",
+		data:        `{"id": 1}`,
+		contentType: "application/vnd.example+json; charset=utf-8",
+		eventTypes: []*eventingv1beta1.EventType{
+			withSchemaData(makeEventType("et", "ns", "name", "type", "source"), testSchema),
+		},
+	}, {
+		name:        "data content type is not JSON without schema",
+		data:        `not json`,
+		contentType: "text/plain",
+		eventTypes: []*eventingv1beta1.EventType{
+			makeEventType("et", "ns", "name", "type", "source"),
+		},
+	}, {
+		name: "invalid schema is ignored",
+		data: `{"id": "1"}`,
+		eventTypes: []*eventingv1beta1.EventType{
+			withSchemaData(makeEventType("et", "ns", "name", "type", "source"), `{"type": 1}`),
+		},
+	}, {
+		name: "remote schema is not loaded",
+		data: `{"id": "1"}`,
+		eventTypes: []*eventingv1beta1.EventType{
+			withSchemaData(makeEventType("et", "ns", "name", "type", "source"), `{"$ref": "https://example.com/schema.json"}`),
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			objs := make([]runtime.Object, 0, len(tc.eventTypes))
+			for _, et := range tc.eventTypes {
+				objs = append(objs, et)
+			}
+			listers := reconcilertestingv1.NewListers(objs)
+			h := &Handler{
+				Logger:          zap.NewNop(),
+				EventTypeLister: listers.GetEventTypeLister(),
+			}
+
+			e := event.New()
+			e.SetType("type")
+			e.SetSource("source")
+			e.SetID("1234")
+			contentType := event.ApplicationJSON
+			if tc.contentType != "" {
+				contentType = tc.contentType
+			}
+			_ = e.SetData(contentType, []byte(tc.data))
+
+			err := h.validateSchema(&e, makeBroker("name", "ns"))
+			if (err != nil) != tc.wantErr {
+				t.Errorf("validateSchema() = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateSchema_ListerError(t *testing.T) {
+	h := &Handler{
+		Logger:          zap.NewNop(),
+		EventTypeLister: failingEventTypeLister{},
+	}
+	e := event.New()
+	e.SetType("type")
+	e.SetSource("source")
+	e.SetID("1234")
+
+	if err := h.validateSchema(&e, makeBroker("name", "ns")); !errors.Is(err, errEventTypesUnavailable) {
+		t.Errorf("validateSchema() = %v, want %v", err, errEventTypesUnavailable)
+	}
+}
+
+// failingEventTypeLister fails to list the EventTypes.
+type failingEventTypeLister struct{}
+
+func (failingEventTypeLister) List(labels.Selector) ([]*eventingv1beta1.EventType, error) {
+	return nil, errors.New("failed to list")
+}
+
+func (l failingEventTypeLister) EventTypes(string) eventinglistersv1beta1.EventTypeNamespaceLister {
+	return l
+}
+
+func (failingEventTypeLister) Get(string) (*eventingv1beta1.EventType, error) {
+	return nil, errors.New("failed to get")
+}
+
+func TestSchemaCache(t *testing.T) {
+	var c schemaCache
+	et := withSchemaData(makeEventType("et", "ns", "name", "type", "source"), testSchema)
+	et.UID = "uid"
+	et.Generation = 1
+
+	first := c.Get(et)
+	if first.err != nil || first.schema == nil {
+		t.Fatalf("Expected the schema to compile, got %+v", first)
+	}
+	if cached := c.Get(et); cached != first {
+		t.Error("Expected the cached schema for the same generation")
+	}
+
+	updated := et.DeepCopy()
+	updated.Generation = 2
+	updated.Spec.SchemaData = ""
+	if second := c.Get(updated); second == first || second.schema != nil {
+		t.Errorf("Expected the schema to be compiled again for a new generation, got %+v", second)
+	}
+}
+
+func TestEventTypeEventHandler(t *testing.T) {
+	h := &Handler{}
+	et := withSchemaData(makeEventType("et", "ns", "name", "type", "source"), testSchema)
+	et.UID = "uid"
+	et.Generation = 1
+	handler := h.EventTypeEventHandler()
+
+	cached := func() bool {
+		_, ok := h.schemas.schemas[types.NamespacedName{Namespace: et.Namespace, Name: et.Name}]
+		return ok
+	}
+
+	h.schemas.Get(et)
+	statusUpdate := et.DeepCopy()
+	handler.OnUpdate(et, statusUpdate)
+	if !cached() {
+		t.Error("Expected the schema to stay cached when the spec did not change")
+	}
+
+	specUpdate := et.DeepCopy()
+	specUpdate.Generation = 2
+	handler.OnUpdate(et, specUpdate)
+	if cached() {
+		t.Error("Expected the schema to be evicted when the spec changed")
+	}
+
+	h.schemas.Get(et)
+	handler.OnDelete(cache.DeletedFinalStateUnknown{Key: "ns/et", Obj: et})
+	if cached() {
+		t.Error("Expected the schema to be evicted when the EventType is deleted")
+	}
+
+	brokerKey := types.NamespacedName{Namespace: et.Namespace, Name: et.Spec.Broker}
+	h.eventTypes.brokers = map[types.NamespacedName][]*eventingv1beta1.EventType{brokerKey: nil}
+	handler.OnAdd(et)
+	if _, ok := h.eventTypes.brokers[brokerKey]; ok {
+		t.Error("Expected the EventTypes of the broker to be evicted when an EventType is added")
+	}
+}
+
+func TestEventTypeIndex(t *testing.T) {
+	b := makeBroker("name", "ns")
+	et := makeEventType("et", "ns", "name", "type", "source")
+	listers := reconcilertestingv1.NewListers([]runtime.Object{
+		et,
+		makeEventType("other-broker", "ns", "other", "type", "source"),
+	})
+	var index eventTypeIndex
+
+	eventTypes, err := index.Get(listers.GetEventTypeLister(), b)
+	if err != nil {
+		t.Fatal("Failed to get the EventTypes:", err)
+	}
+	if len(eventTypes) != 1 || eventTypes[0].Name != "et" {
+		t.Errorf("Expected only the EventType of the broker, got %v", eventTypes)
+	}
+	if _, ok := index.brokers[types.NamespacedName{Namespace: "ns", Name: "name"}]; !ok {
+		t.Error("Expected the EventTypes of the broker to be cached")
+	}
+
+	index.Invalidate(et)
+	if _, ok := index.brokers[types.NamespacedName{Namespace: "ns", Name: "name"}]; ok {
+		t.Error("Expected the EventTypes of the broker to be evicted")
+	}
+}
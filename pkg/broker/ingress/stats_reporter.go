@@ -49,6 +49,30 @@ var (
 		stats.UnitMilliseconds,
 	)
 
+	// validationFailureCountM is a counter which records the number of events
+	// not conforming to the schema of their EventType.
+	validationFailureCountM = stats.Int64(
+		"event_validation_failure_count",
+		"Number of events received by a Broker not conforming to the schema of their EventType",
+		stats.UnitDimensionless,
+	)
+
+	// throttledCountM is a counter which records the number of events
+	// rejected for exceeding the rate limits of a Broker.
+	throttledCountM = stats.Int64(
+		"event_throttled_count",
+		"Number of events received by a Broker exceeding its rate limits",
+		stats.UnitDimensionless,
+	)
+
+	// duplicateCountM is a counter which records the number of duplicate
+	// events dropped by a Broker.
+	duplicateCountM = stats.Int64(
+		"event_duplicate_count",
+		"Number of duplicate events dropped by a Broker",
+		stats.UnitDimensionless,
+	)
+
 	// Create the tag keys that will be used to add tags to our measurements.
 	// Tag keys must conform to the restrictions described in
 	// go.opencensus.io/tag/validate.go. Currently those restrictions are:
@@ -73,6 +97,9 @@ func init() {
 type StatsReporter interface {
 	ReportEventCount(args *ReportArgs, responseCode int) error
 	ReportEventDispatchTime(args *ReportArgs, responseCode int, d time.Duration) error
+	ReportEventValidationFailure(args *ReportArgs) error
+	ReportEventThrottled(args *ReportArgs) error
+	ReportEventDuplicate(args *ReportArgs) error
 }
 
 var _ StatsReporter = (*reporter)(nil)
@@ -114,6 +141,33 @@ func register() {
 			Aggregation: view.Distribution(metrics.Buckets125(1, 10000)...), // 1, 2, 5, 10, 20, 50, 100, 500, 1000, 5000, 10000
 			TagKeys:     tagKeys,
 		},
+		&view.View{
+			Description: validationFailureCountM.Description(),
+			Measure:     validationFailureCountM,
+			Aggregation: view.Count(),
+			TagKeys: []tag.Key{
+				eventTypeKey,
+				broker.ContainerTagKey,
+				broker.UniqueTagKey},
+		},
+		&view.View{
+			Description: throttledCountM.Description(),
+			Measure:     throttledCountM,
+			Aggregation: view.Count(),
+			TagKeys: []tag.Key{
+				eventTypeKey,
+				broker.ContainerTagKey,
+				broker.UniqueTagKey},
+		},
+		&view.View{
+			Description: duplicateCountM.Description(),
+			Measure:     duplicateCountM,
+			Aggregation: view.Count(),
+			TagKeys: []tag.Key{
+				eventTypeKey,
+				broker.ContainerTagKey,
+				broker.UniqueTagKey},
+		},
 	)
 	if err != nil {
 		log.Printf("failed to register opencensus views, %s", err)
@@ -141,19 +195,64 @@ func (r *reporter) ReportEventDispatchTime(args *ReportArgs, responseCode int, d
 	return nil
 }
 
+// ReportEventValidationFailure captures the count of events not conforming to their EventType schema.
+func (r *reporter) ReportEventValidationFailure(args *ReportArgs) error {
+	ctx, err := tag.New(
+		withBrokerResource(args),
+		tag.Insert(broker.ContainerTagKey, r.container),
+		tag.Insert(broker.UniqueTagKey, r.uniqueName),
+		tag.Insert(eventTypeKey, args.eventType))
+	if err != nil {
+		return err
+	}
+	metrics.Record(ctx, validationFailureCountM.M(1))
+	return nil
+}
+
+// ReportEventThrottled captures the count of events exceeding the rate limits of a Broker.
+func (r *reporter) ReportEventThrottled(args *ReportArgs) error {
+	ctx, err := tag.New(
+		withBrokerResource(args),
+		tag.Insert(broker.ContainerTagKey, r.container),
+		tag.Insert(broker.UniqueTagKey, r.uniqueName),
+		tag.Insert(eventTypeKey, args.eventType))
+	if err != nil {
+		return err
+	}
+	metrics.Record(ctx, throttledCountM.M(1))
+	return nil
+}
+
+// ReportEventDuplicate captures the count of duplicate events dropped by a Broker.
+func (r *reporter) ReportEventDuplicate(args *ReportArgs) error {
+	ctx, err := tag.New(
+		withBrokerResource(args),
+		tag.Insert(broker.ContainerTagKey, r.container),
+		tag.Insert(broker.UniqueTagKey, r.uniqueName),
+		tag.Insert(eventTypeKey, args.eventType))
+	if err != nil {
+		return err
+	}
+	metrics.Record(ctx, duplicateCountM.M(1))
+	return nil
+}
+
 func (r *reporter) generateTag(args *ReportArgs, responseCode int) (context.Context, error) {
-	ctx := metricskey.WithResource(emptyContext, resource.Resource{
-		Type: eventingmetrics.ResourceTypeKnativeBroker,
-		Labels: map[string]string{
-			eventingmetrics.LabelNamespaceName: args.ns,
-			eventingmetrics.LabelBrokerName:    args.broker,
-		},
-	})
 	return tag.New(
-		ctx,
+		withBrokerResource(args),
 		tag.Insert(broker.ContainerTagKey, r.container),
 		tag.Insert(broker.UniqueTagKey, r.uniqueName),
 		tag.Insert(eventTypeKey, args.eventType),
 		tag.Insert(responseCodeKey, strconv.Itoa(responseCode)),
 		tag.Insert(responseCodeClassKey, metrics.ResponseCodeClass(responseCode)))
 }
+
+func withBrokerResource(args *ReportArgs) context.Context {
+	return metricskey.WithResource(emptyContext, resource.Resource{
+		Type: eventingmetrics.ResourceTypeKnativeBroker,
+		Labels: map[string]string{
+			eventingmetrics.LabelNamespaceName: args.ns,
+			eventingmetrics.LabelBrokerName:    args.broker,
+		},
+	})
+}
@@ -73,6 +73,39 @@ func TestStatsReporter(t *testing.T) {
 	})
 	metricstest.AssertMetric(t, metricstest.DistributionCountOnlyMetric("event_dispatch_latencies", 2, wantTags))
 	metricstest.CheckDistributionData(t, "event_dispatch_latencies", wantTags, 2, 1100.0, 9100.0)
+
+	// test ReportEventValidationFailure
+	expectSuccess(t, func() error {
+		return r.ReportEventValidationFailure(args)
+	})
+	metricstest.AssertMetric(t, metricstest.IntMetric("event_validation_failure_count", 1, map[string]string{
+		metrics.LabelEventType:    "testeventtype",
+		broker.LabelUniqueName:    "testpod",
+		broker.LabelContainerName: "testcontainer",
+	}).WithResource(&resource))
+
+	// test ReportEventThrottled
+	expectSuccess(t, func() error {
+		return r.ReportEventThrottled(args)
+	})
+	expectSuccess(t, func() error {
+		return r.ReportEventThrottled(args)
+	})
+	metricstest.AssertMetric(t, metricstest.IntMetric("event_throttled_count", 2, map[string]string{
+		metrics.LabelEventType:    "testeventtype",
+		broker.LabelUniqueName:    "testpod",
+		broker.LabelContainerName: "testcontainer",
+	}).WithResource(&resource))
+
+	// test ReportEventDuplicate
+	expectSuccess(t, func() error {
+		return r.ReportEventDuplicate(args)
+	})
+	metricstest.AssertMetric(t, metricstest.IntMetric("event_duplicate_count", 1, map[string]string{
+		metrics.LabelEventType:    "testeventtype",
+		broker.LabelUniqueName:    "testpod",
+		broker.LabelContainerName: "testcontainer",
+	}).WithResource(&resource))
 }
 
 func expectSuccess(t *testing.T, f func() error) {
@@ -90,6 +123,9 @@ func resetMetrics() {
 	// OpenCensus metrics carry global state that need to be reset between unit tests.
 	metricstest.Unregister(
 		"event_count",
-		"event_dispatch_latencies")
+		"event_dispatch_latencies",
+		"event_validation_failure_count",
+		"event_throttled_count",
+		"event_duplicate_count")
 	register()
 }
@@ -0,0 +1,99 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package attributes
+
+import (
+	"encoding/json"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/uuid"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DeadLetterEventType is the type of the events wrapping the events that could not be delivered,
+// sent to the dead letter sinks with the envelope and reference payloads.
+const DeadLetterEventType = "dev.knative.delivery.failed"
+
+// SubscriberReference identifies the resource, like a Subscription or a Trigger, an event was
+// delivered for.
+type SubscriberReference struct {
+	Kind      string    `json:"kind,omitempty"`
+	Namespace string    `json:"namespace,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	UID       types.UID `json:"uid,omitempty"`
+}
+
+// EventReference identifies the event that could not be delivered.
+type EventReference struct {
+	ID          string `json:"id"`
+	Source      string `json:"source"`
+	Type        string `json:"type"`
+	SpecVersion string `json:"specversion"`
+	Subject     string `json:"subject,omitempty"`
+}
+
+// DeadLetterEventData is the data of the events sent to the dead letter sinks with the envelope
+// and reference payloads.
+type DeadLetterEventData struct {
+	// Destination is the URL the event could not be delivered to.
+	Destination string `json:"destination"`
+	// ResponseCode is the status code of the last response of the destination.
+	ResponseCode int `json:"responseCode"`
+	// ResponseBody is the body of the last response of the destination, it isn't truncated.
+	ResponseBody string `json:"responseBody,omitempty"`
+	// Attempts is the number of attempts to deliver the event, including the retries.
+	Attempts int `json:"attempts"`
+	// FirstAttemptTime and LastAttemptTime are when the delivery started and failed.
+	FirstAttemptTime time.Time `json:"firstAttemptTime"`
+	LastAttemptTime  time.Time `json:"lastAttemptTime"`
+	// Subscriber is the resource the event was delivered for, when known.
+	Subscriber *SubscriberReference `json:"subscriber,omitempty"`
+	// EventReference identifies the event.
+	EventReference EventReference `json:"eventRef"`
+	// Event is the event, in the structured JSON format. It is omitted with the reference payload.
+	Event json.RawMessage `json:"event,omitempty"`
+}
+
+// NewDeadLetterEvent returns the event wrapping the event that could not be delivered. The event
+// itself is included in the data when withEvent is true, otherwise the data only references it.
+func NewDeadLetterEvent(original *cloudevents.Event, data DeadLetterEventData, withEvent bool) (*cloudevents.Event, error) {
+	data.EventReference = EventReference{
+		ID:          original.ID(),
+		Source:      original.Source(),
+		Type:        original.Type(),
+		SpecVersion: original.SpecVersion(),
+		Subject:     original.Subject(),
+	}
+	if withEvent {
+		raw, err := json.Marshal(original)
+		if err != nil {
+			return nil, err
+		}
+		data.Event = raw
+	}
+
+	event := cloudevents.NewEvent()
+	event.SetID(uuid.New().String())
+	event.SetType(DeadLetterEventType)
+	event.SetSource(data.Destination)
+	event.SetTime(data.LastAttemptTime)
+	if err := event.SetData(cloudevents.ApplicationJSON, data); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
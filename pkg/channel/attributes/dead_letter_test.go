@@ -0,0 +1,65 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package attributes
+
+import (
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDeadLetterEvent(t *testing.T) {
+	original := cloudevents.NewEvent()
+	original.SetID("id")
+	original.SetSource("/source")
+	original.SetType("dev.knative.test")
+	original.SetSubject("subject")
+	_ = original.SetData(cloudevents.ApplicationJSON, map[string]string{"hello": "world"})
+
+	lastAttempt := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, withEvent := range []bool{true, false} {
+		e, err := NewDeadLetterEvent(&original, DeadLetterEventData{
+			Destination:      "http://subscriber.example.com",
+			ResponseCode:     500,
+			ResponseBody:     "failed",
+			Attempts:         3,
+			FirstAttemptTime: lastAttempt.Add(-time.Minute),
+			LastAttemptTime:  lastAttempt,
+			Subscriber:       &SubscriberReference{Kind: "Subscription", UID: "abc"},
+		}, withEvent)
+		assert.Nil(t, err)
+		assert.Nil(t, e.Validate())
+		assert.Equal(t, DeadLetterEventType, e.Type())
+		assert.Equal(t, "http://subscriber.example.com", e.Source())
+		assert.Equal(t, lastAttempt, e.Time())
+
+		var data DeadLetterEventData
+		assert.Nil(t, e.DataAs(&data))
+		assert.Equal(t, EventReference{ID: "id", Source: "/source", Type: "dev.knative.test", SpecVersion: "1.0", Subject: "subject"}, data.EventReference)
+		assert.Equal(t, 3, data.Attempts)
+		assert.Equal(t, "failed", data.ResponseBody)
+		if withEvent {
+			var wrapped cloudevents.Event
+			assert.Nil(t, wrapped.UnmarshalJSON(data.Event))
+			assert.Equal(t, original.Data(), wrapped.Data())
+		} else {
+			assert.Empty(t, data.Event)
+		}
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package attributes
 
 import (
+	"encoding/base64"
 	"net/url"
 
 	"github.com/cloudevents/sdk-go/v2/binding"
@@ -40,3 +41,50 @@ func KnativeErrorTransformers(destination url.URL, code int, data string) bindin
 	dataTransformer := transformer.AddExtension(KnativeErrorDataExtensionKey, data)
 	return binding.Transformers{destTransformer, codeTransformer, dataTransformer}
 }
+
+// KnativeErrorData returns the data of the knativeerrordata extension for the given response body: the
+// body encoded as base64, truncated to the max extension length.
+func KnativeErrorData(body []byte) string {
+	// Unprintable control characters are not allowed in header values
+	// and cause HTTP requests to fail if not removed.
+	// https://pkg.go.dev/golang.org/x/net/http/httpguts#ValidHeaderFieldValue
+	httpBody := sanitizeHTTPBody(body)
+
+	data := base64.StdEncoding.EncodeToString([]byte(httpBody))
+	if len(data) > KnativeErrorDataExtensionMaxLength {
+		data = data[:KnativeErrorDataExtensionMaxLength]
+	}
+	return data
+}
+
+func sanitizeHTTPBody(body []byte) string {
+	if !hasControlChars(body) {
+		return string(body)
+	}
+
+	sanitizedResponse := make([]byte, 0, len(body))
+	for _, v := range body {
+		if !isControl(v) {
+			sanitizedResponse = append(sanitizedResponse, v)
+		}
+	}
+	return string(sanitizedResponse)
+}
+
+func hasControlChars(data []byte) bool {
+	for _, v := range data {
+		if isControl(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func isControl(c byte) bool {
+	// US ASCII codes range for printable graphic characters and a space.
+	// http://www.columbia.edu/kermit/ascii.html
+	const asciiUnitSeparator = 31
+	const asciiRubout = 127
+
+	return int(c) < asciiUnitSeparator || int(c) > asciiRubout
+}
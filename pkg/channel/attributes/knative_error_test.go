@@ -18,8 +18,10 @@ package attributes
 
 import (
 	"context"
+	"encoding/base64"
 	"math/rand"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/cloudevents/sdk-go/v2/binding"
@@ -125,3 +127,28 @@ func randomString(t *testing.T, length int) string {
 	assert.Len(t, bytes, length)
 	return string(bytes)
 }
+
+func TestKnativeErrorData(t *testing.T) {
+	testCases := map[string]struct {
+		body []byte
+		want string
+	}{
+		"empty body": {
+			body: nil,
+			want: "",
+		},
+		"control characters are removed": {
+			body: []byte("service\r\nunavailable"),
+			want: base64.StdEncoding.EncodeToString([]byte("serviceunavailable")),
+		},
+		"truncated to the max length": {
+			body: []byte(strings.Repeat("a", KnativeErrorDataExtensionMaxLength)),
+			want: base64.StdEncoding.EncodeToString([]byte(strings.Repeat("a", KnativeErrorDataExtensionMaxLength)))[:KnativeErrorDataExtensionMaxLength],
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, KnativeErrorData(tc.body))
+		})
+	}
+}
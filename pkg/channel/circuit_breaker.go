@@ -0,0 +1,231 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channel
+
+import (
+	"errors"
+	nethttp "net/http"
+	"sync"
+	"time"
+
+	"go.opencensus.io/tag"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/metrics"
+
+	"knative.dev/eventing/pkg/channel/attributes"
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+// ErrCircuitBreakerOpen is the error of the events not sent to a destination because its circuit
+// breaker is open.
+var ErrCircuitBreakerOpen = errors.New("circuit breaker open")
+
+// CircuitBreakerState is the state of the circuit breaker of a destination.
+type CircuitBreakerState int
+
+const (
+	// CircuitBreakerClosed lets the events through.
+	CircuitBreakerClosed CircuitBreakerState = iota
+	// CircuitBreakerHalfOpen lets a single trial event through, its result closes or reopens
+	// the circuit breaker.
+	CircuitBreakerHalfOpen
+	// CircuitBreakerOpen rejects the events.
+	CircuitBreakerOpen
+)
+
+func (s CircuitBreakerState) String() string {
+	switch s {
+	case CircuitBreakerClosed:
+		return "closed"
+	case CircuitBreakerHalfOpen:
+		return "half-open"
+	case CircuitBreakerOpen:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+// circuitBreakerKey identifies the circuit breaker of a destination for a subscriber.
+type circuitBreakerKey struct {
+	// subscriber is the UID of the subscriber, like a Subscription, delivering to the destination.
+	// It is empty when the subscriber is unknown, the circuit breaker of the destination is then
+	// shared by all the deliveries without subscriber.
+	subscriber  types.UID
+	destination string
+}
+
+// circuitBreaker tracks the consecutive failures of a destination.
+type circuitBreaker struct {
+	key circuitBreakerKey
+	// namespace and name identify the subscriber in the reported metrics.
+	namespace string
+	name      string
+	now       func() time.Time
+	// refs counts the deliveries using the circuit breaker, it is guarded by circuitBreakers.mu.
+	refs int
+
+	mu       sync.Mutex
+	config   kncloudevents.CircuitBreakerConfig
+	state    CircuitBreakerState
+	failures int
+	openedAt time.Time
+	// trial is true while the trial event of a half-open circuit breaker is being delivered.
+	trial bool
+}
+
+// allow returns true if an event can be sent to the destination.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case CircuitBreakerOpen:
+		if b.now().Sub(b.openedAt) < b.config.OpenDuration {
+			return false
+		}
+		b.setState(CircuitBreakerHalfOpen)
+		b.trial = true
+		return true
+	case CircuitBreakerHalfOpen:
+		if b.trial {
+			return false
+		}
+		b.trial = true
+		return true
+	default:
+		return true
+	}
+}
+
+// record updates the circuit breaker with the result of the delivery of an allowed event.
+func (b *circuitBreaker) record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == CircuitBreakerHalfOpen {
+		b.trial = false
+		if failed {
+			b.open()
+		} else {
+			b.failures = 0
+			b.setState(CircuitBreakerClosed)
+		}
+		return
+	}
+	if !failed {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.state == CircuitBreakerClosed && b.failures >= b.config.FailureThreshold {
+		b.open()
+	}
+}
+
+func (b *circuitBreaker) open() {
+	b.openedAt = b.now()
+	b.setState(CircuitBreakerOpen)
+}
+
+func (b *circuitBreaker) setState(state CircuitBreakerState) {
+	if state == b.state {
+		return
+	}
+	b.state = state
+	reportCircuitBreakerState(b.namespace, b.name, state)
+}
+
+// idle returns true if the circuit breaker is closed without failures, it doesn't need to be kept.
+func (b *circuitBreaker) idle() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state == CircuitBreakerClosed && b.failures == 0
+}
+
+func (b *circuitBreaker) getState() CircuitBreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// circuitBreakers are the circuit breakers of the destinations, keyed by subscriber and URL, so
+// that the subscribers sharing a destination don't share the config and the state of its circuit
+// breaker. Only the circuit breakers in use or of the destinations that failed are kept.
+type circuitBreakers struct {
+	mu       sync.Mutex
+	breakers map[circuitBreakerKey]*circuitBreaker
+	now      func() time.Time
+}
+
+func newCircuitBreakers() *circuitBreakers {
+	return &circuitBreakers{
+		breakers: make(map[circuitBreakerKey]*circuitBreaker),
+		now:      time.Now,
+	}
+}
+
+// get returns the circuit breaker of the destination for the subscriber, with the given config.
+// The circuit breaker must be released once the delivery is done.
+func (c *circuitBreakers) get(subscriber attributes.SubscriberReference, destination string, config kncloudevents.CircuitBreakerConfig) *circuitBreaker {
+	key := circuitBreakerKey{subscriber: subscriber.UID, destination: destination}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, ok := c.breakers[key]
+	if !ok {
+		b = &circuitBreaker{key: key, namespace: subscriber.Namespace, name: subscriber.Name, now: c.now}
+		c.breakers[key] = b
+	}
+	b.refs++
+	b.mu.Lock()
+	b.config = config
+	b.mu.Unlock()
+	return b
+}
+
+// release releases a circuit breaker returned by get, and forgets it once it is idle and no other
+// delivery uses it, so that the failures recorded by the other deliveries aren't lost.
+func (c *circuitBreakers) release(b *circuitBreaker) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b.refs--
+	if b.refs == 0 && b.idle() && c.breakers[b.key] == b {
+		delete(c.breakers, b.key)
+	}
+}
+
+// isCircuitBreakerFailure returns true if a failed delivery counts as a failure of the destination.
+// Client errors, but timeouts and throttling, are caused by the events rather than the destination.
+func isCircuitBreakerFailure(info *DispatchExecutionInfo, err error) bool {
+	if err == nil {
+		return false
+	}
+	if info == nil {
+		return true
+	}
+	code := info.ResponseCode
+	if code >= 400 && code < 500 {
+		return code == nethttp.StatusRequestTimeout || code == nethttp.StatusTooManyRequests
+	}
+	return true
+}
+
+func reportCircuitBreakerState(namespace, subscription string, state CircuitBreakerState) {
+	ctx, err := tag.New(emptyContext, tag.Insert(namespaceKey, namespace), tag.Insert(subscriptionKey, subscription))
+	if err != nil {
+		return
+	}
+	metrics.Record(ctx, circuitBreakerStateM.M(int64(state)))
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"go.uber.org/zap"
+
+	"knative.dev/eventing/pkg/channel/attributes"
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	breakers := newCircuitBreakers()
+	breakers.now = func() time.Time { return now }
+	config := kncloudevents.CircuitBreakerConfig{FailureThreshold: 2, OpenDuration: time.Minute}
+
+	b := breakers.get(attributes.SubscriberReference{}, "http://destination", config)
+	for i := 0; i < 2; i++ {
+		if !b.allow() {
+			t.Fatalf("allow() = false before %d failures", i)
+		}
+		b.record(true)
+	}
+	if got := b.getState(); got != CircuitBreakerOpen {
+		t.Fatalf("state = %v after the failure threshold, want %v", got, CircuitBreakerOpen)
+	}
+	if b.allow() {
+		t.Error("allow() = true while open")
+	}
+
+	now = now.Add(time.Minute)
+	if !b.allow() {
+		t.Fatal("allow() = false after the open duration")
+	}
+	if got := b.getState(); got != CircuitBreakerHalfOpen {
+		t.Fatalf("state = %v after the open duration, want %v", got, CircuitBreakerHalfOpen)
+	}
+	if b.allow() {
+		t.Error("allow() = true during the trial")
+	}
+	b.record(true)
+	if got := b.getState(); got != CircuitBreakerOpen {
+		t.Fatalf("state = %v after a failed trial, want %v", got, CircuitBreakerOpen)
+	}
+
+	now = now.Add(time.Minute)
+	if !b.allow() {
+		t.Fatal("allow() = false after the open duration")
+	}
+	b.record(false)
+	if got := b.getState(); got != CircuitBreakerClosed {
+		t.Fatalf("state = %v after a successful trial, want %v", got, CircuitBreakerClosed)
+	}
+	breakers.release(b)
+	if _, ok := breakers.breakers[circuitBreakerKey{destination: "http://destination"}]; ok {
+		t.Error("idle circuit breaker not forgotten")
+	}
+}
+
+func TestCircuitBreakerInUseNotForgotten(t *testing.T) {
+	breakers := newCircuitBreakers()
+	config := kncloudevents.CircuitBreakerConfig{FailureThreshold: 2, OpenDuration: time.Hour}
+
+	// Two concurrent deliveries, the first one succeeds while the second one is in flight.
+	first := breakers.get(attributes.SubscriberReference{}, "http://destination", config)
+	second := breakers.get(attributes.SubscriberReference{}, "http://destination", config)
+	first.record(false)
+	breakers.release(first)
+	if _, ok := breakers.breakers[circuitBreakerKey{destination: "http://destination"}]; !ok {
+		t.Fatal("circuit breaker forgotten while in use")
+	}
+
+	// The failure of the second delivery is counted by the circuit breaker of the next deliveries.
+	second.record(true)
+	breakers.release(second)
+	third := breakers.get(attributes.SubscriberReference{}, "http://destination", config)
+	if third != second {
+		t.Fatal("circuit breaker with failures forgotten")
+	}
+	third.record(true)
+	breakers.release(third)
+	if got := third.getState(); got != CircuitBreakerOpen {
+		t.Errorf("state = %v after the failure threshold, want %v", got, CircuitBreakerOpen)
+	}
+}
+
+func TestCircuitBreakersPerSubscriber(t *testing.T) {
+	breakers := newCircuitBreakers()
+	strict := kncloudevents.CircuitBreakerConfig{FailureThreshold: 1, OpenDuration: time.Hour}
+	lenient := kncloudevents.CircuitBreakerConfig{FailureThreshold: 10, OpenDuration: time.Hour}
+
+	a := breakers.get(attributes.SubscriberReference{Namespace: "ns", Name: "a", UID: "a"}, "http://destination", strict)
+	b := breakers.get(attributes.SubscriberReference{Namespace: "ns", Name: "b", UID: "b"}, "http://destination", lenient)
+	if a == b {
+		t.Fatal("Subscribers share the circuit breaker of the destination")
+	}
+	a.record(true)
+	b.record(true)
+	if got := a.getState(); got != CircuitBreakerOpen {
+		t.Errorf("state of a = %v, want %v", got, CircuitBreakerOpen)
+	}
+	// The config of b doesn't override the config of a, and b isn't opened by a.
+	if got := b.getState(); got != CircuitBreakerClosed {
+		t.Errorf("state of b = %v, want %v", got, CircuitBreakerClosed)
+	}
+	if got := breakers.get(attributes.SubscriberReference{Namespace: "ns", Name: "a", UID: "a"}, "http://destination", strict); got != a {
+		t.Error("get() returned another circuit breaker for the same subscriber and destination")
+	}
+}
+
+func TestIsCircuitBreakerFailure(t *testing.T) {
+	err := errors.New("failed")
+	for _, tc := range []struct {
+		name string
+		info *DispatchExecutionInfo
+		err  error
+		want bool
+	}{{
+		name: "success",
+		info: &DispatchExecutionInfo{ResponseCode: http.StatusAccepted},
+	}, {
+		name: "connection error",
+		err:  err,
+		want: true,
+	}, {
+		name: "server error",
+		info: &DispatchExecutionInfo{ResponseCode: http.StatusBadGateway},
+		err:  err,
+		want: true,
+	}, {
+		name: "client error",
+		info: &DispatchExecutionInfo{ResponseCode: http.StatusBadRequest},
+		err:  err,
+	}, {
+		name: "throttled",
+		info: &DispatchExecutionInfo{ResponseCode: http.StatusTooManyRequests},
+		err:  err,
+		want: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isCircuitBreakerFailure(tc.info, tc.err); got != tc.want {
+				t.Errorf("isCircuitBreakerFailure() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDispatchMessageCircuitBreaker(t *testing.T) {
+	var destinationRequests, deadLetterRequests atomic.Int32
+	destination := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		destinationRequests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer destination.Close()
+	deadLetter := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deadLetterRequests.Add(1)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer deadLetter.Close()
+
+	md := NewMessageDispatcher(zap.NewNop())
+	retryConfig := &kncloudevents.RetryConfig{
+		CheckRetry: kncloudevents.SelectiveRetry,
+		CircuitBreaker: &kncloudevents.CircuitBreakerConfig{
+			FailureThreshold: 2,
+			OpenDuration:     time.Hour,
+		},
+	}
+
+	for i := 0; i < 4; i++ {
+		e := event.New()
+		e.SetID("id")
+		e.SetType("dev.knative.test")
+		e.SetSource("/test")
+		message := binding.ToMessage(&e)
+		info, err := md.DispatchMessageWithRetries(context.Background(), message, nil,
+			getOnlyDomainURL(t, true, destination.URL), nil, getOnlyDomainURL(t, true, deadLetter.URL), retryConfig)
+		if err != nil {
+			t.Fatalf("DispatchMessageWithRetries() = %v", err)
+		}
+		if info.ResponseCode != http.StatusAccepted {
+			t.Errorf("Unexpected response code %d", info.ResponseCode)
+		}
+	}
+
+	if got := destinationRequests.Load(); got != 2 {
+		t.Errorf("destination requests = %d, want 2", got)
+	}
+	if got := deadLetterRequests.Load(); got != 4 {
+		t.Errorf("dead letter sink requests = %d, want 4", got)
+	}
+}
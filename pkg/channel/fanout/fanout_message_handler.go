@@ -24,29 +24,55 @@ package fanout
 import (
 	"context"
 	"errors"
+	"fmt"
 	nethttp "net/http"
 	"net/url"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/cloudevents/sdk-go/v2/binding/buffering"
+	"github.com/cloudevents/sdk-go/v2/event"
 	"go.opencensus.io/trace"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
 	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/channel"
+	"knative.dev/eventing/pkg/channel/attributes"
+	"knative.dev/eventing/pkg/channel/retention"
+	"knative.dev/eventing/pkg/channel/wal"
+	"knative.dev/eventing/pkg/eventfilter"
+	"knative.dev/eventing/pkg/eventfilter/subscriptionsapi"
 	"knative.dev/eventing/pkg/kncloudevents"
 )
 
 const (
 	defaultTimeout = 15 * time.Minute
+
+	// throttledRetryAfter is the delay after which a sender should retry an event rejected
+	// because too many events are in flight.
+	throttledRetryAfter = time.Second
+
+	// replayWaitInterval is the interval at which the replay of the write-ahead log checks for an
+	// in-flight slot.
+	replayWaitInterval = 100 * time.Millisecond
 )
 
 type Subscription struct {
+	// UID is the UID of the Subscription, identifying the subscriber in the status.
+	UID types.UID
+	// Namespace and Name are the namespace and the name of the Subscription, if known.
+	Namespace   string
+	Name        string
 	Subscriber  *url.URL
 	Reply       *url.URL
 	DeadLetter  *url.URL
 	RetryConfig *kncloudevents.RetryConfig
+	// Filters are the filters the events must pass to be sent to the subscriber.
+	Filters []eventingduckv1.SubscriptionsAPIFilter
+	// Filter is the materialized Filters, it is nil when there are no filters.
+	Filter eventfilter.Filter
 }
 
 // Config for a fanout.MessageHandler.
@@ -55,8 +81,28 @@ type Config struct {
 	// AsyncHandler controls whether the Subscriptions are called synchronous or asynchronously.
 	// It is expected to be false when used as a sidecar.
 	AsyncHandler bool `json:"asyncHandler,omitempty"`
+	// WriteAheadLog, when set, persists the messages accepted by an AsyncHandler until they are
+	// dispatched, so that they can be replayed after a restart.
+	WriteAheadLog *wal.Log `json:"-"`
+	// SubscriberStatusChanged, when set, is called when the readiness of a subscriber changes.
+	SubscriberStatusChanged func() `json:"-"`
+	// DispatchLimits bounds the events dispatched concurrently.
+	DispatchLimits DispatchLimits `json:"-"`
+	// Retention bounds the recent events retained to be replayed to a subscriber.
+	Retention retention.Limits `json:"-"`
 }
 
+var (
+	// ErrRetentionDisabled is returned when replaying the events of a handler not retaining them.
+	ErrRetentionDisabled = errors.New("the channel doesn't retain the events")
+	// ErrUnknownSubscriber is returned when replaying the events to a subscriber not subscribed
+	// to the channel.
+	ErrUnknownSubscriber = errors.New("unknown subscriber")
+	// ErrReplayThrottled is returned when replaying the events is interrupted because too many
+	// events are in flight.
+	ErrReplayThrottled = errors.New("too many events in flight")
+)
+
 // MessageHandler is an http.Handler but has methods for managing
 // the fanout Subscriptions. Get/Set methods are synchronized, and
 // GetSubscriptions returns a copy of the Subscriptions, so you can
@@ -73,8 +119,22 @@ type FanoutMessageHandler struct {
 	// It is expected to be false when used as a sidecar.
 	asyncHandler bool
 
+	writeAheadLog *wal.Log
+
 	subscriptionsMutex sync.RWMutex
 	subscriptions      []Subscription
+	// queues are the queues of the subscriptions, in the same order.
+	queues []*subscriberQueue
+	limits DispatchLimits
+
+	// inFlight is the number of events accepted by an AsyncHandler, or being replayed, and not
+	// yet dispatched.
+	inFlight atomic.Int64
+
+	// retention retains the recent events, it is nil when the retention is disabled.
+	retention *retention.Buffer
+
+	subscriberStatusChanged func()
 
 	receiver   *channel.MessageReceiver
 	dispatcher channel.MessageDispatcher
@@ -91,14 +151,21 @@ type FanoutMessageHandler struct {
 
 func NewFanoutMessageHandler(logger *zap.Logger, messageDispatcher channel.MessageDispatcher, config Config, reporter channel.StatsReporter) (*FanoutMessageHandler, error) {
 	handler := &FanoutMessageHandler{
-		logger:       logger,
-		dispatcher:   messageDispatcher,
-		timeout:      defaultTimeout,
-		reporter:     reporter,
-		asyncHandler: config.AsyncHandler,
-	}
-	handler.subscriptions = make([]Subscription, len(config.Subscriptions))
-	copy(handler.subscriptions, config.Subscriptions)
+		logger:                  logger,
+		dispatcher:              messageDispatcher,
+		timeout:                 defaultTimeout,
+		reporter:                reporter,
+		asyncHandler:            config.AsyncHandler,
+		subscriberStatusChanged: config.SubscriberStatusChanged,
+		limits:                  config.DispatchLimits,
+	}
+	if config.AsyncHandler {
+		handler.writeAheadLog = config.WriteAheadLog
+	}
+	if config.Retention.Enabled() {
+		handler.retention = retention.NewBuffer(config.Retention)
+	}
+	handler.SetSubscriptions(context.Background(), config.Subscriptions)
 	// The receiver function needs to point back at the handler itself, so set it up after
 	// initialization.
 	receiver, err := channel.NewMessageReceiver(createMessageReceiverFunction(handler), logger, reporter)
@@ -136,15 +203,197 @@ func SubscriberSpecToFanoutConfig(sub eventingduckv1.SubscriberSpec) (*Subscript
 		}
 	}
 
-	return &Subscription{Subscriber: destination, Reply: reply, DeadLetter: deadLetter, RetryConfig: retryConfig}, nil
+	var filter eventfilter.Filter
+	if len(sub.Filters) > 0 {
+		filter = subscriptionsapi.CreateSubscriptionsAPIFilters(context.Background(), sub.Filters)
+	}
+
+	var name string
+	if sub.Name != nil {
+		name = *sub.Name
+	}
+
+	return &Subscription{
+		UID:         sub.UID,
+		Name:        name,
+		Subscriber:  destination,
+		Reply:       reply,
+		DeadLetter:  deadLetter,
+		RetryConfig: retryConfig,
+		Filters:     sub.Filters,
+		Filter:      filter,
+	}, nil
 }
 
+// SetSubscriptions sets the Subscriptions, the queues and the status of the subscribers that
+// are kept are preserved.
 func (f *FanoutMessageHandler) SetSubscriptions(ctx context.Context, subs []Subscription) {
 	f.subscriptionsMutex.Lock()
 	defer f.subscriptionsMutex.Unlock()
+	existing := make(map[subscriptionKey]*subscriberQueue, len(f.queues))
+	for i, q := range f.queues {
+		existing[keyForSubscription(f.subscriptions[i])] = q
+	}
 	s := make([]Subscription, len(subs))
 	copy(s, subs)
+	queues := make([]*subscriberQueue, len(subs))
+	for i, sub := range s {
+		if q, ok := existing[keyForSubscription(sub)]; ok {
+			q.setSubscription(sub)
+			queues[i] = q
+		} else {
+			limits := f.limits.withDefaults()
+			queues[i] = newSubscriberQueue(sub, limits.SubscriberQueueSize, limits.SubscriberWorkers)
+		}
+	}
 	f.subscriptions = s
+	f.queues = queues
+}
+
+// SetDispatchLimits sets the limits of the dispatch, the queues of the subscribers are resized
+// if needed.
+func (f *FanoutMessageHandler) SetDispatchLimits(limits DispatchLimits) {
+	f.subscriptionsMutex.Lock()
+	defer f.subscriptionsMutex.Unlock()
+	f.limits = limits
+	limits = limits.withDefaults()
+	for i, q := range f.queues {
+		if !q.fits(limits) {
+			f.queues[i] = q.resize(limits)
+		}
+	}
+}
+
+// GetDispatchLimits returns the limits of the dispatch.
+func (f *FanoutMessageHandler) GetDispatchLimits() DispatchLimits {
+	f.subscriptionsMutex.RLock()
+	defer f.subscriptionsMutex.RUnlock()
+	return f.limits
+}
+
+// SetRetentionLimits sets the limits of the events retained, the retained events that fit in the
+// new limits are kept.
+func (f *FanoutMessageHandler) SetRetentionLimits(limits retention.Limits) {
+	f.subscriptionsMutex.Lock()
+	defer f.subscriptionsMutex.Unlock()
+	switch {
+	case !limits.Enabled():
+		f.retention = nil
+	case f.retention == nil:
+		f.retention = retention.NewBuffer(limits)
+	default:
+		f.retention.SetLimits(limits)
+	}
+}
+
+// GetRetentionLimits returns the limits of the events retained.
+func (f *FanoutMessageHandler) GetRetentionLimits() retention.Limits {
+	f.subscriptionsMutex.RLock()
+	defer f.subscriptionsMutex.RUnlock()
+	if f.retention == nil {
+		return retention.Limits{}
+	}
+	return f.retention.Limits()
+}
+
+func (f *FanoutMessageHandler) getRetention() *retention.Buffer {
+	f.subscriptionsMutex.RLock()
+	defer f.subscriptionsMutex.RUnlock()
+	return f.retention
+}
+
+// retain adds the message to the retained events, if the retention is enabled.
+func (f *FanoutMessageHandler) retain(ctx context.Context, message binding.Message, additionalHeaders nethttp.Header) {
+	buffer := f.getRetention()
+	if buffer == nil {
+		return
+	}
+	event, err := binding.ToEvent(ctx, message)
+	if err != nil {
+		f.logger.Warn("Failed to retain the event", zap.Error(err))
+		return
+	}
+	buffer.Add(event, additionalHeaders)
+}
+
+// ReplayTo dispatches the retained events within the range to the subscriber of the Subscription
+// with the given UID only, and returns the number of events replayed. The events not passing the
+// filters of the Subscription are skipped. The events are dispatched asynchronously, the failures
+// are logged. The replay stops with ErrReplayThrottled when too many events are in flight.
+func (f *FanoutMessageHandler) ReplayTo(ctx context.Context, uid types.UID, r retention.Range) (int, error) {
+	buffer := f.getRetention()
+	if buffer == nil {
+		return 0, ErrRetentionDisabled
+	}
+	var q *subscriberQueue
+	var filter eventfilter.Filter
+	f.subscriptionsMutex.RLock()
+	for i, sub := range f.subscriptions {
+		if sub.UID == uid {
+			q = f.queues[i]
+			filter = sub.Filter
+		}
+	}
+	f.subscriptionsMutex.RUnlock()
+	if q == nil {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownSubscriber, uid)
+	}
+
+	records := buffer.Records(r)
+	if filter != nil {
+		passed := records[:0:0]
+		for _, rec := range records {
+			if filter.Filter(ctx, *rec.Event) != eventfilter.FailFilter {
+				passed = append(passed, rec)
+			}
+		}
+		records = passed
+	}
+	f.logger.Info("Replaying the retained events", zap.Int("count", len(records)), zap.String("subscription", string(uid)))
+	for i, rec := range records {
+		if !f.acquire() {
+			return i, fmt.Errorf("%w, the replay stopped at the offset %d", ErrReplayThrottled, rec.Offset)
+		}
+		offset := rec.Offset
+		job := dispatchJob{
+			ctx:     context.Background(),
+			message: binding.ToMessage(rec.Event),
+			headers: rec.Headers,
+			done: func(result DispatchResult) {
+				if result.err != nil {
+					f.logger.Warn("Failed to replay an event", zap.Uint64("offset", offset), zap.Error(result.err))
+				}
+				f.release()
+			},
+		}
+		if !q.enqueue(job, f.dispatchJob(q)) {
+			job.done(f.rejectJob(job, q))
+		}
+	}
+	return len(records), nil
+}
+
+// acquire reserves an in-flight slot for an event, and returns false if too many events are
+// in flight.
+func (f *FanoutMessageHandler) acquire() bool {
+	maxInFlight := int64(f.GetDispatchLimits().MaxInFlight)
+	if f.inFlight.Add(1) > maxInFlight && maxInFlight > 0 {
+		f.inFlight.Add(-1)
+		return false
+	}
+	return true
+}
+
+// acquireWait reserves an in-flight slot for an event, waiting for a slot to be released if too
+// many events are in flight.
+func (f *FanoutMessageHandler) acquireWait() {
+	for !f.acquire() {
+		time.Sleep(replayWaitInterval)
+	}
+}
+
+func (f *FanoutMessageHandler) release() {
+	f.inFlight.Add(-1)
 }
 
 func (f *FanoutMessageHandler) GetSubscriptions(ctx context.Context) []Subscription {
@@ -155,10 +404,32 @@ func (f *FanoutMessageHandler) GetSubscriptions(ctx context.Context) []Subscript
 	return ret
 }
 
+func (f *FanoutMessageHandler) getQueues() []*subscriberQueue {
+	f.subscriptionsMutex.RLock()
+	defer f.subscriptionsMutex.RUnlock()
+	ret := make([]*subscriberQueue, len(f.queues))
+	copy(ret, f.queues)
+	return ret
+}
+
+// GetSubscriberStatuses returns the status of the dispatch of the events to the subscribers,
+// keyed by Subscription UID. Subscriptions without UID are omitted.
+func (f *FanoutMessageHandler) GetSubscriberStatuses() map[types.UID]SubscriberStatus {
+	f.subscriptionsMutex.RLock()
+	defer f.subscriptionsMutex.RUnlock()
+	statuses := make(map[types.UID]SubscriberStatus, len(f.subscriptions))
+	for i, sub := range f.subscriptions {
+		if sub.UID != "" {
+			statuses[sub.UID] = f.queues[i].getStatus()
+		}
+	}
+	return statuses
+}
+
 func createMessageReceiverFunction(f *FanoutMessageHandler) func(context.Context, channel.ChannelReference, binding.Message, []binding.Transformer, nethttp.Header) error {
 	if f.asyncHandler {
 		return func(ctx context.Context, ref channel.ChannelReference, message binding.Message, transformers []binding.Transformer, additionalHeaders nethttp.Header) error {
-			subs := f.GetSubscriptions(ctx)
+			subs := f.getQueues()
 
 			if len(subs) == 0 {
 				// Nothing to do here, finish the message and return
@@ -166,6 +437,12 @@ func createMessageReceiverFunction(f *FanoutMessageHandler) func(context.Context
 				return nil
 			}
 
+			// Bound the events waiting to be dispatched, the sender retries the rejected events later.
+			if !f.acquire() {
+				_ = message.Finish(nil)
+				return &channel.ThrottledError{Channel: ref, RetryAfter: throttledRetryAfter}
+			}
+
 			parentSpan := trace.FromContext(ctx)
 			te := kncloudevents.TypeExtractorTransformer("")
 			transformers = append(transformers, &te)
@@ -173,12 +450,28 @@ func createMessageReceiverFunction(f *FanoutMessageHandler) func(context.Context
 			// Because the message could be closed before the buffering happens
 			bufferedMessage, err := buffering.CopyMessage(ctx, message, transformers...)
 			if err != nil {
+				f.release()
 				return err
 			}
 
 			reportArgs := channel.ReportArgs{}
 			reportArgs.EventType = string(te)
 			reportArgs.Ns = ref.Namespace
+			f.retain(ctx, bufferedMessage, additionalHeaders)
+
+			// Persist the message before acknowledging it.
+			var recordID uint64
+			if f.writeAheadLog != nil {
+				event, err := binding.ToEvent(ctx, bufferedMessage)
+				if err != nil {
+					f.release()
+					return err
+				}
+				if recordID, err = f.writeAheadLog.Append(event, additionalHeaders); err != nil {
+					f.release()
+					return err
+				}
+			}
 
 			// We don't need the original message anymore
 			_ = message.Finish(nil)
@@ -188,12 +481,14 @@ func createMessageReceiverFunction(f *FanoutMessageHandler) func(context.Context
 				// Any returned error is already logged in f.dispatch().
 				dispatchResultForFanout := f.dispatch(ctx, subs, m, h)
 				_ = ParseDispatchResultAndReportMetrics(dispatchResultForFanout, *r, *args)
+				f.ack(recordID)
+				f.release()
 			}(bufferedMessage, additionalHeaders, parentSpan, &f.reporter, &reportArgs)
 			return nil
 		}
 	}
 	return func(ctx context.Context, ref channel.ChannelReference, message binding.Message, transformers []binding.Transformer, additionalHeaders nethttp.Header) error {
-		subs := f.GetSubscriptions(ctx)
+		subs := f.getQueues()
 		if len(subs) == 0 {
 			// Nothing to do here, finish the message and return
 			_ = message.Finish(nil)
@@ -213,11 +508,58 @@ func createMessageReceiverFunction(f *FanoutMessageHandler) func(context.Context
 		reportArgs := channel.ReportArgs{}
 		reportArgs.EventType = string(te)
 		reportArgs.Ns = ref.Namespace
+		f.retain(ctx, bufferedMessage, additionalHeaders)
 		dispatchResultForFanout := f.dispatch(ctx, subs, bufferedMessage, additionalHeaders)
 		return ParseDispatchResultAndReportMetrics(dispatchResultForFanout, f.reporter, reportArgs)
 	}
 }
 
+// Replay dispatches the messages of the write-ahead log that were accepted but not dispatched,
+// for example because the dispatcher restarted, to the current Subscriptions. The messages are
+// dispatched in the background, within the MaxInFlight limit.
+func (f *FanoutMessageHandler) Replay(ctx context.Context, ref channel.ChannelReference) {
+	if f.writeAheadLog == nil {
+		return
+	}
+	records := f.writeAheadLog.Pending()
+	if len(records) == 0 {
+		return
+	}
+	f.logger.Info("Replaying the write-ahead log", zap.Int("count", len(records)), zap.String("path", f.writeAheadLog.Path()))
+
+	go func() {
+		for _, r := range records {
+			subs := f.getQueues()
+			if len(subs) == 0 {
+				f.ack(r.ID)
+				continue
+			}
+			reportArgs := channel.ReportArgs{
+				Ns:        ref.Namespace,
+				EventType: r.Event.Type(),
+			}
+			f.acquireWait()
+			go func(r wal.Record, args channel.ReportArgs) {
+				m := binding.ToMessage(r.Event)
+				dispatchResultForFanout := f.dispatch(context.Background(), subs, m, r.Headers)
+				_ = ParseDispatchResultAndReportMetrics(dispatchResultForFanout, f.reporter, args)
+				f.ack(r.ID)
+				f.release()
+			}(r, reportArgs)
+		}
+	}()
+}
+
+// ack acknowledges a dispatched message in the write-ahead log.
+func (f *FanoutMessageHandler) ack(recordID uint64) {
+	if f.writeAheadLog == nil || recordID == 0 {
+		return
+	}
+	if err := f.writeAheadLog.Ack(recordID); err != nil {
+		f.logger.Warn("Failed to acknowledge the message in the write-ahead log", zap.Error(err))
+	}
+}
+
 func (f *FanoutMessageHandler) ServeHTTP(response nethttp.ResponseWriter, request *nethttp.Request) {
 	f.receiver.ServeHTTP(response, request)
 }
@@ -240,18 +582,49 @@ func ParseDispatchResultAndReportMetrics(result DispatchResult, reporter channel
 	return err
 }
 
-// dispatch takes the event, fans it out to each subscription in subs. If all the fanned out
-// events return successfully, then return nil. Else, return an error.
-func (f *FanoutMessageHandler) dispatch(ctx context.Context, subs []Subscription, bufferedMessage binding.Message, additionalHeaders nethttp.Header) DispatchResult {
+// dispatch takes the event, and fans it out to the queue of each subscription in subs. A failure only
+// affects the subscription that failed, so dispatch returns an error only when the event couldn't be
+// dispatched to any subscription, else it returns nil.
+func (f *FanoutMessageHandler) dispatch(ctx context.Context, subs []*subscriberQueue, bufferedMessage binding.Message, additionalHeaders nethttp.Header) DispatchResult {
 	// Bind the lifecycle of the buffered message to the number of subs
 	bufferedMessage = buffering.WithAcksBeforeFinish(bufferedMessage, len(subs))
 
 	errorCh := make(chan DispatchResult, len(subs))
-	for _, sub := range subs {
-		go func(s Subscription) {
-			dispatchedResultPerSub, err := f.makeFanoutRequest(ctx, bufferedMessage, additionalHeaders, s)
-			errorCh <- DispatchResult{err: err, info: dispatchedResultPerSub}
-		}(sub)
+	// The message is converted to an event only when a subscription has filters, the events
+	// that can't be converted aren't filtered.
+	var filtered *event.Event
+	passes := func(sub Subscription) bool {
+		if sub.Filter == nil {
+			return true
+		}
+		if filtered == nil {
+			e, err := binding.ToEvent(ctx, bufferedMessage)
+			if err != nil {
+				f.logger.Warn("Failed to convert the message to an event to filter it", zap.Error(err))
+				return true
+			}
+			filtered = e
+		}
+		return sub.Filter.Filter(ctx, *filtered) != eventfilter.FailFilter
+	}
+	for _, q := range subs {
+		// The events not passing the filters are acknowledged without being sent to the subscriber.
+		if !passes(q.subscription()) {
+			_ = bufferedMessage.Finish(nil)
+			errorCh <- DispatchResult{}
+			continue
+		}
+		job := dispatchJob{
+			ctx:     ctx,
+			message: bufferedMessage,
+			headers: additionalHeaders,
+			done: func(r DispatchResult) {
+				errorCh <- r
+			},
+		}
+		if !q.enqueue(job, f.dispatchJob(q)) {
+			errorCh <- f.rejectJob(job, q)
+		}
 	}
 
 	var totalDispatchTimeForFanout time.Duration = channel.NoDuration
@@ -261,6 +634,8 @@ func (f *FanoutMessageHandler) dispatch(ctx context.Context, subs []Subscription
 			ResponseCode: channel.NoResponse,
 		},
 	}
+	succeeded := false
+	timeout := time.After(f.timeout)
 	for range subs {
 		select {
 		case dispatchResult := <-errorCh:
@@ -278,21 +653,64 @@ func (f *FanoutMessageHandler) dispatch(ctx context.Context, subs []Subscription
 			if dispatchResult.err != nil {
 				f.logger.Error("Fanout had an error", zap.Error(dispatchResult.err))
 				dispatchResultForFanout.err = dispatchResult.err
-				return dispatchResultForFanout
+			} else {
+				succeeded = true
 			}
-		case <-time.After(f.timeout):
+		case <-timeout:
 			f.logger.Error("Fanout timed out")
-			dispatchResultForFanout.err = errors.New("fanout timed out")
+			if !succeeded {
+				dispatchResultForFanout.err = errors.New("fanout timed out")
+				return dispatchResultForFanout
+			}
+			dispatchResultForFanout.err = nil
 			return dispatchResultForFanout
 		}
 	}
-	// All Subscriptions returned err = nil.
+	if succeeded {
+		dispatchResultForFanout.err = nil
+	}
 	return dispatchResultForFanout
 }
 
+// dispatchJob returns the function dispatching the jobs of the queue, and updating the status of
+// the subscriber.
+func (f *FanoutMessageHandler) dispatchJob(q *subscriberQueue) func(dispatchJob, Subscription) DispatchResult {
+	return func(job dispatchJob, sub Subscription) DispatchResult {
+		info, err := f.makeFanoutRequest(job.ctx, job.message, job.headers, sub)
+		f.setSubscriberResult(q, err)
+		return DispatchResult{err: err, info: info}
+	}
+}
+
+// rejectJob handles an event that doesn't fit in the queue of the subscriber. The event is sent to
+// the dead letter sink of the subscription, if any.
+func (f *FanoutMessageHandler) rejectJob(job dispatchJob, q *subscriberQueue) DispatchResult {
+	sub := q.subscription()
+	f.logger.Warn("Subscriber queue full, rejecting the event", zap.Any("subscriber", sub.Subscriber))
+	f.setSubscriberResult(q, errSubscriberQueueFull)
+	if sub.DeadLetter == nil {
+		_ = job.message.Finish(errSubscriberQueueFull)
+		return DispatchResult{err: errSubscriberQueueFull}
+	}
+	info, err := f.dispatcher.DispatchMessage(job.ctx, job.message, job.headers, sub.DeadLetter, nil, nil)
+	if err != nil {
+		return DispatchResult{err: fmt.Errorf("%w, and sending it to the dead letter sink failed: %v", errSubscriberQueueFull, err), info: info}
+	}
+	return DispatchResult{info: info}
+}
+
+func (f *FanoutMessageHandler) setSubscriberResult(q *subscriberQueue, err error) {
+	if q.setResult(err) && f.subscriberStatusChanged != nil {
+		f.subscriberStatusChanged()
+	}
+}
+
 // makeFanoutRequest sends the request to exactly one subscription. It handles both the `call` and
 // the `sink` portions of the subscription.
 func (f *FanoutMessageHandler) makeFanoutRequest(ctx context.Context, message binding.Message, additionalHeaders nethttp.Header, sub Subscription) (*channel.DispatchExecutionInfo, error) {
+	if sub.UID != "" {
+		ctx = channel.ContextWithSubscriberReference(ctx, attributes.SubscriberReference{Kind: "Subscription", Namespace: sub.Namespace, Name: sub.Name, UID: sub.UID})
+	}
 	return f.dispatcher.DispatchMessageWithRetries(
 		ctx,
 		message,
@@ -21,6 +21,9 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"sort"
 	"sync"
 	"testing"
 	"time"
@@ -37,9 +40,13 @@ import (
 	"go.opencensus.io/trace"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"knative.dev/pkg/apis"
 
 	"knative.dev/eventing/pkg/channel"
+	"knative.dev/eventing/pkg/channel/retention"
+	"knative.dev/eventing/pkg/channel/wal"
 )
 
 // Domains used in subscriptions, which will be replaced by the real domains of the started HTTP
@@ -53,7 +60,10 @@ func TestSubscriberSpecToFanoutConfig(t *testing.T) {
 	three := int32(3)
 	linear := eventingduckv1.BackoffPolicyLinear
 	delay := "PT1S"
+	name := "mysubscription"
 	spec := &eventingduckv1.SubscriberSpec{
+		UID:           "abc",
+		Name:          &name,
 		SubscriberURI: apis.HTTP("subscriber.example.com"),
 		ReplyURI:      apis.HTTP("reply.example.com"),
 		Delivery: &eventingduckv1.DeliverySpec{
@@ -70,8 +80,13 @@ func TestSubscriberSpecToFanoutConfig(t *testing.T) {
 			BackoffPolicy: &linear,
 			BackoffDelay:  &delay,
 		},
+		Filters: []eventingduckv1.SubscriptionsAPIFilter{{
+			Exact: map[string]string{"type": "com.example.someevent"},
+		}},
 	}
 	want := Subscription{
+		UID:        "abc",
+		Name:       name,
 		Subscriber: apis.HTTP("subscriber.example.com").URL(),
 		Reply:      apis.HTTP("reply.example.com").URL(),
 		DeadLetter: apis.HTTP("dls.example.com").URL(),
@@ -80,14 +95,20 @@ func TestSubscriberSpecToFanoutConfig(t *testing.T) {
 			BackoffPolicy: &linear,
 			BackoffDelay:  &delay,
 		},
+		Filters: spec.Filters,
 	}
 	got, err := SubscriberSpecToFanoutConfig(*spec)
 	if err != nil {
 		t.Error("Failed to convert using SubscriberSpecToFanoutConfig:", err)
 	}
-	if diff := cmp.Diff(&want, got, cmpopts.IgnoreFields(kncloudevents.RetryConfig{}, "Backoff", "CheckRetry")); diff != "" {
+	if diff := cmp.Diff(&want, got,
+		cmpopts.IgnoreFields(kncloudevents.RetryConfig{}, "Backoff", "CheckRetry"),
+		cmpopts.IgnoreFields(Subscription{}, "Filter")); diff != "" {
 		t.Error("Unexpected diff", diff)
 	}
+	if got.Filter == nil {
+		t.Error("The filters weren't materialized")
+	}
 }
 
 func TestGetSetSubscriptions(t *testing.T) {
@@ -242,11 +263,12 @@ func TestFanoutMessageHandler_ServeHTTP(t *testing.T) {
 					Reply:      replaceReplier,
 				},
 			},
-			subscriber:          callableSucceed,
-			replier:             (&succeedOnce{}).handler,
-			subscriberReqs:      2,
-			replierReqs:         2,
-			expectedStatus:      http.StatusInternalServerError,
+			subscriber:     callableSucceed,
+			replier:        (&succeedOnce{}).handler,
+			subscriberReqs: 2,
+			replierReqs:    2,
+			// A failure only affects the subscription that failed.
+			expectedStatus:      http.StatusAccepted,
 			asyncExpectedStatus: http.StatusAccepted,
 		},
 		"all subs succeed": {
@@ -284,6 +306,490 @@ func TestFanoutMessageHandler_ServeHTTP(t *testing.T) {
 	}
 }
 
+func TestFanoutMessageHandler_WriteAheadLog(t *testing.T) {
+	received := make(chan string, 2)
+	subscriber := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Get("ce-id")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer subscriber.Close()
+
+	logger := zap.NewNop()
+	path := filepath.Join(t.TempDir(), "channel.wal")
+	log, err := wal.Open(path)
+	if err != nil {
+		t.Fatal("wal.Open() =", err)
+	}
+	defer log.Close()
+
+	// An event accepted before a restart, but not dispatched.
+	pending := makeCloudEvent()
+	pending.SetID("pending")
+	if _, err := log.Append(&pending, nil); err != nil {
+		t.Fatal("Append() =", err)
+	}
+
+	h, err := NewFanoutMessageHandler(
+		logger,
+		channel.NewMessageDispatcher(logger),
+		Config{
+			Subscriptions: []Subscription{{Subscriber: apis.HTTP(subscriber.URL[7:]).URL()}},
+			AsyncHandler:  true,
+			WriteAheadLog: log,
+		},
+		channel.NewStatsReporter("testcontainer", "testpod"),
+	)
+	if err != nil {
+		t.Fatal("NewHandler failed =", err)
+	}
+
+	h.Replay(context.Background(), channel.ChannelReference{Namespace: "channelnamespace", Name: "channelname"})
+	if got := <-received; got != "pending" {
+		t.Errorf("Unexpected replayed event %q", got)
+	}
+
+	event := makeCloudEvent()
+	req := httptest.NewRequest(http.MethodPost, "http://channelname.channelnamespace/", nil)
+	if err := bindingshttp.WriteRequest(context.Background(), binding.ToMessage(&event), req); err != nil {
+		t.Fatal("WriteRequest =", err)
+	}
+	resp := httptest.NewRecorder()
+	h.ServeHTTP(resp, req)
+	if resp.Code != http.StatusAccepted {
+		t.Errorf("Unexpected status code. Expected %v, Actual %v", http.StatusAccepted, resp.Code)
+	}
+	if got := <-received; got != event.ID() {
+		t.Errorf("Unexpected dispatched event %q", got)
+	}
+
+	// All the events are eventually acknowledged in the log.
+	err = wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return len(log.Pending()) == 0, nil
+	})
+	if err != nil {
+		t.Errorf("Events still pending in the write-ahead log: %v", log.Pending())
+	}
+}
+
+func TestFanoutMessageHandler_SubscriberIsolation(t *testing.T) {
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer slow.Close()
+	defer close(release)
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer failing.Close()
+	succeeding := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer succeeding.Close()
+
+	statusChanged := atomic.NewInt32(0)
+	logger := zap.NewNop()
+	h, err := NewFanoutMessageHandler(
+		logger,
+		channel.NewMessageDispatcher(logger),
+		Config{
+			Subscriptions: []Subscription{
+				{UID: "slow", Subscriber: apis.HTTP(slow.URL[7:]).URL()},
+				{UID: "failing", Subscriber: apis.HTTP(failing.URL[7:]).URL()},
+				{UID: "succeeding", Subscriber: apis.HTTP(succeeding.URL[7:]).URL()},
+			},
+			SubscriberStatusChanged: func() {
+				statusChanged.Inc()
+			},
+		},
+		channel.NewStatsReporter("testcontainer", "testpod"),
+	)
+	if err != nil {
+		t.Fatal("NewHandler failed =", err)
+	}
+	h.timeout = 100 * time.Millisecond
+
+	// The failing subscriber isn't ready once subscriberFailureThreshold events failed.
+	for i := 0; i < subscriberFailureThreshold; i++ {
+		event := makeCloudEvent()
+		req := httptest.NewRequest(http.MethodPost, "http://channelname.channelnamespace/", nil)
+		if err := bindingshttp.WriteRequest(context.Background(), binding.ToMessage(&event), req); err != nil {
+			t.Fatal("WriteRequest =", err)
+		}
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, req)
+
+		// Neither the slow nor the failing subscriber fail the fanout.
+		if resp.Code != http.StatusAccepted {
+			t.Errorf("Unexpected status code. Expected %v, Actual %v", http.StatusAccepted, resp.Code)
+		}
+	}
+	statuses := h.GetSubscriberStatuses()
+	if !statuses["slow"].Ready || !statuses["succeeding"].Ready {
+		t.Errorf("Unexpected statuses of the slow and succeeding subscribers: %+v", statuses)
+	}
+	if statuses["failing"].Ready || statuses["failing"].Message == "" {
+		t.Errorf("Unexpected status of the failing subscriber: %+v", statuses["failing"])
+	}
+	if got := statusChanged.Load(); got != 1 {
+		t.Errorf("Unexpected status changes. Expected 1, Actual %d", got)
+	}
+
+	// The status of a subscriber is kept when the subscriptions are updated.
+	h.SetSubscriptions(context.Background(), []Subscription{
+		{UID: "failing", Subscriber: apis.HTTP(failing.URL[7:]).URL()},
+	})
+	if diff := cmp.Diff(map[types.UID]SubscriberStatus{"failing": statuses["failing"]}, h.GetSubscriberStatuses()); diff != "" {
+		t.Error("Unexpected statuses (-want, +got) =", diff)
+	}
+}
+
+func TestFanoutMessageHandler_Filters(t *testing.T) {
+	received := map[string]chan string{
+		"matching":     make(chan string, 10),
+		"not-matching": make(chan string, 10),
+		"unfiltered":   make(chan string, 10),
+	}
+	filters := map[string][]eventingduckv1.SubscriptionsAPIFilter{
+		"matching": {{
+			Exact: map[string]string{"type": "com.example.someevent"},
+		}},
+		"not-matching": {{
+			Prefix: map[string]string{"type": "com.example.other"},
+		}},
+	}
+	var subs []Subscription
+	for uid, ch := range received {
+		ch := ch
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ch <- r.Header.Get("Ce-Id")
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+		sub, err := SubscriberSpecToFanoutConfig(eventingduckv1.SubscriberSpec{
+			UID:           types.UID(uid),
+			SubscriberURI: apis.HTTP(server.URL[7:]),
+			Filters:       filters[uid],
+		})
+		if err != nil {
+			t.Fatal("SubscriberSpecToFanoutConfig() =", err)
+		}
+		subs = append(subs, *sub)
+	}
+
+	logger := zap.NewNop()
+	h, err := NewFanoutMessageHandler(
+		logger,
+		channel.NewMessageDispatcher(logger),
+		Config{Subscriptions: subs},
+		channel.NewStatsReporter("testcontainer", "testpod"),
+	)
+	if err != nil {
+		t.Fatal("NewHandler failed =", err)
+	}
+
+	event := makeCloudEvent()
+	req := httptest.NewRequest(http.MethodPost, "http://channelname.channelnamespace/", nil)
+	if err := bindingshttp.WriteRequest(context.Background(), binding.ToMessage(&event), req); err != nil {
+		t.Fatal("WriteRequest =", err)
+	}
+	resp := httptest.NewRecorder()
+	h.ServeHTTP(resp, req)
+
+	if resp.Code != http.StatusAccepted {
+		t.Errorf("Unexpected status code. Expected %v, Actual %v", http.StatusAccepted, resp.Code)
+	}
+	for uid, want := range map[string]int{"matching": 1, "not-matching": 0, "unfiltered": 1} {
+		if got := len(received[uid]); got != want {
+			t.Errorf("Subscriber %s received %d events, want %d", uid, got, want)
+		}
+	}
+}
+
+func TestSubscriberQueue_Full(t *testing.T) {
+	deadLetter := make(chan struct{}, 1)
+	dls := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deadLetter <- struct{}{}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer dls.Close()
+
+	logger := zap.NewNop()
+	h, err := NewFanoutMessageHandler(logger, channel.NewMessageDispatcher(logger), Config{}, nil)
+	if err != nil {
+		t.Fatal("NewHandler failed =", err)
+	}
+	for _, tc := range []struct {
+		name       string
+		deadLetter *url.URL
+		wantErr    bool
+	}{
+		{name: "without dead letter sink", wantErr: true},
+		{name: "with dead letter sink", deadLetter: apis.HTTP(dls.URL[7:]).URL()},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// A queue without capacity is always full.
+			q := newSubscriberQueue(Subscription{UID: "full", DeadLetter: tc.deadLetter}, 0, 1)
+			event := makeCloudEvent()
+			job := dispatchJob{ctx: context.Background(), message: binding.ToMessage(&event)}
+			for i := 0; i < subscriberFailureThreshold; i++ {
+				if q.enqueue(job, h.dispatchJob(q)) {
+					t.Fatal("enqueue() = true, want false")
+				}
+				result := h.rejectJob(job, q)
+				if got := result.err != nil; got != tc.wantErr {
+					t.Errorf("Unexpected error %v", result.err)
+				}
+				if tc.deadLetter != nil {
+					<-deadLetter
+				}
+			}
+			if q.getStatus().Ready {
+				t.Error("Subscriber with a full queue is ready")
+			}
+		})
+	}
+}
+
+func TestSubscriberQueue_SetResult(t *testing.T) {
+	q := newSubscriberQueue(Subscription{UID: "sub"}, 1, 1)
+	errFailed := errors.New("failed")
+	var changes []bool
+	setResult := func(err error) {
+		if q.setResult(err) {
+			changes = append(changes, q.getStatus().Ready)
+		}
+	}
+
+	// Sporadic failures don't change the readiness of the subscriber.
+	for i := 0; i < 3; i++ {
+		for j := 0; j < subscriberFailureThreshold-1; j++ {
+			setResult(errFailed)
+		}
+		setResult(nil)
+	}
+	if len(changes) != 0 {
+		t.Errorf("Unexpected readiness changes %v after sporadic failures", changes)
+	}
+
+	for i := 0; i < 2*subscriberFailureThreshold; i++ {
+		setResult(errFailed)
+	}
+	if want := (SubscriberStatus{Message: "failed"}); q.getStatus() != want {
+		t.Errorf("getStatus() = %+v, want %+v", q.getStatus(), want)
+	}
+	setResult(nil)
+	if diff := cmp.Diff([]bool{false, true}, changes); diff != "" {
+		t.Error("Unexpected readiness changes (-want, +got) =", diff)
+	}
+}
+
+func TestFanoutMessageHandler_MaxInFlight(t *testing.T) {
+	release := make(chan struct{})
+	received := make(chan struct{}, 2)
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+		<-release
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer slow.Close()
+
+	logger := zap.NewNop()
+	h, err := NewFanoutMessageHandler(
+		logger,
+		channel.NewMessageDispatcher(logger),
+		Config{
+			Subscriptions:  []Subscription{{Subscriber: apis.HTTP(slow.URL[7:]).URL()}},
+			AsyncHandler:   true,
+			DispatchLimits: DispatchLimits{MaxInFlight: 1},
+		},
+		channel.NewStatsReporter("testcontainer", "testpod"),
+	)
+	if err != nil {
+		t.Fatal("NewHandler failed =", err)
+	}
+
+	send := func() *httptest.ResponseRecorder {
+		event := makeCloudEvent()
+		req := httptest.NewRequest(http.MethodPost, "http://channelname.channelnamespace/", nil)
+		if err := bindingshttp.WriteRequest(context.Background(), binding.ToMessage(&event), req); err != nil {
+			t.Fatal("WriteRequest =", err)
+		}
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, req)
+		return resp
+	}
+
+	if resp := send(); resp.Code != http.StatusAccepted {
+		t.Fatalf("Unexpected status code. Expected %v, Actual %v", http.StatusAccepted, resp.Code)
+	}
+	<-received
+
+	// The first event is still in flight.
+	resp := send()
+	if resp.Code != http.StatusTooManyRequests {
+		t.Errorf("Unexpected status code. Expected %v, Actual %v", http.StatusTooManyRequests, resp.Code)
+	}
+	if got := resp.Header().Get("Retry-After"); got != "1" {
+		t.Errorf("Unexpected Retry-After header. Expected %q, Actual %q", "1", got)
+	}
+
+	// Raising the limit accepts new events.
+	h.SetDispatchLimits(DispatchLimits{MaxInFlight: 2, SubscriberWorkers: 4, SubscriberQueueSize: 10})
+	if resp := send(); resp.Code != http.StatusAccepted {
+		t.Errorf("Unexpected status code. Expected %v, Actual %v", http.StatusAccepted, resp.Code)
+	}
+	if q := h.getQueues()[0]; cap(q.jobs) != 10 || q.maxWorkers != 4 {
+		t.Errorf("Unexpected queue size %d and workers %d", cap(q.jobs), q.maxWorkers)
+	}
+	close(release)
+}
+
+func TestFanoutMessageHandler_ReplayTo(t *testing.T) {
+	received := map[string]chan string{
+		"a": make(chan string, 10),
+		"b": make(chan string, 10),
+	}
+	var subs []Subscription
+	for uid, ch := range received {
+		ch := ch
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ch <- r.Header.Get("Ce-Id")
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+		subs = append(subs, Subscription{UID: types.UID(uid), Subscriber: apis.HTTP(server.URL[7:]).URL()})
+	}
+
+	logger := zap.NewNop()
+	h, err := NewFanoutMessageHandler(
+		logger,
+		channel.NewMessageDispatcher(logger),
+		Config{Subscriptions: subs},
+		channel.NewStatsReporter("testcontainer", "testpod"),
+	)
+	if err != nil {
+		t.Fatal("NewHandler failed =", err)
+	}
+	if _, err := h.ReplayTo(context.Background(), "b", retention.Range{}); !errors.Is(err, ErrRetentionDisabled) {
+		t.Errorf("ReplayTo() without retention = %v, want %v", err, ErrRetentionDisabled)
+	}
+	h.SetRetentionLimits(retention.Limits{Size: 10})
+
+	for _, id := range []string{"1", "2", "3"} {
+		event := makeCloudEvent()
+		event.SetID(id)
+		req := httptest.NewRequest(http.MethodPost, "http://channelname.channelnamespace/", nil)
+		if err := bindingshttp.WriteRequest(context.Background(), binding.ToMessage(&event), req); err != nil {
+			t.Fatal("WriteRequest =", err)
+		}
+		h.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	for uid, ch := range received {
+		for i := 0; i < 3; i++ {
+			<-ch
+		}
+		if len(ch) != 0 {
+			t.Errorf("Subscriber %s received unexpected events", uid)
+		}
+	}
+
+	n, err := h.ReplayTo(context.Background(), "b", retention.Range{FromOffset: 2})
+	if err != nil {
+		t.Fatal("ReplayTo() =", err)
+	}
+	if n != 2 {
+		t.Errorf("ReplayTo() = %d, want 2", n)
+	}
+	var got []string
+	for i := 0; i < n; i++ {
+		got = append(got, <-received["b"])
+	}
+	sort.Strings(got)
+	if diff := cmp.Diff([]string{"2", "3"}, got); diff != "" {
+		t.Error("Unexpected replayed events (-want, +got) =", diff)
+	}
+	if len(received["a"]) != 0 {
+		t.Error("Events replayed to another subscriber")
+	}
+
+	if _, err := h.ReplayTo(context.Background(), "c", retention.Range{}); !errors.Is(err, ErrUnknownSubscriber) {
+		t.Errorf("ReplayTo() unknown subscriber = %v, want %v", err, ErrUnknownSubscriber)
+	}
+
+	// The replayed events count in the events in flight.
+	err = wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return h.inFlight.Load() == 0, nil
+	})
+	if err != nil {
+		t.Fatalf("Replayed events still in flight: %d", h.inFlight.Load())
+	}
+	h.SetDispatchLimits(DispatchLimits{MaxInFlight: 1})
+	if !h.acquire() {
+		t.Fatal("acquire() = false, want true")
+	}
+	if n, err := h.ReplayTo(context.Background(), "b", retention.Range{}); n != 0 || !errors.Is(err, ErrReplayThrottled) {
+		t.Errorf("ReplayTo() with too many events in flight = (%d, %v), want (0, %v)", n, err, ErrReplayThrottled)
+	}
+	h.release()
+}
+
+func TestFanoutMessageHandler_ReplayMaxInFlight(t *testing.T) {
+	release := make(chan struct{})
+	received := make(chan string, 2)
+	subscriber := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Get("ce-id")
+		<-release
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer subscriber.Close()
+
+	log, err := wal.Open(filepath.Join(t.TempDir(), "channel.wal"))
+	if err != nil {
+		t.Fatal("wal.Open() =", err)
+	}
+	defer log.Close()
+	for _, id := range []string{"1", "2"} {
+		event := makeCloudEvent()
+		event.SetID(id)
+		if _, err := log.Append(&event, nil); err != nil {
+			t.Fatal("Append() =", err)
+		}
+	}
+
+	logger := zap.NewNop()
+	h, err := NewFanoutMessageHandler(
+		logger,
+		channel.NewMessageDispatcher(logger),
+		Config{
+			Subscriptions:  []Subscription{{Subscriber: apis.HTTP(subscriber.URL[7:]).URL()}},
+			AsyncHandler:   true,
+			WriteAheadLog:  log,
+			DispatchLimits: DispatchLimits{MaxInFlight: 1},
+		},
+		channel.NewStatsReporter("testcontainer", "testpod"),
+	)
+	if err != nil {
+		t.Fatal("NewHandler failed =", err)
+	}
+
+	h.Replay(context.Background(), channel.ChannelReference{Namespace: "channelnamespace", Name: "channelname"})
+	if got := <-received; got != "1" {
+		t.Errorf("Unexpected replayed event %q", got)
+	}
+	// The second event waits for the first one to be dispatched.
+	select {
+	case got := <-received:
+		t.Errorf("Event %q replayed beyond MaxInFlight", got)
+	case <-time.After(3 * replayWaitInterval):
+	}
+	close(release)
+	if got := <-received; got != "2" {
+		t.Errorf("Unexpected replayed event %q", got)
+	}
+}
+
 func testFanoutMessageHandler(t *testing.T, async bool, receiverFunc channel.UnbufferedMessageReceiverFunc, timeout time.Duration, inSubs []Subscription, subscriberHandler func(http.ResponseWriter, *http.Request), subscriberReqs int, replierHandler func(http.ResponseWriter, *http.Request), replierReqs int, expectedStatus int) {
 	var subscriberServerWg *sync.WaitGroup
 	reporter := channel.NewStatsReporter("testcontainer", "testpod")
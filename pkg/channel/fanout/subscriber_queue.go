@@ -0,0 +1,215 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fanout
+
+import (
+	"context"
+	"errors"
+	nethttp "net/http"
+	"sync"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// defaultSubscriberQueueSize is the number of events waiting to be dispatched to a subscriber,
+	// beyond which the events for this subscriber are rejected.
+	defaultSubscriberQueueSize = 1000
+	// defaultSubscriberWorkers is the maximum number of events dispatched concurrently to a subscriber.
+	defaultSubscriberWorkers = 16
+	// subscriberFailureThreshold is the number of consecutive events failing to be dispatched to a
+	// subscriber after which the subscriber isn't ready, so that sporadic failures don't flip its status.
+	subscriberFailureThreshold = 5
+)
+
+// DispatchLimits bounds the events dispatched concurrently by a FanoutMessageHandler.
+// Zero values are replaced by the defaults.
+type DispatchLimits struct {
+	// MaxInFlight is the maximum number of events accepted by an AsyncHandler, or replayed, and
+	// not yet dispatched, beyond which new events are rejected with a channel.ThrottledError.
+	// Zero means no limit.
+	MaxInFlight int
+	// SubscriberWorkers is the maximum number of events dispatched concurrently to a subscriber.
+	SubscriberWorkers int
+	// SubscriberQueueSize is the maximum number of events waiting to be dispatched to a subscriber.
+	SubscriberQueueSize int
+}
+
+func (l DispatchLimits) withDefaults() DispatchLimits {
+	if l.SubscriberWorkers <= 0 {
+		l.SubscriberWorkers = defaultSubscriberWorkers
+	}
+	if l.SubscriberQueueSize <= 0 {
+		l.SubscriberQueueSize = defaultSubscriberQueueSize
+	}
+	return l
+}
+
+// errSubscriberQueueFull is the error of the events rejected because too many events are waiting
+// to be dispatched to the subscriber.
+var errSubscriberQueueFull = errors.New("too many events waiting to be dispatched to the subscriber")
+
+// SubscriberStatus is the status of the dispatch of the events to a subscriber.
+type SubscriberStatus struct {
+	// Ready is false when the last subscriberFailureThreshold events dispatched to the subscriber
+	// failed, until an event is dispatched successfully.
+	Ready bool
+	// Message is the error of the last event that failed.
+	Message string
+}
+
+// dispatchJob is an event to dispatch to a subscriber.
+type dispatchJob struct {
+	ctx     context.Context
+	message binding.Message
+	headers nethttp.Header
+	// done is called with the result of the dispatch.
+	done func(DispatchResult)
+}
+
+// subscriberQueue is the bounded queue of the events to dispatch to a subscriber, and the pool
+// of workers dispatching them. Workers are started on demand, up to maxWorkers, and stop when
+// the queue is empty, so a queue doesn't need to be closed.
+type subscriberQueue struct {
+	jobs       chan dispatchJob
+	maxWorkers int
+
+	mu      sync.Mutex
+	sub     Subscription
+	workers int
+	status  SubscriberStatus
+	// failures is the number of consecutive events that failed to be dispatched.
+	failures int
+}
+
+func newSubscriberQueue(sub Subscription, size, maxWorkers int) *subscriberQueue {
+	return &subscriberQueue{
+		jobs:       make(chan dispatchJob, size),
+		maxWorkers: maxWorkers,
+		sub:        sub,
+		status:     SubscriberStatus{Ready: true},
+	}
+}
+
+// fits returns true if the queue has the size and the number of workers of the limits.
+func (q *subscriberQueue) fits(limits DispatchLimits) bool {
+	return cap(q.jobs) == limits.SubscriberQueueSize && q.maxWorkers == limits.SubscriberWorkers
+}
+
+// resize returns a new queue with the size and the number of workers of the limits, keeping the
+// subscription and its status. The workers of q keep dispatching the events already queued.
+func (q *subscriberQueue) resize(limits DispatchLimits) *subscriberQueue {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	resized := newSubscriberQueue(q.sub, limits.SubscriberQueueSize, limits.SubscriberWorkers)
+	resized.status = q.status
+	resized.failures = q.failures
+	return resized
+}
+
+// subscriptionKey identifies a subscription across updates of the subscriptions.
+type subscriptionKey struct {
+	uid        types.UID
+	subscriber string
+	reply      string
+}
+
+func keyForSubscription(sub Subscription) subscriptionKey {
+	if sub.UID != "" {
+		return subscriptionKey{uid: sub.UID}
+	}
+	var key subscriptionKey
+	if sub.Subscriber != nil {
+		key.subscriber = sub.Subscriber.String()
+	}
+	if sub.Reply != nil {
+		key.reply = sub.Reply.String()
+	}
+	return key
+}
+
+func (q *subscriberQueue) subscription() Subscription {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.sub
+}
+
+func (q *subscriberQueue) setSubscription(sub Subscription) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.sub = sub
+}
+
+// enqueue adds the job to the queue, and returns false if the queue is full.
+func (q *subscriberQueue) enqueue(job dispatchJob, dispatch func(dispatchJob, Subscription) DispatchResult) bool {
+	select {
+	case q.jobs <- job:
+	default:
+		return false
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.workers < q.maxWorkers {
+		q.workers++
+		go q.work(dispatch)
+	}
+	return true
+}
+
+func (q *subscriberQueue) work(dispatch func(dispatchJob, Subscription) DispatchResult) {
+	for {
+		select {
+		case job := <-q.jobs:
+			job.done(dispatch(job, q.subscription()))
+		default:
+			q.mu.Lock()
+			if len(q.jobs) == 0 {
+				q.workers--
+				q.mu.Unlock()
+				return
+			}
+			q.mu.Unlock()
+		}
+	}
+}
+
+// setResult updates the status of the subscriber with the result of a dispatch, and returns
+// true if the readiness of the subscriber changed.
+func (q *subscriberQueue) setResult(err error) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if err == nil {
+		q.failures = 0
+		changed := !q.status.Ready
+		q.status = SubscriberStatus{Ready: true}
+		return changed
+	}
+	q.failures++
+	if q.failures < subscriberFailureThreshold {
+		return false
+	}
+	changed := q.status.Ready
+	q.status = SubscriberStatus{Ready: false, Message: err.Error()}
+	return changed
+}
+
+func (q *subscriberQueue) getStatus() SubscriberStatus {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.status
+}
@@ -19,7 +19,6 @@ package channel
 import (
 	"bytes"
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -34,6 +33,7 @@ import (
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/util/sets"
 
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/broker"
 	"knative.dev/eventing/pkg/channel/attributes"
 	"knative.dev/eventing/pkg/kncloudevents"
@@ -61,6 +61,21 @@ type MessageDispatcher interface {
 	DispatchMessageWithRetries(ctx context.Context, message cloudevents.Message, additionalHeaders nethttp.Header, destination *url.URL, reply *url.URL, deadLetter *url.URL, config *kncloudevents.RetryConfig, transformers ...binding.Transformer) (*DispatchExecutionInfo, error)
 }
 
+type subscriberReferenceKey struct{}
+
+// ContextWithSubscriberReference returns a context with the resource, like a Subscription, the
+// messages are dispatched for. It is reported in the events sent to the dead letter sinks.
+func ContextWithSubscriberReference(ctx context.Context, ref attributes.SubscriberReference) context.Context {
+	return context.WithValue(ctx, subscriberReferenceKey{}, ref)
+}
+
+func subscriberReferenceFromContext(ctx context.Context) *attributes.SubscriberReference {
+	if ref, ok := ctx.Value(subscriberReferenceKey{}).(attributes.SubscriberReference); ok {
+		return &ref
+	}
+	return nil
+}
+
 // MessageDispatcherImpl is the 'real' MessageDispatcher used everywhere except unit tests.
 var _ MessageDispatcher = &MessageDispatcherImpl{}
 
@@ -68,6 +83,7 @@ var _ MessageDispatcher = &MessageDispatcherImpl{}
 type MessageDispatcherImpl struct {
 	sender           *kncloudevents.HTTPMessageSender
 	supportedSchemes sets.String
+	circuitBreakers  *circuitBreakers
 
 	logger *zap.Logger
 }
@@ -92,6 +108,7 @@ func NewMessageDispatcherFromSender(logger *zap.Logger, sender *kncloudevents.HT
 	return &MessageDispatcherImpl{
 		sender:           sender,
 		supportedSchemes: sets.NewString("http", "https"),
+		circuitBreakers:  newCircuitBreakers(),
 		logger:           logger,
 	}
 }
@@ -133,12 +150,45 @@ func (d *MessageDispatcherImpl) DispatchMessageWithRetries(ctx context.Context,
 		}
 		additionalHeadersForDestination.Set("Prefer", "reply")
 
-		ctx, responseMessage, responseAdditionalHeaders, dispatchExecutionInfo, err = d.executeRequest(ctx, destination, message, additionalHeadersForDestination, retriesConfig, transformers...)
+		// Count the attempts when they are reported to the dead letter sink.
+		destinationRetries, attempts := retriesConfig, func() int { return 1 }
+		if deadLetter != nil && deadLetterPayload(retriesConfig) != eventingduckv1.DeadLetterPayloadOriginal {
+			destinationRetries, attempts = kncloudevents.CountAttempts(retriesConfig)
+		}
+		start := time.Now()
+
+		// While the circuit breaker of the destination is open, the message goes straight to the dead letter sink.
+		var breaker *circuitBreaker
+		if retriesConfig != nil && retriesConfig.CircuitBreaker != nil {
+			var subscriber attributes.SubscriberReference
+			if ref := subscriberReferenceFromContext(ctx); ref != nil {
+				subscriber = *ref
+			}
+			breaker = d.circuitBreakers.get(subscriber, destination.String(), *retriesConfig.CircuitBreaker)
+			defer d.circuitBreakers.release(breaker)
+		}
+		if breaker != nil && !breaker.allow() {
+			err = ErrCircuitBreakerOpen
+			attempts = func() int { return 0 }
+			dispatchExecutionInfo = &DispatchExecutionInfo{
+				Time:         NoDuration,
+				ResponseCode: nethttp.StatusServiceUnavailable,
+				ResponseBody: []byte(ErrCircuitBreakerOpen.Error()),
+			}
+		} else {
+			ctx, responseMessage, responseAdditionalHeaders, dispatchExecutionInfo, err = d.executeRequest(ctx, destination, message, additionalHeadersForDestination, destinationRetries, transformers...)
+			if breaker != nil {
+				breaker.record(isCircuitBreakerFailure(dispatchExecutionInfo, err))
+			}
+		}
 		if err != nil {
 			// If DeadLetter is configured, then send original message with knative error extensions
 			if deadLetter != nil {
-				dispatchTransformers := d.dispatchExecutionInfoTransformers(destination, dispatchExecutionInfo)
-				_, deadLetterResponse, _, dispatchExecutionInfo, deadLetterErr := d.executeRequest(ctx, deadLetter, message, additionalHeaders, retriesConfig, append(transformers, dispatchTransformers)...)
+				deadLetterMessage, deadLetterTransformers, deadLetterErr := d.deadLetterMessage(ctx, message, destination, dispatchExecutionInfo, retriesConfig, attempts(), start, transformers)
+				if deadLetterErr != nil {
+					return dispatchExecutionInfo, fmt.Errorf("unable to complete request to %s (%v) nor to create the dead letter event (%v)", destination, err, deadLetterErr)
+				}
+				_, deadLetterResponse, _, dispatchExecutionInfo, deadLetterErr := d.executeRequest(ctx, deadLetter, deadLetterMessage, additionalHeaders, retriesConfig, deadLetterTransformers...)
 				if deadLetterErr != nil {
 					return dispatchExecutionInfo, fmt.Errorf("unable to complete request to either %s (%v) or %s (%v)", destination, err, deadLetter, deadLetterErr)
 				}
@@ -169,12 +219,20 @@ func (d *MessageDispatcherImpl) DispatchMessageWithRetries(ctx context.Context,
 		return dispatchExecutionInfo, nil
 	}
 
-	ctx, responseResponseMessage, _, dispatchExecutionInfo, err := d.executeRequest(ctx, reply, responseMessage, responseAdditionalHeaders, retriesConfig, transformers...)
+	replyRetries, attempts := retriesConfig, func() int { return 1 }
+	if deadLetter != nil && deadLetterPayload(retriesConfig) != eventingduckv1.DeadLetterPayloadOriginal {
+		replyRetries, attempts = kncloudevents.CountAttempts(retriesConfig)
+	}
+	start := time.Now()
+	ctx, responseResponseMessage, _, dispatchExecutionInfo, err := d.executeRequest(ctx, reply, responseMessage, responseAdditionalHeaders, replyRetries, transformers...)
 	if err != nil {
 		// If DeadLetter is configured, then send original message with knative error extensions
 		if deadLetter != nil {
-			dispatchTransformers := d.dispatchExecutionInfoTransformers(reply, dispatchExecutionInfo)
-			_, deadLetterResponse, _, dispatchExecutionInfo, deadLetterErr := d.executeRequest(ctx, deadLetter, message, responseAdditionalHeaders, retriesConfig, append(transformers, dispatchTransformers)...)
+			deadLetterMessage, deadLetterTransformers, deadLetterErr := d.deadLetterMessage(ctx, message, reply, dispatchExecutionInfo, retriesConfig, attempts(), start, transformers)
+			if deadLetterErr != nil {
+				return dispatchExecutionInfo, fmt.Errorf("failed to forward reply to %s (%v) and failed to create the dead letter event (%v)", reply, err, deadLetterErr)
+			}
+			_, deadLetterResponse, _, dispatchExecutionInfo, deadLetterErr := d.executeRequest(ctx, deadLetter, deadLetterMessage, responseAdditionalHeaders, retriesConfig, deadLetterTransformers...)
 			if deadLetterErr != nil {
 				return dispatchExecutionInfo, fmt.Errorf("failed to forward reply to %s (%v) and failed to send it to the dead letter sink %s (%v)", reply, err, deadLetter, deadLetterErr)
 			}
@@ -288,8 +346,49 @@ func (d *MessageDispatcherImpl) sanitizeURL(u *url.URL) *url.URL {
 	}
 }
 
-// dispatchExecutionTransformer returns Transformers based on the specified destination and DispatchExecutionInfo
-func (d *MessageDispatcherImpl) dispatchExecutionInfoTransformers(destination *url.URL, dispatchExecutionInfo *DispatchExecutionInfo) binding.Transformers {
+// deadLetterPayload returns what is sent to the dead letter sink with the retry config.
+func deadLetterPayload(retriesConfig *kncloudevents.RetryConfig) eventingduckv1.DeadLetterPayloadType {
+	if retriesConfig == nil || retriesConfig.DeadLetterPayload == "" {
+		return eventingduckv1.DeadLetterPayloadOriginal
+	}
+	return retriesConfig.DeadLetterPayload
+}
+
+// deadLetterMessage returns the message, and its transformers, sent to the dead letter sink after the
+// failed delivery of message to destination.
+func (d *MessageDispatcherImpl) deadLetterMessage(ctx context.Context, message cloudevents.Message, destination *url.URL, dispatchExecutionInfo *DispatchExecutionInfo, retriesConfig *kncloudevents.RetryConfig, attempts int, start time.Time, transformers []binding.Transformer) (cloudevents.Message, []binding.Transformer, error) {
+	payload := deadLetterPayload(retriesConfig)
+	if payload == eventingduckv1.DeadLetterPayloadOriginal {
+		dispatchTransformers := d.dispatchExecutionInfoTransformers(destination, dispatchExecutionInfo)
+		return message, append(transformers, dispatchTransformers), nil
+	}
+
+	event, err := binding.ToEvent(ctx, message, transformers...)
+	if err != nil {
+		return nil, nil, err
+	}
+	errDestination, responseBody, ok := d.errorDestination(destination, dispatchExecutionInfo)
+	if !ok {
+		errDestination, responseBody = d.sanitizeURL(destination), dispatchExecutionInfo.ResponseBody
+	}
+	deadLetterEvent, err := attributes.NewDeadLetterEvent(event, attributes.DeadLetterEventData{
+		Destination:      errDestination.String(),
+		ResponseCode:     dispatchExecutionInfo.ResponseCode,
+		ResponseBody:     string(responseBody),
+		Attempts:         attempts,
+		FirstAttemptTime: start,
+		LastAttemptTime:  time.Now(),
+		Subscriber:       subscriberReferenceFromContext(ctx),
+	}, payload == eventingduckv1.DeadLetterPayloadEnvelope)
+	if err != nil {
+		return nil, nil, err
+	}
+	return binding.ToMessage(deadLetterEvent), nil, nil
+}
+
+// errorDestination returns the destination that failed, and its response body. When the destination is
+// the broker filter, they are the ones of the subscriber of the Trigger, reported in the response body.
+func (d *MessageDispatcherImpl) errorDestination(destination *url.URL, dispatchExecutionInfo *DispatchExecutionInfo) (*url.URL, []byte, bool) {
 	if destination == nil {
 		destination = &url.URL{}
 	}
@@ -302,60 +401,22 @@ func (d *MessageDispatcherImpl) dispatchExecutionInfoTransformers(destination *u
 		err := json.Unmarshal(dispatchExecutionInfo.ResponseBody, &errExtensionInfo)
 		if err != nil {
 			d.logger.Debug("Unmarshal dispatchExecutionInfo ResponseBody failed", zap.Error(err))
-			return nil
+			return nil, nil, false
 		}
 		destination = errExtensionInfo.ErrDestination
 		httpResponseBody = errExtensionInfo.ErrResponseBody
 	}
 
-	destination = d.sanitizeURL(destination)
-	// Unprintable control characters are not allowed in header values
-	// and cause HTTP requests to fail if not removed.
-	// https://pkg.go.dev/golang.org/x/net/http/httpguts#ValidHeaderFieldValue
-	httpBody := sanitizeHTTPBody(httpResponseBody)
-
-	// Encodes response body as base64 for the resulting length.
-	bodyLen := len(httpBody)
-	encodedLen := base64.StdEncoding.EncodedLen(bodyLen)
-	if encodedLen > attributes.KnativeErrorDataExtensionMaxLength {
-		encodedLen = attributes.KnativeErrorDataExtensionMaxLength
-	}
-	encodedBuf := make([]byte, encodedLen)
-	base64.StdEncoding.Encode(encodedBuf, []byte(httpBody))
-
-	return attributes.KnativeErrorTransformers(*destination, dispatchExecutionInfo.ResponseCode, string(encodedBuf[:encodedLen]))
-}
-
-func sanitizeHTTPBody(body []byte) string {
-	if !hasControlChars(body) {
-		return string(body)
-	}
-
-	sanitizedResponse := make([]byte, 0, len(body))
-	for _, v := range body {
-		if !isControl(v) {
-			sanitizedResponse = append(sanitizedResponse, v)
-		}
-	}
-	return string(sanitizedResponse)
+	return d.sanitizeURL(destination), httpResponseBody, true
 }
 
-func hasControlChars(data []byte) bool {
-	for _, v := range data {
-		if isControl(v) {
-			return true
-		}
+// dispatchExecutionTransformer returns Transformers based on the specified destination and DispatchExecutionInfo
+func (d *MessageDispatcherImpl) dispatchExecutionInfoTransformers(destination *url.URL, dispatchExecutionInfo *DispatchExecutionInfo) binding.Transformers {
+	destination, httpResponseBody, ok := d.errorDestination(destination, dispatchExecutionInfo)
+	if !ok {
+		return nil
 	}
-	return false
-}
-
-func isControl(c byte) bool {
-	// US ASCII codes range for printable graphic characters and a space.
-	// http://www.columbia.edu/kermit/ascii.html
-	const asciiUnitSeparator = 31
-	const asciiRubout = 127
-
-	return int(c) < asciiUnitSeparator || int(c) > asciiRubout
+	return attributes.KnativeErrorTransformers(*destination, dispatchExecutionInfo.ResponseCode, attributes.KnativeErrorData(httpResponseBody))
 }
 
 // isFailure returns true if the status code is not a successful HTTP status.
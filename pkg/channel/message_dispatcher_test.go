@@ -31,12 +31,18 @@ import (
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/cloudevents/sdk-go/v2/binding/transformer"
+	"github.com/cloudevents/sdk-go/v2/event"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/pointer"
 
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/channel/attributes"
+	"knative.dev/eventing/pkg/kncloudevents"
 	"knative.dev/eventing/pkg/utils"
 )
 
@@ -917,6 +923,72 @@ func TestDispatchMessage(t *testing.T) {
 	}
 }
 
+func TestDispatchMessageDeadLetterPayload(t *testing.T) {
+	linearPolicy := eventingduckv1.BackoffPolicyLinear
+	for _, payload := range []eventingduckv1.DeadLetterPayloadType{eventingduckv1.DeadLetterPayloadEnvelope, eventingduckv1.DeadLetterPayloadReference} {
+		t.Run(string(payload), func(t *testing.T) {
+			destination := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(strings.Repeat("x", 2000)))
+			}))
+			defer destination.Close()
+
+			var deadLetterEvent *event.Event
+			deadLetter := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				e, err := binding.ToEvent(context.Background(), cehttp.NewMessageFromHttpRequest(r))
+				if err != nil {
+					t.Error("Unable to read the dead letter event:", err)
+				}
+				deadLetterEvent = e
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer deadLetter.Close()
+
+			e := event.New()
+			e.SetID("id")
+			e.SetType("dev.knative.test")
+			e.SetSource("/test")
+			_ = e.SetData(event.ApplicationJSON, map[string]string{"hello": "world"})
+
+			retryConfig, err := kncloudevents.RetryConfigFromDeliverySpec(eventingduckv1.DeliverySpec{
+				Retry:             pointer.Int32(2),
+				BackoffPolicy:     &linearPolicy,
+				BackoffDelay:      pointer.String("PT0.01S"),
+				DeadLetterPayload: &payload,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := ContextWithSubscriberReference(context.Background(), attributes.SubscriberReference{Kind: "Subscription", UID: "abc"})
+			md := NewMessageDispatcher(zap.NewNop())
+			if _, err := md.DispatchMessageWithRetries(ctx, binding.ToMessage(&e), nil,
+				getOnlyDomainURL(t, true, destination.URL), nil, getOnlyDomainURL(t, true, deadLetter.URL), &retryConfig); err != nil {
+				t.Fatal("DispatchMessageWithRetries() =", err)
+			}
+
+			if deadLetterEvent == nil {
+				t.Fatal("No event sent to the dead letter sink")
+			}
+			if deadLetterEvent.Type() != attributes.DeadLetterEventType {
+				t.Errorf("Unexpected dead letter event type %q", deadLetterEvent.Type())
+			}
+			var data attributes.DeadLetterEventData
+			if err := deadLetterEvent.DataAs(&data); err != nil {
+				t.Fatal("Unable to read the dead letter event data:", err)
+			}
+			if data.Attempts != 3 || data.ResponseCode != http.StatusInternalServerError || len(data.ResponseBody) != 2000 {
+				t.Errorf("Unexpected dead letter event data %+v", data)
+			}
+			if data.EventReference.ID != "id" || data.Subscriber == nil || data.Subscriber.UID != "abc" {
+				t.Errorf("Unexpected dead letter event references %+v %+v", data.EventReference, data.Subscriber)
+			}
+			if withEvent := len(data.Event) != 0; withEvent != (payload == eventingduckv1.DeadLetterPayloadEnvelope) {
+				t.Errorf("Unexpected event in the dead letter event data: %s", data.Event)
+			}
+		})
+	}
+}
+
 func getOnlyDomainURL(t *testing.T, shouldSend bool, serverURL string) *url.URL {
 	if shouldSend {
 		server, err := url.Parse(serverURL)
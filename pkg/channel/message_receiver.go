@@ -20,7 +20,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	nethttp "net/http"
+	"strconv"
 	"time"
 
 	"github.com/cloudevents/sdk-go/v2/binding"
@@ -44,6 +46,17 @@ func (e *UnknownChannelError) Error() string {
 	return fmt.Sprint("unknown channel: ", e.Channel)
 }
 
+// ThrottledError represents the error when an event is rejected by a channel dispatcher because
+// too many events are in flight. The sender should retry after RetryAfter.
+type ThrottledError struct {
+	Channel    ChannelReference
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprint("too many events in flight for channel: ", e.Channel)
+}
+
 // UnknownHostError represents the error when a ResolveMessageChannelFromHostHeader func cannot resolve an host
 type UnknownHostError string
 
@@ -212,8 +225,13 @@ func (r *MessageReceiver) ServeHTTP(response nethttp.ResponseWriter, request *ne
 
 	err = r.receiverFunc(request.Context(), channel, bufferedMessage, []binding.Transformer{}, utils.PassThroughHeaders(request.Header))
 	if err != nil {
+		var throttled *ThrottledError
 		if _, ok := err.(*UnknownChannelError); ok {
 			response.WriteHeader(nethttp.StatusNotFound)
+		} else if errors.As(err, &throttled) {
+			response.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+			response.WriteHeader(nethttp.StatusTooManyRequests)
+			_ = r.reporter.ReportEventCount(&args, nethttp.StatusTooManyRequests)
 		} else {
 			r.logger.Info("Error in receiver", zap.Error(err))
 			response.WriteHeader(nethttp.StatusInternalServerError)
@@ -24,6 +24,7 @@ import (
 	nethttp "net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	obsclient "github.com/cloudevents/sdk-go/observability/opencensus/v2/client"
 	cloudevents "github.com/cloudevents/sdk-go/v2"
@@ -79,6 +80,18 @@ func TestMessageReceiver_ServeHTTP(t *testing.T) {
 			},
 			expected: nethttp.StatusInternalServerError,
 		},
+		"throttled receiver function error": {
+			receiverFunc: func(_ context.Context, c ChannelReference, _ binding.Message, _ []binding.Transformer, _ nethttp.Header) error {
+				return &ThrottledError{Channel: c, RetryAfter: 1500 * time.Millisecond}
+			},
+			expected: nethttp.StatusTooManyRequests,
+			responseValidator: func(res httptest.ResponseRecorder) error {
+				if got := res.Header().Get("Retry-After"); got != "2" {
+					return fmt.Errorf("unexpected Retry-After header: %q, want %q", got, "2")
+				}
+				return nil
+			},
+		},
 		"headers and body pass through": {
 			// The header, body, and host values set here are verified in the receiverFunc. Altering
 			// them here will require the same alteration in the receiverFunc.
@@ -0,0 +1,175 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package retention provides a bounded ring buffer of the recent events accepted by a channel,
+// so that they can be replayed to a subscriber.
+package retention
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+// Record is an event retained by a channel.
+type Record struct {
+	// Offset is the position of the event in the channel, starting at 1.
+	Offset uint64
+	// Time is when the channel accepted the event.
+	Time time.Time
+	// Headers are the additional headers dispatched with the event.
+	Headers http.Header
+	Event   *cloudevents.Event
+}
+
+// Limits bounds the events retained by a Buffer.
+type Limits struct {
+	// Size is the maximum number of events retained, zero disables the retention.
+	Size int
+	// MaxAge is the maximum age of the events retained, zero means no limit.
+	MaxAge time.Duration
+}
+
+// Enabled returns true if the limits retain events.
+func (l Limits) Enabled() bool {
+	return l.Size > 0
+}
+
+// Range selects the retained events, by time and by offset. Zero bounds are ignored.
+type Range struct {
+	// From and To select the events accepted at or after From, and at or before To.
+	From time.Time
+	To   time.Time
+	// FromOffset and ToOffset select the events with an offset between them, inclusive.
+	FromOffset uint64
+	ToOffset   uint64
+}
+
+func (r Range) contains(rec *Record) bool {
+	if !r.From.IsZero() && rec.Time.Before(r.From) {
+		return false
+	}
+	if !r.To.IsZero() && rec.Time.After(r.To) {
+		return false
+	}
+	if r.FromOffset != 0 && rec.Offset < r.FromOffset {
+		return false
+	}
+	if r.ToOffset != 0 && rec.Offset > r.ToOffset {
+		return false
+	}
+	return true
+}
+
+// Buffer is a ring buffer of the last events accepted by a channel. When full, adding an event
+// evicts the oldest one.
+type Buffer struct {
+	mu     sync.Mutex
+	limits Limits
+	// records is the ring of the retained events, the oldest is at start.
+	records []*Record
+	start   int
+	count   int
+	next    uint64
+	now     func() time.Time
+}
+
+// NewBuffer creates a Buffer bounded by limits.
+func NewBuffer(limits Limits) *Buffer {
+	return &Buffer{
+		limits:  limits,
+		records: make([]*Record, limits.Size),
+		next:    1,
+		now:     time.Now,
+	}
+}
+
+// Limits returns the limits of the buffer.
+func (b *Buffer) Limits() Limits {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.limits
+}
+
+// SetLimits updates the limits of the buffer, keeping the most recent events that fit.
+func (b *Buffer) SetLimits(limits Limits) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if limits.Size != b.limits.Size {
+		retained := b.retained()
+		if len(retained) > limits.Size {
+			retained = retained[len(retained)-limits.Size:]
+		}
+		b.records = make([]*Record, limits.Size)
+		copy(b.records, retained)
+		b.start = 0
+		b.count = len(retained)
+	}
+	b.limits = limits
+}
+
+// Add retains the event and its additional headers, and returns its offset.
+func (b *Buffer) Add(event *cloudevents.Event, headers http.Header) uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	offset := b.next
+	b.next++
+	if len(b.records) == 0 {
+		return offset
+	}
+	rec := &Record{Offset: offset, Time: b.now(), Headers: headers, Event: event}
+	if b.count < len(b.records) {
+		b.records[(b.start+b.count)%len(b.records)] = rec
+		b.count++
+	} else {
+		b.records[b.start] = rec
+		b.start = (b.start + 1) % len(b.records)
+	}
+	return offset
+}
+
+// Records returns the retained events within the range, oldest first. Events older than MaxAge
+// are not returned.
+func (b *Buffer) Records(r Range) []Record {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var records []Record
+	for _, rec := range b.retained() {
+		if r.contains(rec) {
+			records = append(records, *rec)
+		}
+	}
+	return records
+}
+
+// retained returns the events not older than MaxAge, oldest first. The expired events are evicted.
+func (b *Buffer) retained() []*Record {
+	if b.limits.MaxAge > 0 {
+		oldest := b.now().Add(-b.limits.MaxAge)
+		for b.count > 0 && b.records[b.start].Time.Before(oldest) {
+			b.records[b.start] = nil
+			b.start = (b.start + 1) % len(b.records)
+			b.count--
+		}
+	}
+	records := make([]*Record, 0, b.count)
+	for i := 0; i < b.count; i++ {
+		records = append(records, b.records[(b.start+i)%len(b.records)])
+	}
+	return records
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retention
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/google/go-cmp/cmp"
+)
+
+var epoch = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func makeEvent(id string) *cloudevents.Event {
+	e := event.New()
+	e.SetID(id)
+	e.SetType("dev.knative.test")
+	e.SetSource("/test")
+	return &e
+}
+
+func ids(records []Record) []string {
+	var ids []string
+	for _, r := range records {
+		ids = append(ids, r.Event.ID())
+	}
+	return ids
+}
+
+// newTestBuffer returns a buffer with an event added every minute, starting at epoch.
+func newTestBuffer(limits Limits, events int) (*Buffer, *time.Time) {
+	now := epoch
+	b := NewBuffer(limits)
+	b.now = func() time.Time { return now }
+	for i := 1; i <= events; i++ {
+		b.Add(makeEvent(strconv.Itoa(i)), nil)
+		now = now.Add(time.Minute)
+	}
+	return b, &now
+}
+
+func TestBufferRecords(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		limits Limits
+		events int
+		r      Range
+		want   []string
+	}{{
+		name:   "all events",
+		limits: Limits{Size: 10},
+		events: 3,
+		want:   []string{"1", "2", "3"},
+	}, {
+		name:   "oldest events evicted",
+		limits: Limits{Size: 3},
+		events: 5,
+		want:   []string{"3", "4", "5"},
+	}, {
+		name:   "retention disabled",
+		events: 3,
+	}, {
+		name:   "time range",
+		limits: Limits{Size: 10},
+		events: 5,
+		r:      Range{From: epoch.Add(time.Minute), To: epoch.Add(3 * time.Minute)},
+		want:   []string{"2", "3", "4"},
+	}, {
+		name:   "offset range",
+		limits: Limits{Size: 10},
+		events: 5,
+		r:      Range{FromOffset: 4},
+		want:   []string{"4", "5"},
+	}, {
+		name:   "offset range of evicted events",
+		limits: Limits{Size: 2},
+		events: 5,
+		r:      Range{ToOffset: 3},
+	}, {
+		name:   "events older than max age",
+		limits: Limits{Size: 10, MaxAge: 150 * time.Second},
+		events: 5,
+		want:   []string{"4", "5"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			b, _ := newTestBuffer(tc.limits, tc.events)
+			if diff := cmp.Diff(tc.want, ids(b.Records(tc.r))); diff != "" {
+				t.Error("Unexpected Records() (-want, +got) =", diff)
+			}
+		})
+	}
+}
+
+func TestBufferSetLimits(t *testing.T) {
+	b, _ := newTestBuffer(Limits{Size: 5}, 5)
+
+	b.SetLimits(Limits{Size: 2})
+	if diff := cmp.Diff([]string{"4", "5"}, ids(b.Records(Range{}))); diff != "" {
+		t.Error("Unexpected Records() after shrinking (-want, +got) =", diff)
+	}
+
+	b.SetLimits(Limits{Size: 4})
+	if offset := b.Add(makeEvent("6"), nil); offset != 6 {
+		t.Errorf("Add() = %d, want 6", offset)
+	}
+	if diff := cmp.Diff([]string{"4", "5", "6"}, ids(b.Records(Range{}))); diff != "" {
+		t.Error("Unexpected Records() after growing (-want, +got) =", diff)
+	}
+}
@@ -47,6 +47,14 @@ var (
 		stats.UnitMilliseconds,
 	)
 
+	// circuitBreakerStateM records the state of the circuit breaker of the destination of a
+	// subscription: 0 is closed, 1 is half-open and 2 is open.
+	circuitBreakerStateM = stats.Int64(
+		"circuit_breaker_state",
+		"State of the circuit breaker of the destination, 0 is closed, 1 half-open and 2 open",
+		stats.UnitDimensionless,
+	)
+
 	// Create the tag keys that will be used to add tags to our measurements.
 	// Tag keys must conform to the restrictions described in
 	// go.opencensus.io/tag/validate.go. Currently those restrictions are:
@@ -56,6 +64,7 @@ var (
 	eventTypeKey         = tag.MustNewKey(eventingmetrics.LabelEventType)
 	responseCodeKey      = tag.MustNewKey(eventingmetrics.LabelResponseCode)
 	responseCodeClassKey = tag.MustNewKey(eventingmetrics.LabelResponseCodeClass)
+	subscriptionKey      = tag.MustNewKey(eventingmetrics.LabelSubscriptionName)
 )
 
 type ReportArgs struct {
@@ -113,6 +122,12 @@ func register() {
 			Aggregation: view.Distribution(metrics.Buckets125(1, 10000)...), // 1, 2, 5, 10, 20, 50, 100, 500, 1000, 5000, 10000
 			TagKeys:     tagKeys,
 		},
+		&view.View{
+			Description: circuitBreakerStateM.Description(),
+			Measure:     circuitBreakerStateM,
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{namespaceKey, subscriptionKey},
+		},
 	)
 	if err != nil {
 		log.Print("failed to register opencensus views, " + err.Error())
@@ -0,0 +1,305 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package wal provides a disk-backed write-ahead log of the events accepted by a channel.
+// An event is appended to the log, and synced to disk, before it is acknowledged to the sender,
+// and it is acknowledged in the log once it has been dispatched. The events that are still
+// pending when the log is opened are the events to replay.
+package wal
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+const (
+	// compactionThreshold is the minimum number of acknowledged records before the log is compacted.
+	compactionThreshold = 1000
+
+	// maxRecordSize is the maximum size of an entry of the log, including its trailing newline.
+	maxRecordSize = 64 * 1024 * 1024
+)
+
+var (
+	// ErrClosed is returned when using a closed Log.
+	ErrClosed = errors.New("write-ahead log is closed")
+
+	// ErrRecordTooLarge is returned when appending an event whose record exceeds the maximum size.
+	ErrRecordTooLarge = errors.New("write-ahead log record is too large")
+)
+
+// Record is an event accepted by a channel, persisted until it is dispatched.
+type Record struct {
+	ID uint64 `json:"id"`
+	// Headers are the additional headers dispatched with the event.
+	Headers http.Header        `json:"headers,omitempty"`
+	Event   *cloudevents.Event `json:"event"`
+}
+
+// entry is a line of the log, either an appended record or the acknowledgement of a record.
+type entry struct {
+	Record *Record `json:"record,omitempty"`
+	Ack    uint64  `json:"ack,omitempty"`
+}
+
+// Log is a write-ahead log stored in a single file, one JSON entry per line.
+type Log struct {
+	mu      sync.Mutex
+	path    string
+	file    *os.File
+	nextID  uint64
+	pending map[uint64]*Record
+	// acked is the number of records acknowledged since the last compaction.
+	acked int
+	// skipped is the number of corrupted entries skipped when loading the log.
+	skipped int
+	closed  bool
+}
+
+// Open opens the log stored at path, creating it if missing, and loads its pending records.
+// A truncated last entry, left by a crash while appending, is ignored, and the other entries that
+// can't be read are skipped, see Skipped.
+func Open(path string) (*Log, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create the write-ahead log directory: %w", err)
+	}
+	l := &Log{
+		path:    path,
+		nextID:  1,
+		pending: make(map[uint64]*Record),
+	}
+	if err := l.load(); err != nil {
+		return nil, err
+	}
+	if err := l.compact(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *Log) load() error {
+	f, err := os.Open(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open the write-ahead log: %w", err)
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// Only the last entry can be partially written, it is ignored.
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read the write-ahead log: %w", err)
+		}
+		var e entry
+		if len(line) > maxRecordSize || json.Unmarshal(line, &e) != nil {
+			// A corrupted entry doesn't prevent replaying the following ones.
+			l.skipped++
+			continue
+		}
+		if e.Record != nil && e.Record.Event != nil {
+			l.pending[e.Record.ID] = e.Record
+			if e.Record.ID >= l.nextID {
+				l.nextID = e.Record.ID + 1
+			}
+		}
+		if e.Ack != 0 {
+			delete(l.pending, e.Ack)
+		}
+	}
+}
+
+// compact rewrites the log with only the pending records, and opens it for appending.
+func (l *Log) compact() error {
+	tmp := l.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to compact the write-ahead log: %w", err)
+	}
+	w := bufio.NewWriter(f)
+	for _, r := range l.sortedPending() {
+		if err := writeEntry(w, entry{Record: r}); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to compact the write-ahead log: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to compact the write-ahead log: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to compact the write-ahead log: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to compact the write-ahead log: %w", err)
+	}
+	if err := os.Rename(tmp, l.path); err != nil {
+		return fmt.Errorf("failed to compact the write-ahead log: %w", err)
+	}
+
+	if l.file != nil {
+		_ = l.file.Close()
+	}
+	l.file, err = os.OpenFile(l.path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open the write-ahead log: %w", err)
+	}
+	l.acked = 0
+	return nil
+}
+
+func writeEntry(w *bufio.Writer, e entry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if len(b)+1 > maxRecordSize {
+		return ErrRecordTooLarge
+	}
+	if _, err := w.Write(b); err != nil {
+		return err
+	}
+	return w.WriteByte('\n')
+}
+
+func (l *Log) write(e entry, sync bool) error {
+	w := bufio.NewWriter(l.file)
+	if err := writeEntry(w, e); err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if sync {
+		return l.file.Sync()
+	}
+	return nil
+}
+
+// Append persists the event and its additional headers, and returns the ID of the record.
+// The record is synced to disk when Append returns. ErrRecordTooLarge is returned when the
+// record wouldn't fit in an entry of the log.
+func (l *Log) Append(event *cloudevents.Event, headers http.Header) (uint64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return 0, ErrClosed
+	}
+	r := &Record{ID: l.nextID, Headers: headers, Event: event}
+	if err := l.write(entry{Record: r}, true); err != nil {
+		return 0, fmt.Errorf("failed to append to the write-ahead log: %w", err)
+	}
+	l.nextID++
+	l.pending[r.ID] = r
+	return r.ID, nil
+}
+
+// Ack acknowledges the record with the given ID, which isn't replayed anymore. Acknowledgements
+// aren't synced to disk, a record whose acknowledgement is lost is replayed.
+func (l *Log) Ack(id uint64) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return ErrClosed
+	}
+	if _, ok := l.pending[id]; !ok {
+		return nil
+	}
+	if err := l.write(entry{Ack: id}, false); err != nil {
+		return fmt.Errorf("failed to acknowledge a write-ahead log record: %w", err)
+	}
+	delete(l.pending, id)
+	l.acked++
+	if l.acked >= compactionThreshold && l.acked > len(l.pending) {
+		return l.compact()
+	}
+	return nil
+}
+
+// Pending returns the records that are not acknowledged, in the order they were appended.
+func (l *Log) Pending() []Record {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	pending := l.sortedPending()
+	records := make([]Record, 0, len(pending))
+	for _, r := range pending {
+		records = append(records, *r)
+	}
+	return records
+}
+
+func (l *Log) sortedPending() []*Record {
+	records := make([]*Record, 0, len(l.pending))
+	for _, r := range l.pending {
+		records = append(records, r)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].ID < records[j].ID
+	})
+	return records
+}
+
+// Skipped returns the number of corrupted entries skipped when the log was opened, the records
+// they contained aren't replayed.
+func (l *Log) Skipped() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.skipped
+}
+
+// Path returns the path of the file storing the log.
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Close closes the log, the pending records are kept on disk.
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return nil
+	}
+	l.closed = true
+	return l.file.Close()
+}
+
+// Remove closes the log and deletes it from disk, discarding the pending records.
+func (l *Log) Remove() error {
+	if err := l.Close(); err != nil {
+		return err
+	}
+	if err := os.Remove(l.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove the write-ahead log: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wal
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/google/go-cmp/cmp"
+)
+
+func makeEvent(id string) *cloudevents.Event {
+	e := event.New()
+	e.SetID(id)
+	e.SetType("dev.knative.test")
+	e.SetSource("/test")
+	_ = e.SetData(cloudevents.ApplicationJSON, map[string]string{"id": id})
+	return &e
+}
+
+func pendingIDs(l *Log) []string {
+	var ids []string
+	for _, r := range l.Pending() {
+		ids = append(ids, r.Event.ID())
+	}
+	return ids
+}
+
+func TestLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ns", "channel.wal")
+
+	l, err := Open(path)
+	if err != nil {
+		t.Fatal("Open() =", err)
+	}
+	headers := http.Header{"Knative-Foo": {"bar"}}
+	var ids []uint64
+	for i := 0; i < 3; i++ {
+		id, err := l.Append(makeEvent(strconv.Itoa(i)), headers)
+		if err != nil {
+			t.Fatal("Append() =", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := l.Ack(ids[1]); err != nil {
+		t.Fatal("Ack() =", err)
+	}
+	if diff := cmp.Diff([]string{"0", "2"}, pendingIDs(l)); diff != "" {
+		t.Error("Unexpected Pending() (-want, +got) =", diff)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal("Close() =", err)
+	}
+	if _, err := l.Append(makeEvent("closed"), nil); err != ErrClosed {
+		t.Errorf("Append() after Close() = %v, want %v", err, ErrClosed)
+	}
+
+	// Reopening replays the pending records, with a truncated last entry.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(`{"record":{"id":4,"event":{"specversion":"1.0"`)
+	_ = f.Close()
+
+	l, err = Open(path)
+	if err != nil {
+		t.Fatal("Open() =", err)
+	}
+	pending := l.Pending()
+	if diff := cmp.Diff([]string{"0", "2"}, pendingIDs(l)); diff != "" {
+		t.Fatal("Unexpected Pending() after reopening (-want, +got) =", diff)
+	}
+	if got := pending[0].Headers.Get("Knative-Foo"); got != "bar" {
+		t.Errorf("Headers = %q, want %q", got, "bar")
+	}
+	if got := string(pending[0].Event.Data()); got != `{"id":"0"}` {
+		t.Errorf("Data = %q, want %q", got, `{"id":"0"}`)
+	}
+
+	// New records don't reuse the IDs of the pending ones.
+	id, err := l.Append(makeEvent("3"), nil)
+	if err != nil {
+		t.Fatal("Append() =", err)
+	}
+	if id <= ids[2] {
+		t.Errorf("Append() = %d, want an ID greater than %d", id, ids[2])
+	}
+
+	if err := l.Remove(); err != nil {
+		t.Fatal("Remove() =", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Stat() after Remove() = %v, want not exist", err)
+	}
+}
+
+func TestLogCorruptedEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "channel.wal")
+	l, err := Open(path)
+	if err != nil {
+		t.Fatal("Open() =", err)
+	}
+	if _, err := l.Append(makeEvent("0"), nil); err != nil {
+		t.Fatal("Append() =", err)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal("Close() =", err)
+	}
+
+	// A corrupted entry in the middle of the log, followed by valid ones.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("{\"record\":{\"id\":2,\"eve\x00\n")
+	_, _ = f.WriteString(`{"record":{"id":3,"event":{"specversion":"1.0","id":"3","type":"dev.knative.test","source":"/test"}}}` + "\n")
+	_, _ = f.WriteString(`{"ack":1}` + "\n")
+	_ = f.Close()
+
+	l, err = Open(path)
+	if err != nil {
+		t.Fatal("Open() =", err)
+	}
+	defer l.Close()
+	if diff := cmp.Diff([]string{"3"}, pendingIDs(l)); diff != "" {
+		t.Error("Unexpected Pending() after reopening (-want, +got) =", diff)
+	}
+	if got := l.Skipped(); got != 1 {
+		t.Errorf("Skipped() = %d, want 1", got)
+	}
+}
+
+func TestLogCompaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "channel.wal")
+	l, err := Open(path)
+	if err != nil {
+		t.Fatal("Open() =", err)
+	}
+	defer l.Close()
+
+	for i := 0; i < compactionThreshold+1; i++ {
+		id, err := l.Append(makeEvent(strconv.Itoa(i)), nil)
+		if err != nil {
+			t.Fatal("Append() =", err)
+		}
+		if i == compactionThreshold {
+			break
+		}
+		if err := l.Ack(id); err != nil {
+			t.Fatal("Ack() =", err)
+		}
+	}
+
+	before, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The log is compacted once enough records are acknowledged, keeping only the pending one.
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatal("Open() =", err)
+	}
+	defer reopened.Close()
+	if diff := cmp.Diff([]string{strconv.Itoa(compactionThreshold)}, pendingIDs(reopened)); diff != "" {
+		t.Error("Unexpected Pending() (-want, +got) =", diff)
+	}
+	if before.Size() > 1024 {
+		t.Errorf("log size = %d, want a compacted log", before.Size())
+	}
+}
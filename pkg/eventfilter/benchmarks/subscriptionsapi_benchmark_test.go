@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmarks
+
+import (
+	"context"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	cetest "github.com/cloudevents/sdk-go/v2/test"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/eventfilter"
+	"knative.dev/eventing/pkg/eventfilter/subscriptionsapi"
+)
+
+func BenchmarkSubscriptionsAPIFilters(b *testing.B) {
+	event := cetest.FullEvent()
+
+	RunFilterBenchmarks(b,
+		func(i interface{}) eventfilter.Filter {
+			return subscriptionsapi.CreateSubscriptionsAPIFilters(context.TODO(), i.([]eventingv1.SubscriptionsAPIFilter))
+		},
+		subscriptionsAPIFilterBenchmarks(event)...,
+	)
+}
+
+// BenchmarkSubscriptionsAPIFiltersDispatch compares the dispatch of an event when the filters are
+// materialized for each event (uncached) with the dispatch using pre-materialized filters (cached).
+func BenchmarkSubscriptionsAPIFiltersDispatch(b *testing.B) {
+	event := cetest.FullEvent()
+
+	for _, fb := range subscriptionsAPIFilterBenchmarks(event) {
+		filters := fb.arg.([]eventingv1.SubscriptionsAPIFilter)
+		b.Run("Uncached: "+fb.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Result = subscriptionsapi.CreateSubscriptionsAPIFilters(context.TODO(), filters).Filter(context.TODO(), fb.event)
+			}
+		})
+		f := subscriptionsapi.CreateSubscriptionsAPIFilters(context.TODO(), filters)
+		b.Run("Cached: "+fb.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Result = f.Filter(context.TODO(), fb.event)
+			}
+		})
+	}
+}
+
+func subscriptionsAPIFilterBenchmarks(event cloudevents.Event) []FilterBenchmark {
+	return []FilterBenchmark{
+		{
+			name:  "Pass with exact match of id",
+			arg:   []eventingv1.SubscriptionsAPIFilter{{Exact: map[string]string{"id": event.ID()}}},
+			event: event,
+		},
+		{
+			name:  "Pass with CESQL match of type and source",
+			arg:   []eventingv1.SubscriptionsAPIFilter{{CESQL: "type = '" + event.Type() + "' AND source = '" + event.Source() + "'"}},
+			event: event,
+		},
+		{
+			name: "Pass with nested all, any and not filters",
+			arg: []eventingv1.SubscriptionsAPIFilter{{
+				All: []eventingv1.SubscriptionsAPIFilter{
+					{Prefix: map[string]string{"type": event.Type()[:1]}},
+					{Any: []eventingv1.SubscriptionsAPIFilter{
+						{CESQL: "source = '" + event.Source() + "'"},
+						{Suffix: map[string]string{"subject": "qwertyuiopasdfghjklzxcvbnm"}},
+					}},
+					{Not: &eventingv1.SubscriptionsAPIFilter{CESQL: "id = 'qwertyuiopasdfghjklzxcvbnm'"}},
+				},
+			}},
+			event: event,
+		},
+		{
+			name:  "No pass with CESQL match of id",
+			arg:   []eventingv1.SubscriptionsAPIFilter{{CESQL: "id = 'qwertyuiopasdfghjklzxcvbnm'"}},
+			event: event,
+		},
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subscriptionsapi
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"knative.dev/pkg/logging"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/eventfilter"
+)
+
+// CreateSubscriptionsAPIFilters materializes the given list of SubscriptionsAPIFilter into a single
+// event filter which passes if all the contained filters pass.
+func CreateSubscriptionsAPIFilters(ctx context.Context, filters []eventingv1.SubscriptionsAPIFilter) eventfilter.Filter {
+	return NewAllFilter(MaterializeFiltersList(ctx, filters)...)
+}
+
+// MaterializeSubscriptionsAPIFilter builds the event filter described by the given SubscriptionsAPIFilter.
+// It returns nil if the filter is not valid.
+func MaterializeSubscriptionsAPIFilter(ctx context.Context, filter eventingv1.SubscriptionsAPIFilter) eventfilter.Filter {
+	var materializedFilter eventfilter.Filter
+	var err error
+	switch {
+	case len(filter.Exact) > 0:
+		// The webhook validates that this map has only a single key:value pair.
+		materializedFilter, err = NewExactFilter(filter.Exact)
+		if err != nil {
+			logging.FromContext(ctx).Debugw("Invalid exact expression", zap.Any("filters", filter.Exact), zap.Error(err))
+			return nil
+		}
+	case len(filter.Prefix) > 0:
+		// The webhook validates that this map has only a single key:value pair.
+		materializedFilter, err = NewPrefixFilter(filter.Prefix)
+		if err != nil {
+			logging.FromContext(ctx).Debugw("Invalid prefix expression", zap.Any("filters", filter.Prefix), zap.Error(err))
+			return nil
+		}
+	case len(filter.Suffix) > 0:
+		// The webhook validates that this map has only a single key:value pair.
+		materializedFilter, err = NewSuffixFilter(filter.Suffix)
+		if err != nil {
+			logging.FromContext(ctx).Debugw("Invalid suffix expression", zap.Any("filters", filter.Suffix), zap.Error(err))
+			return nil
+		}
+	case len(filter.All) > 0:
+		materializedFilter = NewAllFilter(MaterializeFiltersList(ctx, filter.All)...)
+	case len(filter.Any) > 0:
+		materializedFilter = NewAnyFilter(MaterializeFiltersList(ctx, filter.Any)...)
+	case filter.Not != nil:
+		materializedFilter = NewNotFilter(MaterializeSubscriptionsAPIFilter(ctx, *filter.Not))
+	case filter.CESQL != "":
+		if materializedFilter, err = NewCESQLFilter(filter.CESQL); err != nil {
+			// This is weird, CESQL expression should be validated when Trigger's are created.
+			logging.FromContext(ctx).Debugw("Found an Invalid CE SQL expression", zap.String("expression", filter.CESQL))
+			return nil
+		}
+	}
+	return materializedFilter
+}
+
+// MaterializeFiltersList builds the event filters described by the given list, skipping the invalid ones.
+func MaterializeFiltersList(ctx context.Context, filters []eventingv1.SubscriptionsAPIFilter) []eventfilter.Filter {
+	materializedFilters := make([]eventfilter.Filter, 0, len(filters))
+	for _, filter := range filters {
+		f := MaterializeSubscriptionsAPIFilter(ctx, filter)
+		if f == nil {
+			logging.FromContext(ctx).Warnw("Failed to parse filter. Skipping filter.", zap.Any("filter", filter))
+			continue
+		}
+		materializedFilters = append(materializedFilters, f)
+	}
+	return materializedFilters
+}
@@ -20,7 +20,9 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"math/rand"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/rickb777/date/period"
@@ -28,6 +30,14 @@ import (
 	v1 "knative.dev/eventing/pkg/apis/duck/v1"
 )
 
+const (
+	// DefaultCircuitBreakerFailureThreshold is the default number of consecutive failed deliveries
+	// opening a circuit breaker.
+	DefaultCircuitBreakerFailureThreshold = 5
+	// DefaultCircuitBreakerOpenDuration is the default duration a circuit breaker stays open.
+	DefaultCircuitBreakerOpenDuration = 30 * time.Second
+)
+
 var noRetries = RetryConfig{
 	RetryMax: 0,
 	CheckRetry: func(ctx context.Context, resp *http.Response, err error) (bool, error) {
@@ -56,11 +66,15 @@ type Backoff func(attemptNum int, resp *http.Response) time.Duration
 type RetryConfig struct {
 	// Maximum number of retries
 	RetryMax int
-	// These next two variables are just copied from the original DeliverySpec so
+	// These next variables are just copied from the original DeliverySpec so
 	// we can detect if anything has changed. We can not do that with the CheckRetry
 	// Backoff (at least not easily).
-	BackoffDelay  *string
-	BackoffPolicy *v1.BackoffPolicyType
+	BackoffDelay    *string
+	BackoffPolicy   *v1.BackoffPolicyType
+	BackoffMaxDelay *string
+	BackoffJitter   *v1.BackoffJitterType
+	// RetryStatusCodes is copied for the same reason, CheckRetry honours it.
+	RetryStatusCodes *v1.RetryStatusCodesSpec
 
 	CheckRetry CheckRetry
 	Backoff    Backoff
@@ -73,6 +87,20 @@ type RetryConfig struct {
 	// value indicates no maximum override.  A value of "0" indicates "Retry-After"
 	// headers are to be ignored.
 	RetryAfterMaxDuration *time.Duration
+
+	// CircuitBreaker configures the circuit breaker of the destination, nil disables it.
+	CircuitBreaker *CircuitBreakerConfig
+
+	// DeadLetterPayload is what is sent to the dead letter sink, empty means the original event.
+	DeadLetterPayload v1.DeadLetterPayloadType
+}
+
+// CircuitBreakerConfig configures the circuit breaker of a destination.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failed deliveries opening the circuit breaker.
+	FailureThreshold int
+	// OpenDuration is how long the circuit breaker stays open before letting a trial event through.
+	OpenDuration time.Duration
 }
 
 func NoRetries() RetryConfig {
@@ -90,6 +118,8 @@ func RetryConfigFromDeliverySpec(spec v1.DeliverySpec) (RetryConfig, error) {
 	}
 	retryConfig.BackoffPolicy = spec.BackoffPolicy
 	retryConfig.BackoffDelay = spec.BackoffDelay
+	retryConfig.BackoffMaxDelay = spec.BackoffMaxDelay
+	retryConfig.BackoffJitter = spec.BackoffJitter
 
 	if spec.BackoffPolicy != nil && spec.BackoffDelay != nil {
 
@@ -99,14 +129,27 @@ func RetryConfigFromDeliverySpec(spec v1.DeliverySpec) (RetryConfig, error) {
 		}
 
 		delayDuration, _ := delay.Duration()
+		maxDelay := time.Duration(math.MaxInt64)
+		if spec.BackoffMaxDelay != nil {
+			maxPeriod, err := period.Parse(*spec.BackoffMaxDelay)
+			if err != nil {
+				return retryConfig, fmt.Errorf("failed to parse Spec.BackoffMaxDelay: %w", err)
+			}
+			maxDelay, _ = maxPeriod.Duration()
+		}
+		jitter := v1.BackoffJitterNone
+		if spec.BackoffJitter != nil {
+			jitter = *spec.BackoffJitter
+		}
+
 		switch *spec.BackoffPolicy {
 		case v1.BackoffPolicyExponential:
 			retryConfig.Backoff = func(attemptNum int, resp *http.Response) time.Duration {
-				return delayDuration * time.Duration(math.Exp2(float64(attemptNum)))
+				return withJitter(capDelay(float64(delayDuration)*math.Exp2(float64(attemptNum)), maxDelay), jitter)
 			}
 		case v1.BackoffPolicyLinear:
 			retryConfig.Backoff = func(attemptNum int, resp *http.Response) time.Duration {
-				return delayDuration * time.Duration(attemptNum)
+				return withJitter(capDelay(float64(delayDuration)*float64(attemptNum), maxDelay), jitter)
 			}
 		}
 	}
@@ -128,9 +171,132 @@ func RetryConfigFromDeliverySpec(spec v1.DeliverySpec) (RetryConfig, error) {
 		retryConfig.RetryAfterMaxDuration = &maxDuration
 	}
 
+	if spec.RetryStatusCodes != nil {
+		checkRetry, err := statusCodesRetry(*spec.RetryStatusCodes)
+		if err != nil {
+			return retryConfig, fmt.Errorf("failed to parse Spec.RetryStatusCodes: %w", err)
+		}
+		retryConfig.RetryStatusCodes = spec.RetryStatusCodes
+		retryConfig.CheckRetry = checkRetry
+	}
+
+	if spec.DeadLetterPayload != nil {
+		retryConfig.DeadLetterPayload = *spec.DeadLetterPayload
+	}
+
+	if spec.CircuitBreaker != nil {
+		circuitBreaker := CircuitBreakerConfig{
+			FailureThreshold: DefaultCircuitBreakerFailureThreshold,
+			OpenDuration:     DefaultCircuitBreakerOpenDuration,
+		}
+		if spec.CircuitBreaker.FailureThreshold != nil {
+			circuitBreaker.FailureThreshold = int(*spec.CircuitBreaker.FailureThreshold)
+		}
+		if spec.CircuitBreaker.OpenDuration != nil {
+			openPeriod, err := period.Parse(*spec.CircuitBreaker.OpenDuration)
+			if err != nil {
+				return retryConfig, fmt.Errorf("failed to parse Spec.CircuitBreaker.OpenDuration: %w", err)
+			}
+			circuitBreaker.OpenDuration, _ = openPeriod.Duration()
+		}
+		retryConfig.CircuitBreaker = &circuitBreaker
+	}
+
 	return retryConfig, nil
 }
 
+// CountAttempts returns a copy of config counting the attempts of the requests sent with it, and a
+// function returning the number of attempts so far. Without config, a single attempt is made.
+func CountAttempts(config *RetryConfig) (*RetryConfig, func() int) {
+	if config == nil {
+		return nil, func() int { return 1 }
+	}
+	var attempts atomic.Int32
+	counting := *config
+	checkRetry := config.CheckRetry
+	counting.CheckRetry = func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		attempts.Add(1)
+		return checkRetry(ctx, resp, err)
+	}
+	return &counting, func() int { return int(attempts.Load()) }
+}
+
+// statusCodesRetry returns a CheckRetry retrying the responses with a retryable status code, and
+// not retrying those with a non-retryable status code. The other responses are retried according
+// to SelectiveRetry.
+func statusCodesRetry(spec v1.RetryStatusCodesSpec) (CheckRetry, error) {
+	parse := func(codes []string) ([]v1.StatusCodeRange, error) {
+		ranges := make([]v1.StatusCodeRange, 0, len(codes))
+		for _, code := range codes {
+			r, err := v1.ParseStatusCodeRange(code)
+			if err != nil {
+				return nil, err
+			}
+			ranges = append(ranges, r)
+		}
+		return ranges, nil
+	}
+	contains := func(ranges []v1.StatusCodeRange, code int) bool {
+		for _, r := range ranges {
+			if r.Contains(code) {
+				return true
+			}
+		}
+		return false
+	}
+
+	retryable, err := parse(spec.Retryable)
+	if err != nil {
+		return nil, err
+	}
+	nonRetryable, err := parse(spec.NonRetryable)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, response *http.Response, err error) (bool, error) {
+		if response != nil && err == nil {
+			if contains(nonRetryable, response.StatusCode) {
+				return false, nil
+			}
+			if contains(retryable, response.StatusCode) {
+				return true, nil
+			}
+		}
+		return SelectiveRetry(ctx, response, err)
+	}, nil
+}
+
+// capDelay converts the delay to a duration, capped to maxDelay. The delay is computed as a float
+// so that large numbers of attempts don't overflow.
+func capDelay(delay float64, maxDelay time.Duration) time.Duration {
+	if delay >= float64(maxDelay) {
+		return maxDelay
+	}
+	return time.Duration(delay)
+}
+
+// randInt63n returns a random number in [0, n), it is a variable so that tests can replace it.
+var randInt63n = rand.Int63n
+
+// withJitter randomizes the delay according to the jitter.
+func withJitter(delay time.Duration, jitter v1.BackoffJitterType) time.Duration {
+	if delay <= 0 {
+		return delay
+	}
+	switch jitter {
+	case v1.BackoffJitterFull:
+		if delay == math.MaxInt64 {
+			return time.Duration(randInt63n(math.MaxInt64))
+		}
+		return time.Duration(randInt63n(int64(delay) + 1))
+	case v1.BackoffJitterEqual:
+		half := delay / 2
+		return delay - half + time.Duration(randInt63n(int64(half)+1))
+	default:
+		return delay
+	}
+}
+
 // SelectiveRetry is an alternative function to determine whether to retry based on response
 //
 // Note - Returning true indicates a retry should occur.  Returning an error will result in that
@@ -19,6 +19,7 @@ package kncloudevents
 import (
 	"context"
 	"errors"
+	"math"
 	"net/http"
 	"testing"
 	"time"
@@ -180,6 +181,197 @@ func TestRetryConfigFromDeliverySpec(t *testing.T) {
 	}
 }
 
+func TestRetryConfigFromDeliverySpecCircuitBreaker(t *testing.T) {
+	openDuration := "PT1M"
+	invalidDuration := "1m"
+	testcases := []struct {
+		name           string
+		circuitBreaker *v1.CircuitBreakerSpec
+		want           *CircuitBreakerConfig
+		wantErr        bool
+	}{{
+		name: "No circuit breaker",
+	}, {
+		name:           "Default circuit breaker",
+		circuitBreaker: &v1.CircuitBreakerSpec{},
+		want: &CircuitBreakerConfig{
+			FailureThreshold: DefaultCircuitBreakerFailureThreshold,
+			OpenDuration:     DefaultCircuitBreakerOpenDuration,
+		},
+	}, {
+		name: "Configured circuit breaker",
+		circuitBreaker: &v1.CircuitBreakerSpec{
+			FailureThreshold: ptr.Int32(2),
+			OpenDuration:     &openDuration,
+		},
+		want: &CircuitBreakerConfig{
+			FailureThreshold: 2,
+			OpenDuration:     time.Minute,
+		},
+	}, {
+		name:           "Invalid OpenDuration",
+		circuitBreaker: &v1.CircuitBreakerSpec{OpenDuration: &invalidDuration},
+		wantErr:        true,
+	}}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			retryConfig, err := RetryConfigFromDeliverySpec(v1.DeliverySpec{CircuitBreaker: tc.circuitBreaker})
+			assert.Equal(t, tc.wantErr, err != nil)
+			if err == nil {
+				assert.Equal(t, tc.want, retryConfig.CircuitBreaker)
+			}
+		})
+	}
+}
+
+func TestRetryConfigFromDeliverySpecBackoffLimits(t *testing.T) {
+	// Replace the random source with the largest value, then with zero.
+	defer func(r func(int64) int64) { randInt63n = r }(randInt63n)
+	randMax := func(n int64) int64 { return n - 1 }
+	randZero := func(int64) int64 { return 0 }
+
+	linear := v1.BackoffPolicyLinear
+	exponential := v1.BackoffPolicyExponential
+	full := v1.BackoffJitterFull
+	equal := v1.BackoffJitterEqual
+	delay := "PT1S"
+	maxDelay := "PT10S"
+	invalidDelay := "10s"
+
+	testcases := []struct {
+		name    string
+		spec    v1.DeliverySpec
+		attempt int
+		rand    func(int64) int64
+		want    time.Duration
+		wantErr bool
+	}{{
+		name:    "Exponential without cap",
+		spec:    v1.DeliverySpec{BackoffPolicy: &exponential, BackoffDelay: &delay},
+		attempt: 5,
+		want:    32 * time.Second,
+	}, {
+		name:    "Exponential capped",
+		spec:    v1.DeliverySpec{BackoffPolicy: &exponential, BackoffDelay: &delay, BackoffMaxDelay: &maxDelay},
+		attempt: 5,
+		want:    10 * time.Second,
+	}, {
+		name:    "Exponential capped without overflow",
+		spec:    v1.DeliverySpec{BackoffPolicy: &exponential, BackoffDelay: &delay, BackoffMaxDelay: &maxDelay},
+		attempt: 100,
+		want:    10 * time.Second,
+	}, {
+		name:    "Exponential without cap nor overflow",
+		spec:    v1.DeliverySpec{BackoffPolicy: &exponential, BackoffDelay: &delay},
+		attempt: 100,
+		want:    time.Duration(math.MaxInt64),
+	}, {
+		name:    "Linear capped",
+		spec:    v1.DeliverySpec{BackoffPolicy: &linear, BackoffDelay: &delay, BackoffMaxDelay: &maxDelay},
+		attempt: 20,
+		want:    10 * time.Second,
+	}, {
+		name:    "Full jitter upper bound",
+		spec:    v1.DeliverySpec{BackoffPolicy: &linear, BackoffDelay: &delay, BackoffJitter: &full},
+		attempt: 4,
+		rand:    randMax,
+		want:    4 * time.Second,
+	}, {
+		name:    "Full jitter lower bound",
+		spec:    v1.DeliverySpec{BackoffPolicy: &linear, BackoffDelay: &delay, BackoffJitter: &full},
+		attempt: 4,
+		rand:    randZero,
+		want:    0,
+	}, {
+		name:    "Equal jitter lower bound",
+		spec:    v1.DeliverySpec{BackoffPolicy: &exponential, BackoffDelay: &delay, BackoffMaxDelay: &maxDelay, BackoffJitter: &equal},
+		attempt: 5,
+		rand:    randZero,
+		want:    5 * time.Second,
+	}, {
+		name:    "Equal jitter upper bound",
+		spec:    v1.DeliverySpec{BackoffPolicy: &exponential, BackoffDelay: &delay, BackoffMaxDelay: &maxDelay, BackoffJitter: &equal},
+		attempt: 5,
+		rand:    randMax,
+		want:    10 * time.Second,
+	}, {
+		name:    "Invalid BackoffMaxDelay",
+		spec:    v1.DeliverySpec{BackoffPolicy: &linear, BackoffDelay: &delay, BackoffMaxDelay: &invalidDelay},
+		wantErr: true,
+	}}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			randInt63n = tc.rand
+			retryConfig, err := RetryConfigFromDeliverySpec(tc.spec)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if err == nil {
+				assert.Equal(t, tc.want, retryConfig.Backoff(tc.attempt, nil))
+			}
+		})
+	}
+}
+
+func TestRetryConfigFromDeliverySpecRetryStatusCodes(t *testing.T) {
+	spec := v1.DeliverySpec{
+		RetryStatusCodes: &v1.RetryStatusCodesSpec{
+			Retryable:    []string{"425", "520-529"},
+			NonRetryable: []string{"409", "525"},
+		},
+	}
+	retryConfig, err := RetryConfigFromDeliverySpec(spec)
+	assert.Nil(t, err)
+	assert.Equal(t, spec.RetryStatusCodes, retryConfig.RetryStatusCodes)
+
+	for code, want := range map[int]bool{
+		http.StatusConflict:            false, // non-retryable
+		http.StatusTooEarly:            true,  // retryable
+		522:                            true,  // retryable range
+		525:                            false, // non-retryable takes precedence
+		http.StatusNotFound:            true,  // default
+		http.StatusBadRequest:          false, // default
+		http.StatusInternalServerError: true,  // default
+	} {
+		got, err := retryConfig.CheckRetry(context.Background(), &http.Response{StatusCode: code}, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, want, got, "status code %d", code)
+	}
+
+	got, _ := retryConfig.CheckRetry(context.Background(), nil, errors.New("connection refused"))
+	assert.True(t, got)
+
+	_, err = RetryConfigFromDeliverySpec(v1.DeliverySpec{
+		RetryStatusCodes: &v1.RetryStatusCodesSpec{Retryable: []string{"5xx"}},
+	})
+	assert.NotNil(t, err)
+}
+
+func TestRetryConfigFromDeliverySpecDeadLetterPayload(t *testing.T) {
+	retryConfig, err := RetryConfigFromDeliverySpec(v1.DeliverySpec{})
+	assert.Nil(t, err)
+	assert.Equal(t, v1.DeadLetterPayloadType(""), retryConfig.DeadLetterPayload)
+
+	reference := v1.DeadLetterPayloadReference
+	retryConfig, err = RetryConfigFromDeliverySpec(v1.DeliverySpec{DeadLetterPayload: &reference})
+	assert.Nil(t, err)
+	assert.Equal(t, reference, retryConfig.DeadLetterPayload)
+}
+
+func TestCountAttempts(t *testing.T) {
+	config, attempts := CountAttempts(nil)
+	assert.Nil(t, config)
+	assert.Equal(t, 1, attempts())
+
+	retryConfig := RetryConfig{RetryMax: 2, CheckRetry: SelectiveRetry}
+	config, attempts = CountAttempts(&retryConfig)
+	for i := 0; i < 3; i++ {
+		_, _ = config.CheckRetry(context.Background(), &http.Response{StatusCode: http.StatusBadGateway}, nil)
+	}
+	assert.Equal(t, 3, attempts())
+	assert.Equal(t, 2, config.RetryMax)
+}
+
 func TestSelectiveRetry(t *testing.T) {
 
 	// Define The TestCase Type
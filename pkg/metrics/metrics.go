@@ -66,6 +66,9 @@ const (
 	// LabelResponseError is the label for client error. For HTTP, A non-2xx status code doesn't cause an error.
 	LabelResponseError = metricskey.LabelResponseError
 
+	// LabelSubscriptionName is the label for the name of the Subscription.
+	LabelSubscriptionName = "subscription_name"
+
 	// LabelResponseTimeout is the label timeout.
 	LabelResponseTimeout = metricskey.LabelResponseTimeout
 )
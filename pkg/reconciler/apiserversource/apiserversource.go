@@ -60,6 +60,11 @@ func newWarningSinkNotFound(sink *duckv1.Destination) pkgreconciler.Event {
 	return pkgreconciler.NewEvent(corev1.EventTypeWarning, "SinkNotFound", "Sink not found: %s", string(b))
 }
 
+func newWarningDeadLetterSinkNotFound(sink *duckv1.Destination) pkgreconciler.Event {
+	b, _ := json.Marshal(sink)
+	return pkgreconciler.NewEvent(corev1.EventTypeWarning, "DeadLetterSinkNotFound", "Dead letter sink not found: %s", string(b))
+}
+
 // Reconciler reconciles a ApiServerSource object
 type Reconciler struct {
 	kubeClientSet kubernetes.Interface
@@ -101,6 +106,21 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, source *v1.ApiServerSour
 	}
 	source.Status.MarkSink(sinkURI)
 
+	if source.Spec.Delivery != nil && source.Spec.Delivery.DeadLetterSink != nil {
+		dls := source.Spec.Delivery.DeadLetterSink.DeepCopy()
+		if dls.Ref != nil && dls.Ref.Namespace == "" {
+			dls.Ref.Namespace = source.GetNamespace()
+		}
+		deadLetterSinkURI, err := r.sinkResolver.URIFromDestinationV1(ctx, *dls, source)
+		if err != nil {
+			source.Status.MarkNoDeadLetterSink("NotFound", "")
+			return newWarningDeadLetterSinkNotFound(dls)
+		}
+		source.Status.MarkDeadLetterSink(deadLetterSinkURI)
+	} else {
+		source.Status.MarkDeadLetterSink(nil)
+	}
+
 	// resolve namespaces to watch
 	namespaces, err := r.namespacesFromSelector(source)
 	if err != nil {
@@ -176,13 +196,14 @@ func (r *Reconciler) createReceiveAdapter(ctx context.Context, src *v1.ApiServer
 	// }
 
 	adapterArgs := resources.ReceiveAdapterArgs{
-		Image:         r.receiveAdapterImage,
-		Source:        src,
-		Labels:        resources.Labels(src.Name),
-		SinkURI:       sinkURI,
-		Configs:       r.configs,
-		Namespaces:    namespaces,
-		AllNamespaces: allNamespaces,
+		Image:             r.receiveAdapterImage,
+		Source:            src,
+		Labels:            resources.Labels(src.Name),
+		SinkURI:           sinkURI,
+		Configs:           r.configs,
+		Namespaces:        namespaces,
+		AllNamespaces:     allNamespaces,
+		DeadLetterSinkURI: src.Status.DeadLetterSinkURI,
 	}
 	expected, err := resources.MakeReceiveAdapter(&adapterArgs)
 	if err != nil {
@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
+	"knative.dev/pkg/apis"
 	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/ptr"
 	"knative.dev/pkg/system"
@@ -48,6 +49,8 @@ type ReceiveAdapterArgs struct {
 	Configs       reconcilersource.ConfigAccessor
 	Namespaces    []string
 	AllNamespaces bool
+	// DeadLetterSinkURI is the resolved dead letter sink of the source delivery, if any.
+	DeadLetterSinkURI *apis.URL
 }
 
 // MakeReceiveAdapter generates (but does not insert into K8s) the Receive Adapter Deployment for
@@ -125,6 +128,13 @@ func makeEnv(args *ReceiveAdapterArgs) ([]corev1.EnvVar, error) {
 		ResourceOwner: args.Source.Spec.ResourceOwner,
 		EventMode:     args.Source.Spec.EventMode,
 		AllNamespaces: args.AllNamespaces,
+		Delivery:      args.Source.Spec.Delivery,
+
+		Filters:           args.Source.Spec.Filters,
+		OnlyStatusChanges: args.Source.Spec.OnlyStatusChanges,
+	}
+	if args.Source.Spec.Delivery != nil {
+		cfg.DeadLetterSinkURI = args.DeadLetterSinkURI
 	}
 
 	for _, r := range args.Source.Spec.Resources {
@@ -134,7 +144,7 @@ func makeEnv(args *ReceiveAdapterArgs) ([]corev1.EnvVar, error) {
 		}
 		gvr, _ := meta.UnsafeGuessKindToResource(gv.WithKind(r.Kind))
 
-		rw := apiserver.ResourceWatch{GVR: gvr}
+		rw := apiserver.ResourceWatch{GVR: gvr, FieldSelector: r.FieldSelector}
 
 		if r.LabelSelector != nil {
 			selector, _ := metav1.LabelSelectorAsSelector(r.LabelSelector)
@@ -31,6 +31,7 @@ import (
 	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/ptr"
 
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	v1 "knative.dev/eventing/pkg/apis/sources/v1"
 	"knative.dev/eventing/pkg/reconciler/source"
 
@@ -186,6 +187,15 @@ func TestMakeReceiveAdapters(t *testing.T) {
 		Value: `{"extensions":{"1":"one"}}`,
 	})
 
+	filterSrc := src.DeepCopy()
+	filterSrc.Spec.Resources[2].FieldSelector = "status.phase=Running"
+	filterSrc.Spec.Filters = []eventingduckv1.SubscriptionsAPIFilter{{
+		Exact: map[string]string{"type": "dev.knative.apiserver.resource.update"},
+	}}
+	filterSrc.Spec.OnlyStatusChanges = true
+	filterWant := want.DeepCopy()
+	filterWant.Spec.Template.Spec.Containers[0].Env[1].Value = `{"namespaces":["source-namespace"],"allNamespaces":false,"resources":[{"gvr":{"Group":"","Version":"","Resource":"namespaces"}},{"gvr":{"Group":"batch","Version":"v1","Resource":"jobs"}},{"gvr":{"Group":"","Version":"","Resource":"pods"},"selector":"test-key1=test-value1","fieldSelector":"status.phase=Running"}],"owner":{"apiVersion":"custom/v1","kind":"Parent"},"mode":"Resource","filters":[{"exact":{"type":"dev.knative.apiserver.resource.update"}}],"onlyStatusChanges":true}`
+
 	testCases := map[string]struct {
 		want *appsv1.Deployment
 		src  *v1.ApiServerSource
@@ -197,6 +207,9 @@ func TestMakeReceiveAdapters(t *testing.T) {
 		}, "TestMakeReceiveAdapterWithExtensionOverride": {
 			src:  ceSrc,
 			want: ceWant,
+		}, "TestMakeReceiveAdapterWithFilters": {
+			src:  filterSrc,
+			want: filterWant,
 		},
 	}
 	for n, tc := range testCases {
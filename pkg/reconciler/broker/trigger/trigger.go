@@ -29,6 +29,7 @@ import (
 	"k8s.io/client-go/dynamic"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/apis/eventing"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
 	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
@@ -123,6 +124,10 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, t *eventingv1.Trigger) p
 		return err
 	}
 	t.Status.SubscriberURI = subscriberURI
+
+	if err := r.resolveReply(ctx, b, t); err != nil {
+		return err
+	}
 	t.Status.MarkSubscriberResolvedSucceeded()
 
 	if err := r.resolveDeadLetterSink(ctx, b, t); err != nil {
@@ -144,6 +149,28 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, t *eventingv1.Trigger) p
 	return nil
 }
 
+// resolveReply resolves the destination of the subscriber replies, when the trigger doesn't
+// send them back to its broker.
+func (r *Reconciler) resolveReply(ctx context.Context, b *eventingv1.Broker, t *eventingv1.Trigger) error {
+	if t.Spec.Reply == nil {
+		t.Status.ReplyURI = nil
+		return nil
+	}
+	if t.Spec.Reply.Ref != nil && t.Spec.Reply.Ref.Namespace == "" {
+		t.Spec.Reply.Ref.Namespace = t.GetNamespace()
+	}
+
+	replyURI, err := r.uriResolver.URIFromDestinationV1(ctx, *t.Spec.Reply, b)
+	if err != nil {
+		logging.FromContext(ctx).Errorw("Unable to get the Reply's URI", zap.Error(err))
+		t.Status.MarkSubscriberResolvedFailed("Unable to get the Reply's URI", "%v", err)
+		t.Status.ReplyURI = nil
+		return err
+	}
+	t.Status.ReplyURI = replyURI
+	return nil
+}
+
 func (r *Reconciler) resolveDeadLetterSink(ctx context.Context, b *eventingv1.Broker, t *eventingv1.Trigger) error {
 	// resolve the trigger's dls first, fall back to the broker's
 	if t.Spec.Delivery != nil && t.Spec.Delivery.DeadLetterSink != nil {
@@ -194,9 +221,9 @@ func (r *Reconciler) subscribeToBrokerChannel(ctx context.Context, b *eventingv1
 		Namespace:  b.Namespace,
 	}
 
-	delivery := t.Spec.Delivery
-	if delivery == nil {
-		delivery = b.Spec.Delivery
+	delivery := b.Spec.Delivery
+	if t.Spec.Delivery != nil {
+		delivery = filterOwnedDelivery(t.Spec.Delivery)
 	}
 
 	expected := resources.NewSubscription(t, brokerTrigger, brokerObjRef, uri, delivery)
@@ -225,9 +252,23 @@ func (r *Reconciler) subscribeToBrokerChannel(ctx context.Context, b *eventingv1
 	return sub, nil
 }
 
+// filterOwnedDelivery returns the delivery of the Subscription of a Trigger with its own
+// delivery spec. The broker filter retries the Trigger's subscriber and sends the failed
+// events to the dead letter sink itself, so the channel delivers to the filter once,
+// otherwise every retry of the channel would be retried again by the filter.
+func filterOwnedDelivery(d *eventingduckv1.DeliverySpec) *eventingduckv1.DeliverySpec {
+	if d.Ordering == nil {
+		return nil
+	}
+	return &eventingduckv1.DeliverySpec{Ordering: d.Ordering}
+}
+
 func (r *Reconciler) reconcileSubscription(ctx context.Context, t *eventingv1.Trigger, expected, actual *messagingv1.Subscription) (*messagingv1.Subscription, error) {
 	// Update Subscription if it has changed.
-	if equality.Semantic.DeepDerivative(expected.Spec, actual.Spec) {
+	// DeepDerivative ignores the delivery unset in the expected Subscription, so the retries and
+	// dead letter sink owned by the broker filter are compared on their own.
+	if equality.Semantic.DeepDerivative(expected.Spec, actual.Spec) &&
+		(t.Spec.Delivery == nil || equality.Semantic.DeepEqual(expected.Spec.Delivery, actual.Spec.Delivery)) {
 		return actual, nil
 	}
 	recorder := controller.GetEventRecorder(ctx)
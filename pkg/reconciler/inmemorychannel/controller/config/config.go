@@ -17,9 +17,14 @@ limitations under the License.
 package config
 
 import (
+	"fmt"
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	"knative.dev/pkg/configmap"
 
+	"knative.dev/eventing/pkg/channel/fanout"
+	"knative.dev/eventing/pkg/channel/retention"
 	"knative.dev/eventing/pkg/kncloudevents"
 )
 
@@ -30,6 +35,16 @@ const (
 	// based on what serving is doing. See https://github.com/knative/serving/blob/main/pkg/network/transports.go.
 	defaultMaxIdleConnections        = 1000
 	defaultMaxIdleConnectionsPerHost = 100
+
+	// Defaults bounding the events dispatched concurrently by the dispatcher, per channel.
+	// The channels accept any number of events by default, like before the limit was added.
+	defaultMaxInFlightEvents   = 0
+	defaultSubscriberWorkers   = 16
+	defaultSubscriberQueueSize = 1000
+
+	// The retention of the events to replay is disabled by default.
+	defaultRetainedEvents  = 0
+	defaultRetentionPeriod = time.Hour
 )
 
 // EventDispatcherConfigMap is the name of the configmap for event dispatcher.
@@ -40,26 +55,58 @@ var defaultEventDispatcherConfig = EventDispatcherConfig{
 		MaxIdleConns:        defaultMaxIdleConnections,
 		MaxIdleConnsPerHost: defaultMaxIdleConnectionsPerHost,
 	},
+	DispatchLimits: fanout.DispatchLimits{
+		MaxInFlight:         defaultMaxInFlightEvents,
+		SubscriberWorkers:   defaultSubscriberWorkers,
+		SubscriberQueueSize: defaultSubscriberQueueSize,
+	},
+	Retention: retention.Limits{
+		Size:   defaultRetainedEvents,
+		MaxAge: defaultRetentionPeriod,
+	},
 }
 
 // EventDispatcherConfig holds the configuration parameters for the event dispatcher.
 type EventDispatcherConfig struct {
 	kncloudevents.ConnectionArgs
+	// DispatchLimits are the default limits of the channels, overridden by the Dispatch field
+	// of the InMemoryChannels.
+	DispatchLimits fanout.DispatchLimits
+	// Retention bounds the recent events retained by each channel, to replay them.
+	Retention retention.Limits
 }
 
 // NewEventDisPatcherConfigFromConfigMap converts a k8s configmap into EventDispatcherConfig.
 func NewEventDisPatcherConfigFromConfigMap(config *corev1.ConfigMap) (EventDispatcherConfig, error) {
-	c := EventDispatcherConfig{
-		ConnectionArgs: kncloudevents.ConnectionArgs{
-			MaxIdleConns:        defaultMaxIdleConnections,
-			MaxIdleConnsPerHost: defaultMaxIdleConnectionsPerHost,
-		},
-	}
+	c := defaultEventDispatcherConfig
 	err := configmap.Parse(
 		config.Data,
 		configmap.AsInt("MaxIdleConnections", &c.MaxIdleConns),
-		configmap.AsInt("MaxIdleConnectionsPerHost", &c.MaxIdleConnsPerHost))
-	return c, err
+		configmap.AsInt("MaxIdleConnectionsPerHost", &c.MaxIdleConnsPerHost),
+		configmap.AsInt("MaxInFlightEvents", &c.DispatchLimits.MaxInFlight),
+		configmap.AsInt("SubscriberWorkers", &c.DispatchLimits.SubscriberWorkers),
+		configmap.AsInt("SubscriberQueueSize", &c.DispatchLimits.SubscriberQueueSize),
+		configmap.AsInt("RetainedEvents", &c.Retention.Size),
+		configmap.AsDuration("RetentionPeriod", &c.Retention.MaxAge))
+	if err != nil {
+		return c, err
+	}
+	if c.DispatchLimits.MaxInFlight < 0 {
+		return c, fmt.Errorf("MaxInFlightEvents = %d, must be at least 0", c.DispatchLimits.MaxInFlight)
+	}
+	if c.DispatchLimits.SubscriberWorkers < 1 {
+		return c, fmt.Errorf("SubscriberWorkers = %d, must be at least 1", c.DispatchLimits.SubscriberWorkers)
+	}
+	if c.DispatchLimits.SubscriberQueueSize < 1 {
+		return c, fmt.Errorf("SubscriberQueueSize = %d, must be at least 1", c.DispatchLimits.SubscriberQueueSize)
+	}
+	if c.Retention.Size < 0 {
+		return c, fmt.Errorf("RetainedEvents = %d, must be at least 0", c.Retention.Size)
+	}
+	if c.Retention.MaxAge < 0 {
+		return c, fmt.Errorf("RetentionPeriod = %v, must be positive", c.Retention.MaxAge)
+	}
+	return c, nil
 }
 
 // EventDispatcherConfigStore loads/unloads untyped configuration from configmap.
@@ -18,11 +18,14 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	configmaptesting "knative.dev/pkg/configmap/testing"
 	logtesting "knative.dev/pkg/logging/testing"
 
+	"knative.dev/eventing/pkg/channel/fanout"
+	"knative.dev/eventing/pkg/channel/retention"
 	"knative.dev/eventing/pkg/kncloudevents"
 )
 
@@ -41,6 +44,8 @@ func TestGetConfig(t *testing.T) {
 					MaxIdleConns:        20,
 					MaxIdleConnsPerHost: 10,
 				},
+				DispatchLimits: defaultEventDispatcherConfig.DispatchLimits,
+				Retention:      defaultEventDispatcherConfig.Retention,
 			},
 			keys: []string{"MaxIdleConnections", "MaxIdleConnectionsPerHost"},
 		},
@@ -52,6 +57,8 @@ func TestGetConfig(t *testing.T) {
 					MaxIdleConns:        20,
 					MaxIdleConnsPerHost: defaultMaxIdleConnectionsPerHost,
 				},
+				DispatchLimits: defaultEventDispatcherConfig.DispatchLimits,
+				Retention:      defaultEventDispatcherConfig.Retention,
 			},
 			keys: []string{"MaxIdleConnections"},
 		},
@@ -63,6 +70,8 @@ func TestGetConfig(t *testing.T) {
 					MaxIdleConns:        defaultMaxIdleConnections,
 					MaxIdleConnsPerHost: 10,
 				},
+				DispatchLimits: defaultEventDispatcherConfig.DispatchLimits,
+				Retention:      defaultEventDispatcherConfig.Retention,
 			},
 			keys: []string{"MaxIdleConnectionsPerHost"},
 		},
@@ -74,7 +83,41 @@ func TestGetConfig(t *testing.T) {
 					MaxIdleConns:        defaultMaxIdleConnections,
 					MaxIdleConnsPerHost: defaultMaxIdleConnectionsPerHost,
 				},
+				// The channels accept any number of events unless the operators opt in.
+				DispatchLimits: fanout.DispatchLimits{
+					MaxInFlight:         0,
+					SubscriberWorkers:   defaultSubscriberWorkers,
+					SubscriberQueueSize: defaultSubscriberQueueSize,
+				},
+				Retention: defaultEventDispatcherConfig.Retention,
+			},
+		},
+		{
+			name: "Dispatch limits are configured",
+			file: "config-event-dispatcher-5",
+			want: EventDispatcherConfig{
+				ConnectionArgs: defaultEventDispatcherConfig.ConnectionArgs,
+				DispatchLimits: fanout.DispatchLimits{
+					MaxInFlight:         100,
+					SubscriberWorkers:   4,
+					SubscriberQueueSize: 50,
+				},
+				Retention: defaultEventDispatcherConfig.Retention,
+			},
+			keys: []string{"MaxInFlightEvents", "SubscriberWorkers", "SubscriberQueueSize"},
+		},
+		{
+			name: "Retention is configured",
+			file: "config-event-dispatcher-6",
+			want: EventDispatcherConfig{
+				ConnectionArgs: defaultEventDispatcherConfig.ConnectionArgs,
+				DispatchLimits: defaultEventDispatcherConfig.DispatchLimits,
+				Retention: retention.Limits{
+					Size:   500,
+					MaxAge: 10 * time.Minute,
+				},
 			},
+			keys: []string{"RetainedEvents", "RetentionPeriod"},
 		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
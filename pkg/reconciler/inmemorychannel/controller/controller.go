@@ -34,6 +34,7 @@ import (
 	"knative.dev/pkg/resolver"
 
 	"knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment"
+	"knative.dev/pkg/client/injection/kube/informers/apps/v1/statefulset"
 	"knative.dev/pkg/client/injection/kube/informers/core/v1/endpoints"
 	"knative.dev/pkg/client/injection/kube/informers/core/v1/service"
 	"knative.dev/pkg/client/injection/kube/informers/core/v1/serviceaccount"
@@ -56,6 +57,7 @@ func NewController(
 	logger := logging.FromContext(ctx)
 	inmemorychannelInformer := inmemorychannel.Get(ctx)
 	deploymentInformer := deployment.Get(ctx)
+	statefulSetInformer := statefulset.Get(ctx)
 	serviceInformer := service.Get(ctx)
 	endpointsInformer := endpoints.Get(ctx)
 	serviceAccountInformer := serviceaccount.Get(ctx)
@@ -65,10 +67,12 @@ func NewController(
 		kubeClientSet:        kubeclient.Get(ctx),
 		systemNamespace:      system.Namespace(),
 		deploymentLister:     deploymentInformer.Lister(),
+		statefulSetLister:    statefulSetInformer.Lister(),
 		serviceLister:        serviceInformer.Lister(),
 		endpointsLister:      endpointsInformer.Lister(),
 		serviceAccountLister: serviceAccountInformer.Lister(),
 		roleBindingLister:    roleBindingInformer.Lister(),
+		vpodLister:           newVPodLister(inmemorychannelInformer.Lister()),
 	}
 
 	env := &envConfig{}
@@ -100,6 +104,12 @@ func NewController(
 		FilterFunc: controller.FilterWithName(dispatcherName),
 		Handler:    controller.HandleAll(grCh),
 	})
+	// Move the channels off the removed replicas when the dispatcher StatefulSet is scaled down, the
+	// channels stay on their replica when it is scaled up.
+	statefulSetInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterWithName(dispatcherName),
+		Handler:    controller.HandleAll(grCh),
+	})
 	serviceInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: controller.FilterWithName(dispatcherName),
 		Handler:    controller.HandleAll(grCh),
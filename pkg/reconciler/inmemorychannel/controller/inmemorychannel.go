@@ -42,6 +42,7 @@ import (
 	inmemorychannelreconciler "knative.dev/eventing/pkg/client/injection/reconciler/messaging/v1/inmemorychannel"
 	"knative.dev/eventing/pkg/reconciler/inmemorychannel/controller/config"
 	"knative.dev/eventing/pkg/reconciler/inmemorychannel/controller/resources"
+	"knative.dev/eventing/pkg/scheduler"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/resolver"
 )
@@ -76,6 +77,7 @@ type Reconciler struct {
 	systemNamespace      string
 	dispatcherImage      string
 	deploymentLister     appsv1listers.DeploymentLister
+	statefulSetLister    appsv1listers.StatefulSetLister
 	serviceLister        corev1listers.ServiceLister
 	endpointsLister      corev1listers.EndpointsLister
 	serviceAccountLister corev1listers.ServiceAccountLister
@@ -83,6 +85,11 @@ type Reconciler struct {
 
 	eventDispatcherConfigStore *config.EventDispatcherConfigStore
 
+	// vpodLister lists the channels, to balance them across the replicas of the dispatcher StatefulSet.
+	vpodLister scheduler.VPodLister
+	// placements are the placements of the channels not visible in the informer cache yet.
+	placements dispatcherPlacements
+
 	uriResolver *resolver.URIResolver
 }
 
@@ -96,7 +103,8 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, imc *v1.InMemoryChannel)
 	// 1. Dispatcher Deployment for it's readiness.
 	// 2. Dispatcher k8s Service for it's existence.
 	// 3. Dispatcher endpoints to ensure that there's something backing the Service.
-	// 4. k8s service representing the channel that will use ExternalName to point to the Dispatcher k8s service
+	// 4. k8s service representing the channel that will use ExternalName to point to the Dispatcher k8s service,
+	//    or to the replica of the dispatcher StatefulSet the channel is placed on.
 
 	scope, ok := imc.Annotations[eventing.ScopeAnnotationKey]
 	if !ok {
@@ -108,14 +116,23 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, imc *v1.InMemoryChannel)
 		dispatcherNamespace = imc.Namespace
 	}
 
-	// Make sure the dispatcher deployment exists and propagate the status to the Channel
-	// For namespace-scope dispatcher, make sure configuration files exist and RBAC is properly configured.
-	d, err := r.reconcileDispatcher(ctx, scope, dispatcherNamespace, imc)
+	// The channels are sharded across the replicas of the dispatcher when it is deployed as a StatefulSet.
+	ss, err := r.getDispatcherStatefulSet(ctx, scope, dispatcherNamespace, imc)
 	if err != nil {
-		logging.FromContext(ctx).Errorw("Failed to reconcile InMemoryChannel dispatcher", zap.Error(err))
 		return err
 	}
-	imc.Status.PropagateDispatcherStatus(&d.Status)
+	if ss != nil {
+		imc.Status.PropagateDispatcherStatefulSetStatus(&ss.Status)
+	} else {
+		// Make sure the dispatcher deployment exists and propagate the status to the Channel
+		// For namespace-scope dispatcher, make sure configuration files exist and RBAC is properly configured.
+		d, err := r.reconcileDispatcher(ctx, scope, dispatcherNamespace, imc)
+		if err != nil {
+			logging.FromContext(ctx).Errorw("Failed to reconcile InMemoryChannel dispatcher", zap.Error(err))
+			return err
+		}
+		imc.Status.PropagateDispatcherStatus(&d.Status)
+	}
 
 	// Make sure the dispatcher service exists and propagate the status to the Channel in case it does not exist.
 	// We don't do anything with the service because it's status contains nothing useful, so just do
@@ -149,9 +166,28 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, imc *v1.InMemoryChannel)
 
 	imc.Status.MarkEndpointsTrue()
 
-	// Reconcile the k8s service representing the actual Channel. It points to the Dispatcher service via
-	// ExternalName
-	svc, err := r.reconcileChannelService(ctx, dispatcherNamespace, imc)
+	// Place the channel on a replica of the dispatcher StatefulSet, the channel is then only served by
+	// this replica.
+	dispatcherService := dispatcherName
+	if ss != nil {
+		placements, err := newDispatcherScheduler(ss, r.vpodLister, &r.placements).Schedule(imc)
+		if err != nil {
+			logging.FromContext(ctx).Errorw("Failed to place the channel on a dispatcher replica", zap.Error(err))
+			imc.Status.MarkDispatcherFailed("DispatcherSchedulingFailed", "Failed to place the channel on a dispatcher replica: %v", err)
+			return err
+		}
+		imc.Status.Placements = placements
+		dispatcherService = placements[0].PodName
+		if err := r.reconcileDispatcherReplicaService(ctx, ss, dispatcherService, imc); err != nil {
+			return err
+		}
+	} else {
+		imc.Status.Placements = nil
+	}
+
+	// Reconcile the k8s service representing the actual Channel. It points to the Dispatcher service, or to
+	// the replica of the dispatcher the channel is placed on, via ExternalName
+	svc, err := r.reconcileChannelService(ctx, dispatcherNamespace, dispatcherService, imc)
 	if err != nil {
 		logging.FromContext(ctx).Errorw("Failed to reconcile channel service", zap.Error(err))
 		return err
@@ -235,6 +271,45 @@ func (r *Reconciler) reconcileDispatcher(ctx context.Context, scope, dispatcherN
 	return d, nil
 }
 
+// getDispatcherStatefulSet returns the dispatcher StatefulSet the channels are sharded across, or nil
+// when the dispatcher isn't deployed as a StatefulSet. Namespace-scoped channels are never sharded.
+func (r *Reconciler) getDispatcherStatefulSet(ctx context.Context, scope, dispatcherNamespace string, imc *v1.InMemoryChannel) (*appsv1.StatefulSet, error) {
+	if scope == eventing.ScopeNamespace || r.statefulSetLister == nil {
+		return nil, nil
+	}
+	ss, err := r.statefulSetLister.StatefulSets(dispatcherNamespace).Get(dispatcherName)
+	if err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil, nil
+		}
+		logging.FromContext(ctx).Error("Unable to get the dispatcher StatefulSet", zap.Error(err))
+		imc.Status.MarkDispatcherFailed("DispatcherStatefulSetGetFailed", "Failed to get dispatcher StatefulSet")
+		return nil, err
+	}
+	if ss.Spec.ServiceName == "" {
+		imc.Status.MarkDispatcherFailed("DispatcherStatefulSetNoService", "The dispatcher StatefulSet has no governing Service")
+		return nil, fmt.Errorf("the dispatcher StatefulSet %s/%s has no governing Service", ss.Namespace, ss.Name)
+	}
+	return ss, nil
+}
+
+// reconcileDispatcherReplicaService makes sure the service of the replica of the dispatcher StatefulSet
+// the channel is placed on exists. Unlike the pod DNS name of the replica, the service maps the port of
+// the channel to the port of the dispatcher.
+func (r *Reconciler) reconcileDispatcherReplicaService(ctx context.Context, ss *appsv1.StatefulSet, podName string, imc *v1.InMemoryChannel) error {
+	_, err := r.serviceLister.Services(ss.Namespace).Get(podName)
+	if apierrs.IsNotFound(err) {
+		expected := resources.MakeDispatcherReplicaService(ss, podName)
+		_, err = r.kubeClientSet.CoreV1().Services(ss.Namespace).Create(ctx, expected, metav1.CreateOptions{})
+	}
+	if err != nil && !apierrs.IsAlreadyExists(err) {
+		logging.FromContext(ctx).Errorw("Failed to reconcile the dispatcher replica service", zap.String("replica", podName), zap.Error(err))
+		imc.Status.MarkServiceFailed("DispatcherReplicaServiceFailed", "Failed to reconcile the service of the dispatcher replica %s: %v", podName, err)
+		return err
+	}
+	return nil
+}
+
 func (r *Reconciler) reconcileServiceAccount(ctx context.Context, dispatcherNamespace string, imc *v1.InMemoryChannel) (*corev1.ServiceAccount, error) {
 	sa, err := r.serviceAccountLister.ServiceAccounts(dispatcherNamespace).Get(dispatcherName)
 	if err != nil {
@@ -298,12 +373,12 @@ func (r *Reconciler) reconcileDispatcherService(ctx context.Context, scope, disp
 	return svc, nil
 }
 
-func (r *Reconciler) reconcileChannelService(ctx context.Context, dispatcherNamespace string, imc *v1.InMemoryChannel) (*corev1.Service, error) {
+func (r *Reconciler) reconcileChannelService(ctx context.Context, dispatcherNamespace, dispatcherService string, imc *v1.InMemoryChannel) (*corev1.Service, error) {
 	// Get the  Service and propagate the status to the Channel in case it does not exist.
 	// We don't do anything with the service because it's status contains nothing useful, so just do
 	// an existence check. Then below we check the endpoints targeting it.
 	// We may change this name later, so we have to ensure we use proper addressable when resolving these.
-	expected, err := resources.NewK8sService(imc, resources.ExternalService(dispatcherNamespace, dispatcherName))
+	expected, err := resources.NewK8sService(imc, resources.ExternalService(dispatcherNamespace, dispatcherService))
 	if err != nil {
 		logging.FromContext(ctx).Error("failed to create the channel service object", zap.Error(err))
 		imc.Status.MarkChannelServiceFailed("ChannelServiceFailed", fmt.Sprint("Channel Service failed: ", err))
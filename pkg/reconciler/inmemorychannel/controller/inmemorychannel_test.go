@@ -54,6 +54,7 @@ import (
 	. "knative.dev/pkg/reconciler/testing"
 
 	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	eventingduckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
 	v1 "knative.dev/eventing/pkg/apis/messaging/v1"
 	"knative.dev/eventing/pkg/reconciler/inmemorychannel/controller/resources"
 	. "knative.dev/eventing/pkg/reconciler/testing/v1"
@@ -339,6 +340,37 @@ func TestAllCases(t *testing.T) {
 					WithInMemoryChannelStatusDLSURI(dlsURI),
 				),
 			}},
+		}, {
+			Name: "Works, channel placed on a replica of the dispatcher StatefulSet",
+			Key:  imcKey,
+			Objects: []runtime.Object{
+				makeReadyStatefulSet(2),
+				makeService(),
+				makeReadyEndpoints(),
+				NewInMemoryChannel("other", testNS,
+					WithInMemoryChannelPlacements(eventingduckv1alpha1.Placement{PodName: dispatcherName + "-0", VReplicas: 1})),
+				NewInMemoryChannel(imcName, testNS),
+				makeChannelService(NewInMemoryChannel(imcName, testNS)),
+			},
+			WantErr: false,
+			WantCreates: []runtime.Object{
+				resources.MakeDispatcherReplicaService(makeReadyStatefulSet(2), dispatcherName+"-1"),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: makeChannelServiceForReplica(NewInMemoryChannel(imcName, testNS), dispatcherName+"-1"),
+			}},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewInMemoryChannel(imcName, testNS,
+					WithInitInMemoryChannelConditions,
+					WithInMemoryChannelStatefulSetReady(),
+					WithInMemoryChannelServiceReady(),
+					WithInMemoryChannelEndpointsReady(),
+					WithInMemoryChannelChannelServiceReady(),
+					WithInMemoryChannelAddress(channelServiceAddress),
+					WithInMemoryChannelDLSUnknown(),
+					WithInMemoryChannelPlacements(eventingduckv1alpha1.Placement{PodName: dispatcherName + "-1", VReplicas: 1}),
+				),
+			}},
 		}, {
 			Name: "channel exists, not owned by us",
 			Key:  imcKey,
@@ -454,12 +486,14 @@ func TestAllCases(t *testing.T) {
 	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher) controller.Reconciler {
 		ctx = v1addr.WithDuck(ctx)
 		r := &Reconciler{
-			kubeClientSet:    fakekubeclient.Get(ctx),
-			systemNamespace:  testNS,
-			deploymentLister: listers.GetDeploymentLister(),
-			serviceLister:    listers.GetServiceLister(),
-			endpointsLister:  listers.GetEndpointsLister(),
-			uriResolver:      resolver.NewURIResolverFromTracker(ctx, tracker.New(func(types.NamespacedName) {}, 0)),
+			kubeClientSet:     fakekubeclient.Get(ctx),
+			systemNamespace:   testNS,
+			deploymentLister:  listers.GetDeploymentLister(),
+			statefulSetLister: listers.GetStatefulSetLister(),
+			serviceLister:     listers.GetServiceLister(),
+			endpointsLister:   listers.GetEndpointsLister(),
+			uriResolver:       resolver.NewURIResolverFromTracker(ctx, tracker.New(func(types.NamespacedName) {}, 0)),
+			vpodLister:        newVPodLister(listers.GetInMemoryChannelLister()),
 		}
 		return inmemorychannel.NewReconciler(ctx, logger,
 			fakeeventingclient.Get(ctx), listers.GetInMemoryChannelLister(),
@@ -605,6 +639,27 @@ func makeUnknownDeployment() *appsv1.Deployment {
 	return d
 }
 
+func makeReadyStatefulSet(replicas int32) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "StatefulSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNS,
+			Name:      dispatcherName,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:    &replicas,
+			ServiceName: dispatcherName + "-headless",
+		},
+		Status: appsv1.StatefulSetStatus{
+			Replicas:      replicas,
+			ReadyReplicas: replicas,
+		},
+	}
+}
+
 func makeService() *corev1.Service {
 	return &corev1.Service{
 		TypeMeta: metav1.TypeMeta{
@@ -675,6 +730,12 @@ func makeChannelService(imc *v1.InMemoryChannel) *corev1.Service {
 	}
 }
 
+func makeChannelServiceForReplica(imc *v1.InMemoryChannel, podName string) *corev1.Service {
+	svc := makeChannelService(imc)
+	svc.Spec.ExternalName = network.GetServiceHostname(podName, testNS)
+	return svc
+}
+
 func makeChannelServiceNotOwnedByUs(imc *v1.InMemoryChannel) *corev1.Service {
 	return &corev1.Service{
 		TypeMeta: metav1.TypeMeta{
@@ -17,6 +17,7 @@ limitations under the License.
 package resources
 
 import (
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -46,3 +47,16 @@ func MakeDispatcherService(name, namespace string) *corev1.Service {
 		},
 	}
 }
+
+// MakeDispatcherReplicaService creates the service of a single replica of the dispatcher StatefulSet,
+// named after the pod of the replica and owned by the StatefulSet.
+func MakeDispatcherReplicaService(ss *appsv1.StatefulSet, podName string) *corev1.Service {
+	svc := MakeDispatcherService(podName, ss.Namespace)
+	svc.OwnerReferences = []metav1.OwnerReference{
+		*metav1.NewControllerRef(ss, appsv1.SchemeGroupVersion.WithKind("StatefulSet")),
+	}
+	svc.Spec.Selector = map[string]string{
+		appsv1.StatefulSetPodNameLabel: podName,
+	}
+	return svc
+}
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -54,3 +55,52 @@ func TestNewDispatcherService(t *testing.T) {
 		t.Error("unexpected condition (-want, +got) =", diff)
 	}
 }
+
+func TestNewDispatcherReplicaService(t *testing.T) {
+	ss := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dispatcherName,
+			Namespace: testNS,
+			UID:       "abc",
+		},
+	}
+	isController := true
+	blockOwnerDeletion := true
+	want := &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dispatcherName + "-1",
+			Namespace: testNS,
+			Labels:    dispatcherLabels,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion:         "apps/v1",
+				Kind:               "StatefulSet",
+				Name:               dispatcherName,
+				UID:                "abc",
+				Controller:         &isController,
+				BlockOwnerDeletion: &blockOwnerDeletion,
+			}},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{
+				"statefulset.kubernetes.io/pod-name": dispatcherName + "-1",
+			},
+			Ports: []corev1.ServicePort{
+				{
+					Protocol:   corev1.ProtocolTCP,
+					Port:       80,
+					TargetPort: intstr.IntOrString{IntVal: 8080},
+				},
+			},
+		},
+	}
+
+	got := MakeDispatcherReplicaService(ss, dispatcherName+"-1")
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error("unexpected service (-want, +got) =", diff)
+	}
+}
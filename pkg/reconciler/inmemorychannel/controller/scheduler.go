@@ -0,0 +1,151 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	duckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
+	messaginglisters "knative.dev/eventing/pkg/client/listers/messaging/v1"
+	"knative.dev/eventing/pkg/scheduler"
+	st "knative.dev/eventing/pkg/scheduler/state"
+)
+
+// errNoDispatcherReplicas is returned when the dispatcher StatefulSet is scaled to zero.
+var errNoDispatcherReplicas = errors.New("the dispatcher StatefulSet has no replicas")
+
+// dispatcherPlacements remembers the replicas the channels were placed on until their placements are
+// visible in the informer cache, so that the channels placed in the same burst see each other's
+// placements. The zero value is ready to use.
+type dispatcherPlacements struct {
+	// mu serializes the scheduling of the channels.
+	mu      sync.Mutex
+	pending map[types.NamespacedName]string
+}
+
+// newDispatcherScheduler returns the scheduler placing the InMemoryChannels on the replicas of the
+// dispatcher StatefulSet. A channel stays on its replica while the replica exists, otherwise it is
+// placed on the replica with the fewest channels.
+func newDispatcherScheduler(ss *appsv1.StatefulSet, vpodLister scheduler.VPodLister, placements *dispatcherPlacements) scheduler.Scheduler {
+	return scheduler.SchedulerFunc(func(vpod scheduler.VPod) ([]duckv1alpha1.Placement, error) {
+		replicas := int32(1)
+		if ss.Spec.Replicas != nil {
+			replicas = *ss.Spec.Replicas
+		}
+		if replicas <= 0 {
+			return nil, errNoDispatcherReplicas
+		}
+
+		ordinal := func(podName string) (int32, bool) {
+			if !strings.HasPrefix(podName, ss.Name+"-") {
+				return 0, false
+			}
+			o := st.OrdinalFromPodName(podName)
+			return o, o >= 0 && o < replicas
+		}
+
+		placements.mu.Lock()
+		defer placements.mu.Unlock()
+		place := func(podName string) []duckv1alpha1.Placement {
+			if placements.pending == nil {
+				placements.pending = make(map[types.NamespacedName]string)
+			}
+			placements.pending[vpod.GetKey()] = podName
+			return []duckv1alpha1.Placement{{PodName: podName, VReplicas: vpod.GetVReplicas()}}
+		}
+
+		for _, p := range vpod.GetPlacements() {
+			if _, ok := ordinal(p.PodName); ok && p.VReplicas > 0 {
+				return place(p.PodName), nil
+			}
+		}
+
+		vpods, err := vpodLister()
+		if err != nil {
+			return nil, err
+		}
+		load := make([]int32, replicas)
+		listed := make(map[types.NamespacedName]bool, len(vpods))
+		for _, v := range vpods {
+			key := v.GetKey()
+			listed[key] = true
+			if key == vpod.GetKey() {
+				continue
+			}
+			// Count the placements not visible in the informer cache yet, and forget the others.
+			if podName, ok := placements.pending[key]; ok {
+				if !isPlacedOn(v, podName) {
+					if o, ok := ordinal(podName); ok {
+						load[o] += v.GetVReplicas()
+					}
+					continue
+				}
+				delete(placements.pending, key)
+			}
+			for _, p := range v.GetPlacements() {
+				if o, ok := ordinal(p.PodName); ok {
+					load[o] += p.VReplicas
+				}
+			}
+		}
+		// Forget the placements of the deleted channels.
+		for key := range placements.pending {
+			if !listed[key] {
+				delete(placements.pending, key)
+			}
+		}
+
+		least := int32(0)
+		for o := range load {
+			if load[o] < load[least] {
+				least = int32(o)
+			}
+		}
+		return place(st.PodNameFromOrdinal(ss.Name, least)), nil
+	})
+}
+
+// isPlacedOn returns true if the VPod has a placement on the given pod.
+func isPlacedOn(vpod scheduler.VPod, podName string) bool {
+	for _, p := range vpod.GetPlacements() {
+		if p.PodName == podName {
+			return true
+		}
+	}
+	return false
+}
+
+// newVPodLister returns the VPodLister listing the InMemoryChannels.
+func newVPodLister(lister messaginglisters.InMemoryChannelLister) scheduler.VPodLister {
+	return func() ([]scheduler.VPod, error) {
+		imcs, err := lister.List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		vpods := make([]scheduler.VPod, 0, len(imcs))
+		for _, imc := range imcs {
+			vpods = append(vpods, imc)
+		}
+		return vpods, nil
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+
+	duckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
+	"knative.dev/eventing/pkg/scheduler"
+	tscheduler "knative.dev/eventing/pkg/scheduler/testing"
+)
+
+func TestDispatcherScheduler(t *testing.T) {
+	placed := func(podName string) []duckv1alpha1.Placement {
+		return []duckv1alpha1.Placement{{PodName: podName, VReplicas: 1}}
+	}
+	others := []scheduler.VPod{
+		tscheduler.NewVPod(testNS, "a", 1, placed("imc-dispatcher-0")),
+		tscheduler.NewVPod(testNS, "b", 1, placed("imc-dispatcher-0")),
+		tscheduler.NewVPod(testNS, "c", 1, placed("imc-dispatcher-1")),
+		tscheduler.NewVPod(testNS, "d", 1, placed("imc-dispatcher-5")),
+	}
+
+	tests := []struct {
+		name     string
+		replicas int32
+		vpod     scheduler.VPod
+		want     []duckv1alpha1.Placement
+		wantErr  error
+	}{{
+		name:     "new channel placed on the replica with the fewest channels",
+		replicas: 3,
+		vpod:     tscheduler.NewVPod(testNS, imcName, 1, nil),
+		want:     placed("imc-dispatcher-2"),
+	}, {
+		name:     "channel kept on its replica",
+		replicas: 3,
+		vpod:     tscheduler.NewVPod(testNS, imcName, 1, placed("imc-dispatcher-0")),
+		want:     placed("imc-dispatcher-0"),
+	}, {
+		name:     "channel moved off a removed replica",
+		replicas: 2,
+		vpod:     tscheduler.NewVPod(testNS, imcName, 1, placed("imc-dispatcher-2")),
+		want:     placed("imc-dispatcher-1"),
+	}, {
+		name:     "no replicas",
+		replicas: 0,
+		vpod:     tscheduler.NewVPod(testNS, imcName, 1, nil),
+		wantErr:  errNoDispatcherReplicas,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ss := makeReadyStatefulSet(tc.replicas)
+			s := newDispatcherScheduler(ss, func() ([]scheduler.VPod, error) {
+				return append(others, tc.vpod), nil
+			}, &dispatcherPlacements{})
+			got, err := s.Schedule(tc.vpod)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Schedule() error = %v, want %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Error("unexpected placements (-want, +got):", diff)
+			}
+		})
+	}
+}
+
+func TestDispatcherSchedulerBurst(t *testing.T) {
+	// The channels are placed before their placements are visible in the informer cache.
+	vpods := []scheduler.VPod{
+		tscheduler.NewVPod(testNS, "a", 1, nil),
+		tscheduler.NewVPod(testNS, "b", 1, nil),
+		tscheduler.NewVPod(testNS, "c", 1, nil),
+	}
+	s := newDispatcherScheduler(makeReadyStatefulSet(3), func() ([]scheduler.VPod, error) {
+		return vpods, nil
+	}, &dispatcherPlacements{})
+
+	placed := make(map[string]bool)
+	for _, vpod := range vpods {
+		got, err := s.Schedule(vpod)
+		if err != nil {
+			t.Fatal("Schedule() =", err)
+		}
+		if placed[got[0].PodName] {
+			t.Errorf("channel %s placed on the busy replica %s", vpod.GetKey(), got[0].PodName)
+		}
+		placed[got[0].PodName] = true
+	}
+}
+
+func TestDispatcherPlacementsForgotten(t *testing.T) {
+	placements := &dispatcherPlacements{}
+	vpods := []scheduler.VPod{tscheduler.NewVPod(testNS, "a", 1, nil)}
+	s := newDispatcherScheduler(makeReadyStatefulSet(2), func() ([]scheduler.VPod, error) {
+		return vpods, nil
+	}, placements)
+
+	got, err := s.Schedule(vpods[0])
+	if err != nil {
+		t.Fatal("Schedule() =", err)
+	}
+
+	// The placement is forgotten once it is visible in the informer cache.
+	vpods = []scheduler.VPod{tscheduler.NewVPod(testNS, "a", 1, got), tscheduler.NewVPod(testNS, "b", 1, nil)}
+	if _, err := s.Schedule(vpods[1]); err != nil {
+		t.Fatal("Schedule() =", err)
+	}
+	if _, ok := placements.pending[vpods[0].GetKey()]; ok {
+		t.Error("placement visible in the informer cache not forgotten")
+	}
+
+	// The placement of a deleted channel is forgotten.
+	vpods = []scheduler.VPod{tscheduler.NewVPod(testNS, "c", 1, nil)}
+	if _, err := s.Schedule(vpods[0]); err != nil {
+		t.Fatal("Schedule() =", err)
+	}
+	if _, ok := placements.pending[types.NamespacedName{Namespace: testNS, Name: "b"}]; ok {
+		t.Error("placement of a deleted channel not forgotten")
+	}
+}
@@ -22,6 +22,8 @@ import (
 
 	"knative.dev/pkg/injection"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/google/uuid"
@@ -48,6 +50,8 @@ import (
 	inmemorychannelinformer "knative.dev/eventing/pkg/client/injection/informers/messaging/v1/inmemorychannel"
 	inmemorychannelreconciler "knative.dev/eventing/pkg/client/injection/reconciler/messaging/v1/inmemorychannel"
 	"knative.dev/eventing/pkg/inmemorychannel"
+	"knative.dev/eventing/pkg/reconciler/inmemorychannel/controller/config"
+	"knative.dev/eventing/pkg/utils"
 )
 
 const (
@@ -66,6 +70,15 @@ type envConfig struct {
 	MaxIdleConns int `envconfig:"MAX_IDLE_CONNS" required:"true"`
 	// MaxIdleConnsPerHost refers to the max idle connections per host, as in net/http/transport.
 	MaxIdleConnsPerHost int `envconfig:"MAX_IDLE_CONNS_PER_HOST" required:"true"`
+
+	// WriteAheadLogDir is the directory of the write-ahead logs of the channels using persistent
+	// buffering, persistent buffering is disabled when empty.
+	WriteAheadLogDir string `envconfig:"WAL_DIR"`
+
+	// ReplayPort is the port of the endpoint replaying the retained events to a subscriber,
+	// the endpoint is disabled when zero. The endpoint isn't authenticated, it only listens on
+	// the loopback interface.
+	ReplayPort int `envconfig:"REPLAY_PORT"`
 }
 
 // NewController initializes the controller and is called by the generated code.
@@ -82,6 +95,10 @@ func NewController(
 	if err != nil {
 		logger.Panicw("Error setting up trace publishing", zap.Error(err))
 	}
+	// Watch the header pass-through policy and dynamically update the forwarded headers.
+	iw.WatchWithDefault(corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: utils.HeadersConfigName}},
+		utils.UpdateHeadersPolicyFromConfigMap(logger))
+
 	var env envConfig
 	if err := envconfig.Process("", &env); err != nil {
 		logger.Panicw("Failed to process env var", zap.Error(err))
@@ -106,6 +123,7 @@ func NewController(
 	readinessChecker := &DispatcherReadyChecker{
 		chLister:     inmemorychannelinformer.Get(ctx).Lister(),
 		chMsgHandler: sh,
+		podName:      env.PodName,
 	}
 
 	args := &inmemorychannel.InMemoryMessageDispatcherArgs{
@@ -127,10 +145,20 @@ func NewController(
 		multiChannelMessageHandler: sh,
 		reporter:                   reporter,
 		messagingClientSet:         eventingclient.Get(ctx).MessagingV1(),
+		writeAheadLogs:             writeAheadLogs{dir: env.WriteAheadLogDir},
+		podName:                    env.PodName,
 	}
 	impl := inmemorychannelreconciler.NewImpl(ctx, r, func(impl *controller.Impl) controller.Options {
 		return controller.Options{SkipStatusUpdates: true, FinalizerName: finalizerName}
 	})
+	r.enqueueKey = impl.EnqueueKey
+
+	// Watch the default dispatch limits, and apply them to the channels when they change.
+	r.eventDispatcherConfigStore = config.NewEventDispatcherConfigStore(logger, func(name string, value interface{}) {
+		impl.GlobalResync(inmemorychannelInformer.Informer())
+	})
+	iw.WatchWithDefault(corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: config.EventDispatcherConfigMap}},
+		r.eventDispatcherConfigStore.OnConfigChanged)
 
 	// Watch for inmemory channels.
 	inmemorychannelInformer.Informer().AddEventHandler(
@@ -142,6 +170,20 @@ func NewController(
 				DeleteFunc: r.deleteFunc,
 			}})
 
+	// Start the replay endpoint.
+	if env.ReplayPort != 0 {
+		replay := &replayHandler{
+			lister:  inmemorychannelInformer.Lister(),
+			handler: sh,
+			logger:  logger.Desugar(),
+		}
+		go func() {
+			if err := serveReplay(ctx, env.ReplayPort, replay); err != nil {
+				logger.Errorw("Failed to serve the replay endpoint", zap.Error(err))
+			}
+		}()
+	}
+
 	// Start the dispatcher.
 	go func() {
 		err := inMemoryDispatcher.Start(ctx)
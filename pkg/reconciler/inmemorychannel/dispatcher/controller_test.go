@@ -26,8 +26,11 @@ import (
 
 	"knative.dev/eventing/pkg/apis/eventing"
 
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
 	configmap "knative.dev/pkg/configmap/informer"
 	. "knative.dev/pkg/reconciler/testing"
+	"knative.dev/pkg/system"
+	_ "knative.dev/pkg/system/testing"
 
 	// Fake injection client
 	_ "knative.dev/eventing/pkg/client/injection/client/fake"
@@ -47,7 +50,7 @@ func TestNew(t *testing.T) {
 	os.Setenv("CONTAINER_NAME", "testcontainer")
 	os.Setenv("MAX_IDLE_CONNS", "2000")
 	os.Setenv("MAX_IDLE_CONNS_PER_HOST", "200")
-	c := NewController(ctx, &configmap.InformedWatcher{})
+	c := NewController(ctx, configmap.NewInformedWatcher(fakekubeclient.Get(ctx), system.Namespace()))
 
 	if c == nil {
 		t.Fatal("Expected NewController to return a non-nil value")
@@ -66,7 +69,7 @@ func TestNewInNamespace(t *testing.T) {
 	os.Setenv("CONTAINER_NAME", "testcontainer")
 	os.Setenv("MAX_IDLE_CONNS", "2000")
 	os.Setenv("MAX_IDLE_CONNS_PER_HOST", "200")
-	c := NewController(ctx, &configmap.InformedWatcher{})
+	c := NewController(ctx, configmap.NewInformedWatcher(fakekubeclient.Get(ctx), system.Namespace()))
 
 	if c == nil {
 		t.Fatal("Expected NewController to return a non-nil value")
@@ -87,7 +90,7 @@ func TestMaxIdleConnsEqualToZero(t *testing.T) {
 	os.Setenv("MAX_IDLE_CONNS_PER_HOST", "200")
 
 	require.Panics(t, func() {
-		NewController(ctx, &configmap.InformedWatcher{})
+		NewController(ctx, configmap.NewInformedWatcher(fakekubeclient.Get(ctx), system.Namespace()))
 	})
 }
 
@@ -105,6 +108,6 @@ func TestMaxIdleConnsPerHostEqualToZero(t *testing.T) {
 	os.Setenv("MAX_IDLE_CONNS_PER_HOST", "0")
 
 	require.Panics(t, func() {
-		NewController(ctx, &configmap.InformedWatcher{})
+		NewController(ctx, configmap.NewInformedWatcher(fakekubeclient.Get(ctx), system.Namespace()))
 	})
 }
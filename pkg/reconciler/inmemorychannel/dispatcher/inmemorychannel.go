@@ -38,9 +38,12 @@ import (
 	"knative.dev/eventing/pkg/channel"
 	"knative.dev/eventing/pkg/channel/fanout"
 	"knative.dev/eventing/pkg/channel/multichannelfanout"
+	"knative.dev/eventing/pkg/channel/retention"
 	messagingv1 "knative.dev/eventing/pkg/client/clientset/versioned/typed/messaging/v1"
 	reconcilerv1 "knative.dev/eventing/pkg/client/injection/reconciler/messaging/v1/inmemorychannel"
 	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/eventing/pkg/reconciler/inmemorychannel/controller/config"
+	"knative.dev/eventing/pkg/scheduler"
 )
 
 // Reconciler reconciles InMemory Channels.
@@ -48,6 +51,30 @@ type Reconciler struct {
 	multiChannelMessageHandler multichannelfanout.MultiChannelMessageHandler
 	reporter                   channel.StatsReporter
 	messagingClientSet         messagingv1.MessagingV1Interface
+	writeAheadLogs             writeAheadLogs
+	// eventDispatcherConfigStore holds the default dispatch limits of the channels.
+	eventDispatcherConfigStore *config.EventDispatcherConfigStore
+	// enqueueKey enqueues an InMemoryChannel, to update the status of its subscribers.
+	enqueueKey func(types.NamespacedName)
+	// podName is the name of the dispatcher replica, it only programs the channels placed on it.
+	podName string
+}
+
+// subscriberStatuses is implemented by the fanout handlers reporting the status of their subscribers.
+type subscriberStatuses interface {
+	GetSubscriberStatuses() map[types.UID]fanout.SubscriberStatus
+}
+
+// dispatchLimiter is implemented by the fanout handlers bounding the events dispatched concurrently.
+type dispatchLimiter interface {
+	GetDispatchLimits() fanout.DispatchLimits
+	SetDispatchLimits(fanout.DispatchLimits)
+}
+
+// retainer is implemented by the fanout handlers retaining the recent events to replay them.
+type retainer interface {
+	GetRetentionLimits() retention.Limits
+	SetRetentionLimits(retention.Limits)
 }
 
 // Check the interfaces Reconciler should implement
@@ -62,18 +89,41 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, imc *v1.InMemoryChannel)
 		return err
 	}
 
+	// The status of the subscribers of a sharded channel is reported by the replica it is placed on.
+	if !isPlacedOn(imc, r.podName) {
+		return nil
+	}
+
 	// Then patch the subscribers to reflect that they are now ready to go
 	return r.patchSubscriberStatus(ctx, imc)
 }
 
 // ObserveKind implements inmemorychannel.ReadOnlyInterface.
 func (r *Reconciler) ObserveKind(ctx context.Context, imc *v1.InMemoryChannel) reconciler.Event {
-	return r.reconcile(ctx, imc)
+	if err := r.reconcile(ctx, imc); err != nil {
+		return err
+	}
+
+	// The replica a sharded channel is placed on reports the status of its subscribers, even
+	// when it isn't the leader.
+	if len(imc.GetPlacements()) > 0 && isPlacedOn(imc, r.podName) {
+		return r.patchSubscriberStatus(ctx, imc)
+	}
+	return nil
 }
 
 func (r *Reconciler) reconcile(ctx context.Context, imc *v1.InMemoryChannel) reconciler.Event {
 	logging.FromContext(ctx).Infow("Reconciling", zap.Any("InMemoryChannel", imc))
 
+	if !isPlacedOn(imc, r.podName) {
+		logging.FromContext(ctx).Debug("IMC is placed on another dispatcher replica, skipping")
+		// The channel may have been moved from this replica.
+		if imc.Status.Address != nil && imc.Status.Address.URL != nil {
+			r.multiChannelMessageHandler.DeleteChannelHandler(imc.Status.Address.URL.Host)
+		}
+		return nil
+	}
+
 	if !imc.IsReady() {
 		logging.FromContext(ctx).Debug("IMC is not ready, skipping")
 		return nil
@@ -85,10 +135,43 @@ func (r *Reconciler) reconcile(ctx context.Context, imc *v1.InMemoryChannel) rec
 		return err
 	}
 
+	config.FanoutConfig.DispatchLimits = r.dispatchLimits(imc)
+	config.FanoutConfig.Retention = r.retentionLimits()
+
+	key := types.NamespacedName{Namespace: imc.Namespace, Name: imc.Name}
+	persistent := isPersistent(imc)
+	if persistent && !r.writeAheadLogs.enabled() {
+		logging.FromContext(ctx).Warn("Persistent buffering is not enabled in the dispatcher, buffering the events in memory")
+		persistent = false
+	}
+
 	// First grab the MultiChannelFanoutMessage handler
 	handler := r.multiChannelMessageHandler.GetChannelHandler(config.HostName)
+	if handler != nil && persistent != (r.writeAheadLogs.get(key) != nil) {
+		// The buffering changed, replace the handler.
+		logging.FromContext(ctx).Info("Updating the buffering of the channel", zap.Bool("persistent", persistent))
+		handler = nil
+	}
 	if handler == nil {
 		// No handler yet, create one.
+		loaded := false
+		if persistent {
+			if config.FanoutConfig.WriteAheadLog, loaded, err = r.writeAheadLogs.open(key); err != nil {
+				logging.FromContext(ctx).Error("Failed to open the write-ahead log", zap.Error(err))
+				return err
+			}
+			if skipped := config.FanoutConfig.WriteAheadLog.Skipped(); loaded && skipped > 0 {
+				logging.FromContext(ctx).Warn("Skipped the corrupted entries of the write-ahead log, their events aren't replayed",
+					zap.Int("skipped", skipped))
+			}
+		} else if err := r.writeAheadLogs.remove(key); err != nil {
+			logging.FromContext(ctx).Warn("Failed to remove the write-ahead log", zap.Error(err))
+		}
+		if r.enqueueKey != nil {
+			config.FanoutConfig.SubscriberStatusChanged = func() {
+				r.enqueueKey(key)
+			}
+		}
 		fanoutHandler, err := fanout.NewFanoutMessageHandler(
 			logging.FromContext(ctx).Desugar(),
 			channel.NewMessageDispatcher(logging.FromContext(ctx).Desugar()),
@@ -100,30 +183,92 @@ func (r *Reconciler) reconcile(ctx context.Context, imc *v1.InMemoryChannel) rec
 			return err
 		}
 		r.multiChannelMessageHandler.SetChannelHandler(config.HostName, fanoutHandler)
+		if loaded {
+			// Dispatch the events accepted but not dispatched before the dispatcher restarted, the
+			// events of a log that was already open are dispatched by the handler being replaced.
+			fanoutHandler.Replay(ctx, channel.ChannelReference{Namespace: imc.Namespace, Name: imc.Name})
+		}
 	} else {
 		// Just update the config if necessary.
 		haveSubs := handler.GetSubscriptions(ctx)
 
-		// Ignore the closures, we stash the values that we can tell from if the values have actually changed.
-		if diff := cmp.Diff(config.FanoutConfig.Subscriptions, haveSubs, cmpopts.IgnoreFields(kncloudevents.RetryConfig{}, "Backoff", "CheckRetry")); diff != "" {
+		// Ignore the closures and the materialized filters, we stash the values that we can tell from if the values have actually changed.
+		if diff := cmp.Diff(config.FanoutConfig.Subscriptions, haveSubs,
+			cmpopts.IgnoreFields(kncloudevents.RetryConfig{}, "Backoff", "CheckRetry"),
+			cmpopts.IgnoreFields(fanout.Subscription{}, "Filter")); diff != "" {
 			logging.FromContext(ctx).Info("Updating fanout config: ", zap.String("Diff", diff))
 			handler.SetSubscriptions(ctx, config.FanoutConfig.Subscriptions)
 		}
+		if limiter, ok := handler.(dispatchLimiter); ok && limiter.GetDispatchLimits() != config.FanoutConfig.DispatchLimits {
+			logging.FromContext(ctx).Info("Updating the dispatch limits", zap.Any("limits", config.FanoutConfig.DispatchLimits))
+			limiter.SetDispatchLimits(config.FanoutConfig.DispatchLimits)
+		}
+		if retainer, ok := handler.(retainer); ok && retainer.GetRetentionLimits() != config.FanoutConfig.Retention {
+			logging.FromContext(ctx).Info("Updating the retention", zap.Any("retention", config.FanoutConfig.Retention))
+			retainer.SetRetentionLimits(config.FanoutConfig.Retention)
+		}
 	}
 
 	return nil
 }
 
+// dispatchLimits returns the dispatch limits of the channel: the defaults of the
+// config-imc-event-dispatcher ConfigMap, overridden by the Dispatch field of the channel.
+func (r *Reconciler) dispatchLimits(imc *v1.InMemoryChannel) fanout.DispatchLimits {
+	var limits fanout.DispatchLimits
+	if r.eventDispatcherConfigStore != nil {
+		limits = r.eventDispatcherConfigStore.GetConfig().DispatchLimits
+	}
+	if d := imc.Spec.Dispatch; d != nil {
+		if d.MaxInFlight != nil {
+			limits.MaxInFlight = int(*d.MaxInFlight)
+		}
+		if d.Workers != nil {
+			limits.SubscriberWorkers = int(*d.Workers)
+		}
+		if d.QueueSize != nil {
+			limits.SubscriberQueueSize = int(*d.QueueSize)
+		}
+	}
+	return limits
+}
+
+// retentionLimits returns the limits of the events retained by the channels, configured in the
+// config-imc-event-dispatcher ConfigMap.
+func (r *Reconciler) retentionLimits() retention.Limits {
+	if r.eventDispatcherConfigStore == nil {
+		return retention.Limits{}
+	}
+	limits := r.eventDispatcherConfigStore.GetConfig().Retention
+	if !limits.Enabled() {
+		return retention.Limits{}
+	}
+	return limits
+}
+
 func (r *Reconciler) patchSubscriberStatus(ctx context.Context, imc *v1.InMemoryChannel) error {
 	after := imc.DeepCopy()
 
+	var statuses map[types.UID]fanout.SubscriberStatus
+	if imc.Status.Address != nil && imc.Status.Address.URL != nil {
+		if handler, ok := r.multiChannelMessageHandler.GetChannelHandler(imc.Status.Address.URL.Host).(subscriberStatuses); ok {
+			statuses = handler.GetSubscriberStatuses()
+		}
+	}
+
 	after.Status.Subscribers = make([]eventingduckv1.SubscriberStatus, 0)
 	for _, sub := range imc.Spec.Subscribers {
-		after.Status.Subscribers = append(after.Status.Subscribers, eventingduckv1.SubscriberStatus{
+		status := eventingduckv1.SubscriberStatus{
 			UID:                sub.UID,
 			ObservedGeneration: sub.Generation,
 			Ready:              corev1.ConditionTrue,
-		})
+		}
+		// A subscriber failing to receive the events isn't ready.
+		if s, ok := statuses[sub.UID]; ok && !s.Ready {
+			status.Ready = corev1.ConditionFalse
+			status.Message = s.Message
+		}
+		after.Status.Subscribers = append(after.Status.Subscribers, status)
 	}
 	jsonPatch, err := duck.CreatePatch(imc, after)
 	if err != nil {
@@ -147,6 +292,13 @@ func (r *Reconciler) patchSubscriberStatus(ctx context.Context, imc *v1.InMemory
 	return nil
 }
 
+// isPlacedOn returns true if the channel is placed on the dispatcher replica. The channels without
+// placements aren't sharded, they are programmed by every replica.
+func isPlacedOn(imc *v1.InMemoryChannel, podName string) bool {
+	placements := imc.GetPlacements()
+	return len(placements) == 0 || scheduler.GetPlacementForPod(placements, podName) != nil
+}
+
 // newConfigForInMemoryChannel creates a new Config for a single inmemory channel.
 func newConfigForInMemoryChannel(imc *v1.InMemoryChannel) (*multichannelfanout.ChannelConfig, error) {
 	subs := make([]fanout.Subscription, len(imc.Spec.Subscribers))
@@ -156,6 +308,8 @@ func newConfigForInMemoryChannel(imc *v1.InMemoryChannel) (*multichannelfanout.C
 		if err != nil {
 			return nil, err
 		}
+		// The Subscriptions are in the namespace of the channel.
+		conf.Namespace = imc.Namespace
 		subs[i] = *conf
 	}
 
@@ -187,4 +341,5 @@ func (r *Reconciler) deleteFunc(obj interface{}) {
 			r.multiChannelMessageHandler.DeleteChannelHandler(hostName)
 		}
 	}
+	_ = r.writeAheadLogs.remove(types.NamespacedName{Namespace: imc.Namespace, Name: imc.Name})
 }
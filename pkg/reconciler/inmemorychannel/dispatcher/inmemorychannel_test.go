@@ -19,11 +19,15 @@ package dispatcher
 import (
 	"context"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgotesting "k8s.io/client-go/testing"
@@ -38,12 +42,16 @@ import (
 	. "knative.dev/pkg/reconciler/testing"
 
 	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	eventingduckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
+	"knative.dev/eventing/pkg/apis/messaging"
 	v1 "knative.dev/eventing/pkg/apis/messaging/v1"
 	"knative.dev/eventing/pkg/channel"
 	"knative.dev/eventing/pkg/channel/fanout"
+	"knative.dev/eventing/pkg/channel/retention"
 	fakeeventingclient "knative.dev/eventing/pkg/client/injection/client/fake"
 	"knative.dev/eventing/pkg/client/injection/reconciler/messaging/v1/inmemorychannel"
 	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/eventing/pkg/reconciler/inmemorychannel/controller/config"
 	. "knative.dev/eventing/pkg/reconciler/testing/v1"
 
 	v1addr "knative.dev/pkg/client/injection/ducks/duck/v1/addressable"
@@ -343,9 +351,9 @@ func TestReconciler_ReconcileKind(t *testing.T) {
 				WithInMemoryChannelAddress(channelServiceAddress),
 				WithInMemoryChannelDLSUnknown()),
 			wantSubs: []fanout.Subscription{
-				{Subscriber: apis.HTTP("call1").URL(),
+				{UID: subscriber1UID, Namespace: testNS, Subscriber: apis.HTTP("call1").URL(),
 					Reply: apis.HTTP("sink2").URL()},
-				{Subscriber: apis.HTTP("call2").URL(),
+				{UID: subscriber2UID, Namespace: testNS, Subscriber: apis.HTTP("call2").URL(),
 					Reply: apis.HTTP("sink2").URL()},
 			},
 		},
@@ -361,9 +369,9 @@ func TestReconciler_ReconcileKind(t *testing.T) {
 				WithInMemoryChannelDLSUnknown()),
 			subs: []fanout.Subscription{*subscription1},
 			wantSubs: []fanout.Subscription{
-				{Subscriber: apis.HTTP("call1").URL(),
+				{UID: subscriber1UID, Namespace: testNS, Subscriber: apis.HTTP("call1").URL(),
 					Reply: apis.HTTP("sink2").URL()},
-				{Subscriber: apis.HTTP("call2").URL(),
+				{UID: subscriber2UID, Namespace: testNS, Subscriber: apis.HTTP("call2").URL(),
 					Reply: apis.HTTP("sink2").URL()},
 			},
 		},
@@ -379,9 +387,9 @@ func TestReconciler_ReconcileKind(t *testing.T) {
 				WithInMemoryChannelDLSUnknown()),
 			subs: []fanout.Subscription{*subscription1, *subscription2},
 			wantSubs: []fanout.Subscription{
-				{Subscriber: apis.HTTP("call1").URL(),
+				{UID: subscriber1UID, Namespace: testNS, Subscriber: apis.HTTP("call1").URL(),
 					Reply: apis.HTTP("sink2").URL()},
-				{Subscriber: apis.HTTP("call2").URL(),
+				{UID: subscriber2UID, Namespace: testNS, Subscriber: apis.HTTP("call2").URL(),
 					Reply: apis.HTTP("sink2").URL()},
 			},
 		},
@@ -397,7 +405,7 @@ func TestReconciler_ReconcileKind(t *testing.T) {
 				WithInMemoryChannelDLSUnknown()),
 			subs: []fanout.Subscription{*subscription1, *subscription2},
 			wantSubs: []fanout.Subscription{
-				{Subscriber: apis.HTTP("call1").URL(),
+				{UID: subscriber1UID, Namespace: testNS, Subscriber: apis.HTTP("call1").URL(),
 					Reply: apis.HTTP("sink2").URL()},
 			},
 		},
@@ -413,9 +421,9 @@ func TestReconciler_ReconcileKind(t *testing.T) {
 				WithInMemoryChannelDLSUnknown()),
 			subs: []fanout.Subscription{*subscription1, *subscription2},
 			wantSubs: []fanout.Subscription{
-				{Subscriber: apis.HTTP("call1").URL(),
+				{UID: subscriber1UID, Namespace: testNS, Subscriber: apis.HTTP("call1").URL(),
 					Reply: apis.HTTP("sink2").URL()},
-				{Subscriber: apis.HTTP("call3").URL(),
+				{UID: subscriber3UID, Namespace: testNS, Subscriber: apis.HTTP("call3").URL(),
 					Reply: apis.HTTP("sink2").URL()},
 			},
 		},
@@ -433,7 +441,7 @@ func TestReconciler_ReconcileKind(t *testing.T) {
 				Reply:       apis.HTTP("sink2").URL(),
 				RetryConfig: &kncloudevents.RetryConfig{RetryMax: 2, BackoffPolicy: &exponential}}},
 			wantSubs: []fanout.Subscription{
-				{Subscriber: apis.HTTP("call1").URL(),
+				{UID: subscriber1UID, Namespace: testNS, Subscriber: apis.HTTP("call1").URL(),
 					Reply:       apis.HTTP("sink2").URL(),
 					RetryConfig: &kncloudevents.RetryConfig{RetryMax: 3, BackoffPolicy: &linear}},
 			},
@@ -474,6 +482,252 @@ func TestReconciler_ReconcileKind(t *testing.T) {
 	}
 }
 
+func TestReconciler_PersistentBuffering(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, testNS, imcName+".wal")
+	opts := []InMemoryChannelOption{
+		WithInitInMemoryChannelConditions,
+		WithInMemoryChannelDeploymentReady(),
+		WithInMemoryChannelServiceReady(),
+		WithInMemoryChannelEndpointsReady(),
+		WithInMemoryChannelChannelServiceReady(),
+		WithInMemoryChannelSubscribers(subscribers),
+		WithInMemoryChannelAddress(channelServiceAddress),
+		WithInMemoryChannelDLSUnknown(),
+	}
+	persistent := NewInMemoryChannel(imcName, testNS,
+		append(opts, WithInMemoryChannelBufferingAnnotation(messaging.BufferingPersistent))...)
+	memory := NewInMemoryChannel(imcName, testNS, opts...)
+
+	ctx, fakeEventingClient := fakeeventingclient.With(context.Background(), persistent)
+	handler := newFakeMultiChannelHandler()
+	r := &Reconciler{
+		multiChannelMessageHandler: handler,
+		messagingClientSet:         fakeEventingClient.MessagingV1(),
+		writeAheadLogs:             writeAheadLogs{dir: dir},
+	}
+
+	if err := r.ReconcileKind(ctx, persistent); err != nil {
+		t.Fatal("ReconcileKind() =", err)
+	}
+	persistentHandler := handler.GetChannelHandler(channelServiceAddress)
+	if persistentHandler == nil {
+		t.Fatal("Did not get handler")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Error("Write-ahead log not created:", err)
+	}
+
+	// Reconciling again keeps the handler.
+	if err := r.ReconcileKind(ctx, persistent); err != nil {
+		t.Fatal("ReconcileKind() =", err)
+	}
+	if handler.GetChannelHandler(channelServiceAddress) != persistentHandler {
+		t.Error("Handler replaced while the buffering didn't change")
+	}
+
+	// Switching to memory buffering replaces the handler and removes the write-ahead log.
+	if err := r.ReconcileKind(ctx, memory); err != nil {
+		t.Fatal("ReconcileKind() =", err)
+	}
+	if handler.GetChannelHandler(channelServiceAddress) == persistentHandler {
+		t.Error("Handler not replaced while the buffering changed")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Write-ahead log not removed:", err)
+	}
+
+	// Persistent buffering is ignored when the dispatcher has no write-ahead log directory.
+	r.writeAheadLogs = writeAheadLogs{}
+	if err := r.ReconcileKind(ctx, persistent); err != nil {
+		t.Fatal("ReconcileKind() =", err)
+	}
+	if r.writeAheadLogs.get(types.NamespacedName{Namespace: testNS, Name: imcName}) != nil {
+		t.Error("Write-ahead log opened without a directory")
+	}
+}
+
+func TestWriteAheadLogs_Open(t *testing.T) {
+	w := writeAheadLogs{dir: t.TempDir()}
+	key := types.NamespacedName{Namespace: testNS, Name: imcName}
+
+	first, loaded, err := w.open(key)
+	if err != nil {
+		t.Fatal("open() =", err)
+	}
+	if !loaded {
+		t.Error("Expected the write-ahead log to be loaded when first opened")
+	}
+
+	// The handler replacing the one that opened the log doesn't replay it again.
+	second, loaded, err := w.open(key)
+	if err != nil {
+		t.Fatal("open() =", err)
+	}
+	if loaded || second != first {
+		t.Error("Expected the opened write-ahead log to be reused without loading it again")
+	}
+}
+
+func TestReconciler_DispatchLimits(t *testing.T) {
+	opts := []InMemoryChannelOption{
+		WithInitInMemoryChannelConditions,
+		WithInMemoryChannelDeploymentReady(),
+		WithInMemoryChannelServiceReady(),
+		WithInMemoryChannelEndpointsReady(),
+		WithInMemoryChannelChannelServiceReady(),
+		WithInMemoryChannelSubscribers(subscribers),
+		WithInMemoryChannelAddress(channelServiceAddress),
+		WithInMemoryChannelDLSUnknown(),
+	}
+	imc := NewInMemoryChannel(imcName, testNS,
+		append(opts, WithInMemoryChannelDispatch(&v1.InMemoryChannelDispatchSpec{Workers: pointer.Int32(2)}))...)
+
+	ctx, fakeEventingClient := fakeeventingclient.With(context.Background(), imc)
+	store := config.NewEventDispatcherConfigStore(logtesting.TestLogger(t))
+	store.OnConfigChanged(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.EventDispatcherConfigMap},
+		Data: map[string]string{
+			"MaxInFlightEvents":   "50",
+			"SubscriberWorkers":   "8",
+			"SubscriberQueueSize": "100",
+			"RetainedEvents":      "20",
+			"RetentionPeriod":     "5m",
+		},
+	})
+	handler := newFakeMultiChannelHandler()
+	r := &Reconciler{
+		multiChannelMessageHandler: handler,
+		messagingClientSet:         fakeEventingClient.MessagingV1(),
+		eventDispatcherConfigStore: store,
+	}
+
+	if err := r.ReconcileKind(ctx, imc); err != nil {
+		t.Fatal("ReconcileKind() =", err)
+	}
+	channelHandler, ok := handler.GetChannelHandler(channelServiceAddress).(dispatchLimiter)
+	if !ok {
+		t.Fatal("Did not get handler")
+	}
+	want := fanout.DispatchLimits{MaxInFlight: 50, SubscriberWorkers: 2, SubscriberQueueSize: 100}
+	if diff := cmp.Diff(want, channelHandler.GetDispatchLimits()); diff != "" {
+		t.Error("Unexpected dispatch limits (-want, +got) =", diff)
+	}
+	wantRetention := retention.Limits{Size: 20, MaxAge: 5 * time.Minute}
+	if diff := cmp.Diff(wantRetention, channelHandler.(retainer).GetRetentionLimits()); diff != "" {
+		t.Error("Unexpected retention (-want, +got) =", diff)
+	}
+
+	// Updating the channel updates the limits of the existing handler.
+	imc = NewInMemoryChannel(imcName, testNS,
+		append(opts, WithInMemoryChannelDispatch(&v1.InMemoryChannelDispatchSpec{MaxInFlight: pointer.Int32(10)}))...)
+	if err := r.ReconcileKind(ctx, imc); err != nil {
+		t.Fatal("ReconcileKind() =", err)
+	}
+	if handler.GetChannelHandler(channelServiceAddress).(dispatchLimiter) != channelHandler {
+		t.Error("Handler replaced while only the dispatch limits changed")
+	}
+	want = fanout.DispatchLimits{MaxInFlight: 10, SubscriberWorkers: 8, SubscriberQueueSize: 100}
+	if diff := cmp.Diff(want, channelHandler.GetDispatchLimits()); diff != "" {
+		t.Error("Unexpected dispatch limits (-want, +got) =", diff)
+	}
+}
+
+func TestReconciler_Sharding(t *testing.T) {
+	placedOn := func(podName string) *v1.InMemoryChannel {
+		return NewInMemoryChannel(imcName, testNS,
+			WithInitInMemoryChannelConditions,
+			WithInMemoryChannelStatefulSetReady(),
+			WithInMemoryChannelServiceReady(),
+			WithInMemoryChannelEndpointsReady(),
+			WithInMemoryChannelChannelServiceReady(),
+			WithInMemoryChannelSubscribers(subscribers),
+			WithInMemoryChannelAddress(channelServiceAddress),
+			WithInMemoryChannelDLSUnknown(),
+			WithInMemoryChannelPlacements(eventingduckv1alpha1.Placement{PodName: podName, VReplicas: 1}))
+	}
+	imc := placedOn("imc-dispatcher-1")
+	ctx, fakeEventingClient := fakeeventingclient.With(context.Background(), imc)
+
+	handler := newFakeMultiChannelHandler()
+	r := &Reconciler{
+		multiChannelMessageHandler: handler,
+		messagingClientSet:         fakeEventingClient.MessagingV1(),
+		podName:                    "imc-dispatcher-0",
+	}
+
+	// The channel placed on another replica isn't programmed, and its subscribers status
+	// isn't reported by the leader.
+	if err := r.ReconcileKind(ctx, imc); err != nil {
+		t.Fatal("ReconcileKind() =", err)
+	}
+	if handler.GetChannelHandler(channelServiceAddress) != nil {
+		t.Error("Got handler for a channel placed on another replica")
+	}
+	if actions := fakeEventingClient.Actions(); len(actions) != 0 {
+		t.Errorf("Unexpected actions %v", actions)
+	}
+
+	// The replica the channel is placed on programs it and reports its subscribers status,
+	// even when it isn't the leader.
+	imc = placedOn("imc-dispatcher-0")
+	if err := r.ObserveKind(ctx, imc); err != nil {
+		t.Fatal("ObserveKind() =", err)
+	}
+	if handler.GetChannelHandler(channelServiceAddress) == nil {
+		t.Error("Did not get handler for a channel placed on the replica")
+	}
+	if actions := fakeEventingClient.Actions(); len(actions) != 1 {
+		t.Errorf("Unexpected actions %v", actions)
+	}
+
+	// The channel moved to another replica is removed.
+	imc = placedOn("imc-dispatcher-1")
+	if err := r.ObserveKind(ctx, imc); err != nil {
+		t.Fatal("ObserveKind() =", err)
+	}
+	if handler.GetChannelHandler(channelServiceAddress) != nil {
+		t.Error("Got handler for a channel moved to another replica")
+	}
+}
+
+func TestReconciler_SubscriberStatus(t *testing.T) {
+	imc := NewInMemoryChannel(imcName, testNS,
+		WithInitInMemoryChannelConditions,
+		WithInMemoryChannelDeploymentReady(),
+		WithInMemoryChannelServiceReady(),
+		WithInMemoryChannelEndpointsReady(),
+		WithInMemoryChannelChannelServiceReady(),
+		WithInMemoryChannelSubscribers(subscribers),
+		WithInMemoryChannelAddress(channelServiceAddress),
+		WithInMemoryChannelDLSUnknown())
+	ctx, fakeEventingClient := fakeeventingclient.With(context.Background(), imc)
+
+	handler := newFakeMultiChannelHandler()
+	handler.SetChannelHandler(channelServiceAddress, &fakeFanoutHandler{
+		statuses: map[types.UID]fanout.SubscriberStatus{
+			subscriber1UID: {Ready: true},
+			subscriber2UID: {Ready: false, Message: "unexpected HTTP response, expected 2xx, got 500"},
+		},
+	})
+	r := &Reconciler{
+		multiChannelMessageHandler: handler,
+		messagingClientSet:         fakeEventingClient.MessagingV1(),
+	}
+	if err := r.patchSubscriberStatus(ctx, imc); err != nil {
+		t.Fatal("patchSubscriberStatus() =", err)
+	}
+
+	want := `[{"op":"add","path":"/status/subscribers","value":[{"observedGeneration":1,"ready":"True","uid":"2f9b5e8e-deb6-11e8-9f32-f2801f1b9fd1"},{"message":"unexpected HTTP response, expected 2xx, got 500","observedGeneration":2,"ready":"False","uid":"34c5aec8-deb6-11e8-9f32-f2801f1b9fd1"}]}]`
+	actions := fakeEventingClient.Actions()
+	if len(actions) != 1 {
+		t.Fatalf("Unexpected actions %v", actions)
+	}
+	if got := string(actions[0].(clientgotesting.PatchAction).GetPatch()); got != want {
+		t.Errorf("Unexpected patch. Expected %s, Actual %s", want, got)
+	}
+}
+
 func TestReconciler_InvalidInputs(t *testing.T) {
 	testCases := map[string]struct {
 		imc interface{}
@@ -574,3 +828,12 @@ func (f *fakeMultiChannelHandler) GetChannelHandler(host string) fanout.MessageH
 func (f *fakeMultiChannelHandler) CountChannelHandlers() int {
 	return len(f.handlers)
 }
+
+type fakeFanoutHandler struct {
+	fanout.MessageHandler
+	statuses map[types.UID]fanout.SubscriberStatus
+}
+
+func (f *fakeFanoutHandler) GetSubscriberStatuses() map[types.UID]fanout.SubscriberStatus {
+	return f.statuses
+}
@@ -46,6 +46,9 @@ type DispatcherReadyChecker struct {
 	// Allows listing/counting the handlers which have already been registered.
 	chMsgHandler multichannelfanout.MultiChannelMessageHandler
 
+	// Name of the dispatcher replica, only the channels placed on it are expected to be registered.
+	podName string
+
 	// Allows safe concurrent read/write of 'isReady'.
 	sync.Mutex
 
@@ -71,7 +74,7 @@ func (c *DispatcherReadyChecker) IsReady() (bool, error) {
 
 	readyChannels := make([]*messagingv1.InMemoryChannel, 0, len(channels))
 	for _, channel := range channels {
-		if channel.IsReady() {
+		if channel.IsReady() && isPlacedOn(channel, c.podName) {
 			readyChannels = append(readyChannels, channel)
 		}
 	}
@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	eventingduckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
 	"knative.dev/eventing/pkg/channel/fanout"
 
 	. "knative.dev/eventing/pkg/reconciler/testing/v1"
@@ -72,3 +73,33 @@ func TestReadinessChecker(t *testing.T) {
 		t.Errorf("Unexpected Readiness probe status. Expected %v. Actual %v.", readinessProbeReady, res.StatusCode)
 	}
 }
+
+func TestReadinessCheckerSharding(t *testing.T) {
+	// Lister with one in-memory channel placed on another dispatcher replica.
+	ls := NewListers([]runtime.Object{
+		NewInMemoryChannel("imc-channel", testNS,
+			WithInMemoryChannelStatefulSetReady(),
+			WithInMemoryChannelServiceReady(),
+			WithInMemoryChannelEndpointsReady(),
+			WithInMemoryChannelChannelServiceReady(),
+			WithInMemoryChannelAddress("fake-address"),
+			WithInMemoryChannelDLSUnknown(),
+			WithInMemoryChannelPlacements(eventingduckv1alpha1.Placement{PodName: "imc-dispatcher-1", VReplicas: 1}),
+		),
+	})
+
+	rc := &DispatcherReadyChecker{
+		chLister:     ls.GetInMemoryChannelLister(),
+		chMsgHandler: newFakeMultiChannelHandler(),
+		podName:      "imc-dispatcher-0",
+	}
+
+	// 1 imc placed on another replica and 0 handlers - dispatcher is ready.
+	ready, err := rc.IsReady()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ready {
+		t.Error("Dispatcher not ready without the channels placed on other replicas")
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	nethttp "net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"knative.dev/eventing/pkg/channel/fanout"
+	"knative.dev/eventing/pkg/channel/multichannelfanout"
+	"knative.dev/eventing/pkg/channel/retention"
+	messaginglisters "knative.dev/eventing/pkg/client/listers/messaging/v1"
+)
+
+const replayPathPrefix = "/replay/"
+
+// replayer is implemented by the fanout handlers replaying the retained events to a subscriber.
+type replayer interface {
+	ReplayTo(ctx context.Context, uid types.UID, r retention.Range) (int, error)
+}
+
+// replayResponse is the body of the responses of the replay endpoint.
+type replayResponse struct {
+	Replayed int    `json:"replayed"`
+	Error    string `json:"error,omitempty"`
+}
+
+// replayHandler is the admin endpoint replaying the events retained by an InMemoryChannel to the
+// subscriber of one of its Subscriptions:
+//
+//	POST /replay/<namespace>/<channel>?subscription=<uid>&from=<RFC3339>&to=<RFC3339>&fromOffset=<n>&toOffset=<n>
+//
+// All the query parameters but subscription are optional.
+type replayHandler struct {
+	lister  messaginglisters.InMemoryChannelLister
+	handler multichannelfanout.MultiChannelMessageHandler
+	logger  *zap.Logger
+}
+
+func (h *replayHandler) ServeHTTP(w nethttp.ResponseWriter, req *nethttp.Request) {
+	if req.Method != nethttp.MethodPost {
+		w.WriteHeader(nethttp.StatusMethodNotAllowed)
+		return
+	}
+	parts := strings.Split(strings.TrimPrefix(req.URL.Path, replayPathPrefix), "/")
+	if !strings.HasPrefix(req.URL.Path, replayPathPrefix) || len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		w.WriteHeader(nethttp.StatusNotFound)
+		return
+	}
+	namespace, name := parts[0], parts[1]
+
+	query := req.URL.Query()
+	uid := types.UID(query.Get("subscription"))
+	if uid == "" {
+		writeReplayResponse(w, nethttp.StatusBadRequest, replayResponse{Error: "missing subscription"})
+		return
+	}
+	r, err := parseReplayRange(query)
+	if err != nil {
+		writeReplayResponse(w, nethttp.StatusBadRequest, replayResponse{Error: err.Error()})
+		return
+	}
+
+	imc, err := h.lister.InMemoryChannels(namespace).Get(name)
+	if apierrs.IsNotFound(err) {
+		writeReplayResponse(w, nethttp.StatusNotFound, replayResponse{Error: err.Error()})
+		return
+	} else if err != nil {
+		writeReplayResponse(w, nethttp.StatusInternalServerError, replayResponse{Error: err.Error()})
+		return
+	}
+	var channelHandler replayer
+	if imc.Status.Address != nil && imc.Status.Address.URL != nil {
+		channelHandler, _ = h.handler.GetChannelHandler(imc.Status.Address.URL.Host).(replayer)
+	}
+	if channelHandler == nil {
+		writeReplayResponse(w, nethttp.StatusNotFound, replayResponse{Error: "the channel is not ready"})
+		return
+	}
+
+	n, err := channelHandler.ReplayTo(req.Context(), uid, r)
+	switch {
+	case errors.Is(err, fanout.ErrUnknownSubscriber):
+		writeReplayResponse(w, nethttp.StatusNotFound, replayResponse{Error: err.Error()})
+	case errors.Is(err, fanout.ErrRetentionDisabled):
+		writeReplayResponse(w, nethttp.StatusConflict, replayResponse{Error: err.Error()})
+	case errors.Is(err, fanout.ErrReplayThrottled):
+		// The events before the offset are replayed, the client resumes from the offset later.
+		w.Header().Set("Retry-After", "1")
+		writeReplayResponse(w, nethttp.StatusTooManyRequests, replayResponse{Replayed: n, Error: err.Error()})
+	case err != nil:
+		writeReplayResponse(w, nethttp.StatusInternalServerError, replayResponse{Error: err.Error()})
+	default:
+		h.logger.Info("Replaying events",
+			zap.String("namespace", namespace), zap.String("channel", name), zap.String("subscription", string(uid)), zap.Int("count", n))
+		writeReplayResponse(w, nethttp.StatusAccepted, replayResponse{Replayed: n})
+	}
+}
+
+func parseReplayRange(query map[string][]string) (retention.Range, error) {
+	var r retention.Range
+	get := func(key string) string {
+		if v := query[key]; len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+	var err error
+	if v := get("from"); v != "" {
+		if r.From, err = time.Parse(time.RFC3339, v); err != nil {
+			return r, fmt.Errorf("invalid from: %w", err)
+		}
+	}
+	if v := get("to"); v != "" {
+		if r.To, err = time.Parse(time.RFC3339, v); err != nil {
+			return r, fmt.Errorf("invalid to: %w", err)
+		}
+	}
+	if v := get("fromOffset"); v != "" {
+		if r.FromOffset, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return r, fmt.Errorf("invalid fromOffset: %w", err)
+		}
+	}
+	if v := get("toOffset"); v != "" {
+		if r.ToOffset, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return r, fmt.Errorf("invalid toOffset: %w", err)
+		}
+	}
+	return r, nil
+}
+
+func writeReplayResponse(w nethttp.ResponseWriter, status int, resp replayResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// serveReplay serves the replay endpoint on the port of the loopback interface until the context
+// is done. The endpoint isn't authenticated, so it is only reachable from within the pod.
+func serveReplay(ctx context.Context, port int, handler nethttp.Handler) error {
+	server := &nethttp.Server{
+		Addr:              fmt.Sprintf("127.0.0.1:%d", port),
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		_ = server.Shutdown(context.Background())
+	}()
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, nethttp.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
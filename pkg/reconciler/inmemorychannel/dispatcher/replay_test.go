@@ -0,0 +1,143 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"knative.dev/eventing/pkg/channel/fanout"
+	"knative.dev/eventing/pkg/channel/retention"
+	. "knative.dev/eventing/pkg/reconciler/testing/v1"
+)
+
+type fakeReplayer struct {
+	fanout.MessageHandler
+	uid types.UID
+	r   retention.Range
+	err error
+}
+
+func (f *fakeReplayer) ReplayTo(_ context.Context, uid types.UID, r retention.Range) (int, error) {
+	f.uid = uid
+	f.r = r
+	if f.err != nil {
+		return 0, f.err
+	}
+	return 3, nil
+}
+
+func TestReplayHandler(t *testing.T) {
+	imc := NewInMemoryChannel(imcName, testNS, WithInMemoryChannelAddress(channelServiceAddress))
+	listers := NewListers([]runtime.Object{imc})
+	from := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name      string
+		method    string
+		target    string
+		err       error
+		want      int
+		wantUID   types.UID
+		wantRange retention.Range
+	}{{
+		name:    "replay all",
+		target:  "/replay/" + testNS + "/" + imcName + "?subscription=" + string(subscriber1UID),
+		want:    http.StatusAccepted,
+		wantUID: subscriber1UID,
+	}, {
+		name:      "replay a range",
+		target:    "/replay/" + testNS + "/" + imcName + "?subscription=" + string(subscriber1UID) + "&from=2022-01-01T00:00:00Z&fromOffset=4&toOffset=10",
+		want:      http.StatusAccepted,
+		wantUID:   subscriber1UID,
+		wantRange: retention.Range{From: from, FromOffset: 4, ToOffset: 10},
+	}, {
+		name:   "not a POST",
+		method: http.MethodGet,
+		target: "/replay/" + testNS + "/" + imcName + "?subscription=" + string(subscriber1UID),
+		want:   http.StatusMethodNotAllowed,
+	}, {
+		name:   "invalid path",
+		target: "/replay/" + testNS + "?subscription=" + string(subscriber1UID),
+		want:   http.StatusNotFound,
+	}, {
+		name:   "missing subscription",
+		target: "/replay/" + testNS + "/" + imcName,
+		want:   http.StatusBadRequest,
+	}, {
+		name:   "invalid range",
+		target: "/replay/" + testNS + "/" + imcName + "?subscription=" + string(subscriber1UID) + "&to=yesterday",
+		want:   http.StatusBadRequest,
+	}, {
+		name:   "unknown channel",
+		target: "/replay/" + testNS + "/unknown?subscription=" + string(subscriber1UID),
+		want:   http.StatusNotFound,
+	}, {
+		name:    "unknown subscriber",
+		target:  "/replay/" + testNS + "/" + imcName + "?subscription=unknown",
+		err:     fanout.ErrUnknownSubscriber,
+		want:    http.StatusNotFound,
+		wantUID: "unknown",
+	}, {
+		name:    "retention disabled",
+		target:  "/replay/" + testNS + "/" + imcName + "?subscription=" + string(subscriber1UID),
+		err:     fanout.ErrRetentionDisabled,
+		want:    http.StatusConflict,
+		wantUID: subscriber1UID,
+	}, {
+		name:    "too many events in flight",
+		target:  "/replay/" + testNS + "/" + imcName + "?subscription=" + string(subscriber1UID),
+		err:     fanout.ErrReplayThrottled,
+		want:    http.StatusTooManyRequests,
+		wantUID: subscriber1UID,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			replayer := &fakeReplayer{err: tc.err}
+			handler := newFakeMultiChannelHandler()
+			handler.SetChannelHandler(channelServiceAddress, replayer)
+			h := &replayHandler{
+				lister:  listers.GetInMemoryChannelLister(),
+				handler: handler,
+				logger:  zap.NewNop(),
+			}
+
+			method := tc.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			resp := httptest.NewRecorder()
+			h.ServeHTTP(resp, httptest.NewRequest(method, tc.target, nil))
+			if resp.Code != tc.want {
+				t.Errorf("Unexpected status code. Expected %v, Actual %v: %s", tc.want, resp.Code, resp.Body.String())
+			}
+			if replayer.uid != tc.wantUID {
+				t.Errorf("Unexpected subscription %q, want %q", replayer.uid, tc.wantUID)
+			}
+			if diff := cmp.Diff(tc.wantRange, replayer.r); diff != "" {
+				t.Error("Unexpected range (-want, +got) =", diff)
+			}
+		})
+	}
+}
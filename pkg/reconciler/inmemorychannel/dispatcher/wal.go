@@ -0,0 +1,85 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"path/filepath"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"knative.dev/eventing/pkg/apis/messaging"
+	v1 "knative.dev/eventing/pkg/apis/messaging/v1"
+	"knative.dev/eventing/pkg/channel/wal"
+)
+
+// isPersistent returns true if the InMemoryChannel buffers the accepted events in a write-ahead log.
+func isPersistent(imc *v1.InMemoryChannel) bool {
+	return imc.GetAnnotations()[messaging.BufferingAnnotationKey] == messaging.BufferingPersistent
+}
+
+// writeAheadLogs keeps the write-ahead logs of the InMemoryChannels using persistent buffering,
+// stored in dir. The zero value is ready to use, but has persistent buffering disabled.
+type writeAheadLogs struct {
+	dir  string
+	mu   sync.Mutex
+	logs map[types.NamespacedName]*wal.Log
+}
+
+// enabled returns true if the dispatcher is configured with a directory for the write-ahead logs.
+func (w *writeAheadLogs) enabled() bool {
+	return w.dir != ""
+}
+
+// get returns the opened write-ahead log of the channel, or nil.
+func (w *writeAheadLogs) get(key types.NamespacedName) *wal.Log {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.logs[key]
+}
+
+// open opens the write-ahead log of the channel, loading the events pending from a previous run.
+// It returns true if the log was loaded by this call, false if it was already open, in which case
+// its pending events are being dispatched by the handler that opened it.
+func (w *writeAheadLogs) open(key types.NamespacedName) (*wal.Log, bool, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if l, ok := w.logs[key]; ok {
+		return l, false, nil
+	}
+	l, err := wal.Open(filepath.Join(w.dir, key.Namespace, key.Name+".wal"))
+	if err != nil {
+		return nil, false, err
+	}
+	if w.logs == nil {
+		w.logs = make(map[types.NamespacedName]*wal.Log)
+	}
+	w.logs[key] = l
+	return l, true, nil
+}
+
+// remove closes and deletes the write-ahead log of the channel, if any.
+func (w *writeAheadLogs) remove(key types.NamespacedName) error {
+	w.mu.Lock()
+	l, ok := w.logs[key]
+	delete(w.logs, key)
+	w.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return l.Remove()
+}
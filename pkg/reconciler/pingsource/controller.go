@@ -34,6 +34,7 @@ import (
 
 	"knative.dev/eventing/pkg/adapter/v2"
 	"knative.dev/eventing/pkg/apis/feature"
+	eventingclient "knative.dev/eventing/pkg/client/injection/client"
 	pingsourceinformer "knative.dev/eventing/pkg/client/injection/informers/sources/v1/pingsource"
 	pingsourcereconciler "knative.dev/eventing/pkg/client/injection/reconciler/sources/v1/pingsource"
 	reconcilersource "knative.dev/eventing/pkg/reconciler/source"
@@ -69,14 +70,17 @@ func NewController(
 	pingSourceInformer := pingsourceinformer.Get(ctx)
 
 	r := &Reconciler{
-		kubeClientSet: kubeclient.Get(ctx),
-		leConfig:      leConfig,
-		configAcc:     reconcilersource.WatchConfigurations(ctx, component, cmw),
+		kubeClientSet:     kubeclient.Get(ctx),
+		eventingClientSet: eventingclient.Get(ctx),
+		leConfig:          leConfig,
+		configAcc:         reconcilersource.WatchConfigurations(ctx, component, cmw),
 	}
 
 	impl := pingsourcereconciler.NewImpl(ctx, r, func(impl *controller.Impl) controller.Options {
 		return controller.Options{
 			ConfigStore: featureStore,
+			// The status is updated by the reconciler, see updateStatus.
+			SkipStatusUpdates: true,
 		}
 	})
 
@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -42,6 +43,7 @@ import (
 	"knative.dev/eventing/pkg/adapter/mtping"
 	"knative.dev/eventing/pkg/adapter/v2"
 	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+	"knative.dev/eventing/pkg/client/clientset/versioned"
 	pingsourcereconciler "knative.dev/eventing/pkg/client/injection/reconciler/sources/v1/pingsource"
 	"knative.dev/eventing/pkg/reconciler/pingsource/resources"
 	reconcilersource "knative.dev/eventing/pkg/reconciler/source"
@@ -61,9 +63,17 @@ func newWarningSinkNotFound(sink *duckv1.Destination) pkgreconciler.Event {
 	return pkgreconciler.NewEvent(corev1.EventTypeWarning, "SinkNotFound", "Sink not found: %s", string(b))
 }
 
+func newWarningDeadLetterSinkNotFound(sink *duckv1.Destination) pkgreconciler.Event {
+	b, _ := json.Marshal(sink)
+	return pkgreconciler.NewEvent(corev1.EventTypeWarning, "DeadLetterSinkNotFound", "Dead letter sink not found: %s", string(b))
+}
+
 type Reconciler struct {
 	kubeClientSet kubernetes.Interface
 
+	// eventingClientSet updates the status of the PingSources.
+	eventingClientSet versioned.Interface
+
 	// tracking mt adapter deployment changes
 	tracker tracker.Interface
 
@@ -79,7 +89,54 @@ type Reconciler struct {
 // Check that our Reconciler implements ReconcileKind
 var _ pingsourcereconciler.Interface = (*Reconciler)(nil)
 
+// ReconcileKind implements Interface.ReconcileKind. Unlike the generated reconciler, it updates the
+// status without overwriting the time and the sequence number of the last tick recorded by the receive
+// adapter.
 func (r *Reconciler) ReconcileKind(ctx context.Context, source *sourcesv1.PingSource) pkgreconciler.Event {
+	original := source.DeepCopy()
+
+	pkgreconciler.PreProcessReconcile(ctx, source)
+	event := r.reconcile(ctx, source)
+	pkgreconciler.PostProcessReconcile(ctx, source, original)
+
+	if !equality.Semantic.DeepEqual(original.Status, source.Status) {
+		if err := r.updateStatus(ctx, original, source); err != nil {
+			logging.FromContext(ctx).Warnw("Failed to update resource status", zap.Error(err))
+			controller.GetEventRecorder(ctx).Eventf(source, corev1.EventTypeWarning, "UpdateFailed",
+				"Failed to update status for %q: %v", source.Name, err)
+			return err
+		}
+	}
+	return event
+}
+
+// updateStatus writes the status of desired, keeping the last schedule time and the last sequence of
+// the latest version of the PingSource, which are only recorded by the receive adapter.
+func (r *Reconciler) updateStatus(ctx context.Context, existing *sourcesv1.PingSource, desired *sourcesv1.PingSource) error {
+	existing = existing.DeepCopy()
+	return pkgreconciler.RetryUpdateConflicts(func(attempts int) (err error) {
+		// The first attempt uses the informer's copy, the next ones fetch the latest version.
+		if attempts > 0 {
+			existing, err = r.eventingClientSet.SourcesV1().PingSources(desired.Namespace).Get(ctx, desired.Name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+		}
+
+		status := desired.Status.DeepCopy()
+		status.LastScheduleTime = existing.Status.LastScheduleTime
+		status.LastSequence = existing.Status.LastSequence
+		if equality.Semantic.DeepEqual(existing.Status, *status) {
+			return nil
+		}
+		existing.Status = *status
+
+		_, err = r.eventingClientSet.SourcesV1().PingSources(existing.Namespace).UpdateStatus(ctx, existing, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, source *sourcesv1.PingSource) pkgreconciler.Event {
 	// This Source attempts to reconcile three things.
 	// 1. Determine the sink's URI.
 	//     - Nothing to delete.
@@ -106,6 +163,21 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, source *sourcesv1.PingSo
 	}
 	source.Status.MarkSink(sinkURI)
 
+	if source.Spec.Delivery != nil && source.Spec.Delivery.DeadLetterSink != nil {
+		dls := source.Spec.Delivery.DeadLetterSink.DeepCopy()
+		if dls.Ref != nil && dls.Ref.Namespace == "" {
+			dls.Ref.Namespace = source.GetNamespace()
+		}
+		deadLetterSinkURI, err := r.sinkResolver.URIFromDestinationV1(ctx, *dls, source)
+		if err != nil {
+			source.Status.MarkNoDeadLetterSink("NotFound", "")
+			return newWarningDeadLetterSinkNotFound(dls)
+		}
+		source.Status.MarkDeadLetterSink(deadLetterSinkURI)
+	} else {
+		source.Status.MarkDeadLetterSink(nil)
+	}
+
 	// Make sure the global mt receive adapter is running
 	d, err := r.reconcileReceiveAdapter(ctx, source)
 	if err != nil {
@@ -132,6 +204,18 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, source *sourcesv1.PingSo
 		Source: sourcesv1.PingSourceSource(source.Namespace, source.Name),
 	}}
 
+	// The receive adapter records the time of the last tick fired, a one-shot source is completed
+	// once its tick is fired.
+	if source.Spec.At != nil {
+		if last := source.Status.LastScheduleTime; last != nil && !last.Before(source.Spec.At) {
+			source.Status.MarkCompleted()
+		} else {
+			source.Status.MarkNotCompleted("Scheduled", "The event is scheduled at %s.", source.Spec.At.UTC().Format(time.RFC3339))
+		}
+	} else {
+		source.Status.ClearCompleted()
+	}
+
 	return nil
 }
 
@@ -20,11 +20,13 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -45,7 +47,10 @@ import (
 
 	"knative.dev/eventing/pkg/adapter/mtping"
 	"knative.dev/eventing/pkg/adapter/v2"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/apis/feature"
 	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+	fakeeventingclientset "knative.dev/eventing/pkg/client/clientset/versioned/fake"
 	fakeeventingclient "knative.dev/eventing/pkg/client/injection/client/fake"
 	"knative.dev/eventing/pkg/client/injection/reconciler/sources/v1/pingsource"
 	"knative.dev/eventing/pkg/reconciler/pingsource/resources"
@@ -77,6 +82,18 @@ var (
 	}
 	sinkDNS = "sink.mynamespace.svc." + network.GetClusterDomainName()
 	sinkURI = apis.HTTP(sinkDNS)
+
+	dlsDest = duckv1.Destination{
+		Ref: &duckv1.KReference{
+			Name:       dlsName,
+			Kind:       "Channel",
+			APIVersion: "messaging.knative.dev/v1",
+		},
+	}
+	dlsDNS = "dls.mynamespace.svc." + network.GetClusterDomainName()
+	dlsURI = apis.HTTP(dlsDNS)
+
+	testAt = metav1.NewTime(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
 )
 
 const (
@@ -89,6 +106,7 @@ const (
 	testDataBase64  = "ZGF0YQ==" // "data"
 
 	sinkName   = "testsink"
+	dlsName    = "testdls"
 	generation = 1
 )
 
@@ -397,6 +415,201 @@ func TestAllCases(t *testing.T) {
 					rtv1.WithPingSourceStatusObservedGeneration(generation),
 				),
 			}},
+		}, {
+			Name: "missing dead letter sink",
+			Ctx: feature.ToContext(context.TODO(), feature.Flags{
+				feature.SourceDelivery: feature.Enabled,
+			}),
+			Objects: []runtime.Object{
+				rtv1.NewPingSource(sourceName, testNS,
+					rtv1.WithPingSourceSpec(sourcesv1.PingSourceSpec{
+						Schedule:    testSchedule,
+						ContentType: testContentType,
+						Data:        testData,
+						Delivery:    &eventingduckv1.DeliverySpec{DeadLetterSink: &dlsDest},
+						SourceSpec: duckv1.SourceSpec{
+							Sink: sinkDest,
+						},
+					}),
+					rtv1.WithPingSource(sourceUID),
+					rtv1.WithPingSourceObjectMetaGeneration(generation),
+				),
+				rtv1.NewChannel(sinkName, testNS,
+					rtv1.WithInitChannelConditions,
+					rtv1.WithChannelAddress(sinkDNS),
+				),
+			},
+			Key: testNS + "/" + sourceName,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: rtv1.NewPingSource(sourceName, testNS,
+					rtv1.WithPingSourceSpec(sourcesv1.PingSourceSpec{
+						Schedule:    testSchedule,
+						ContentType: testContentType,
+						Data:        testData,
+						Delivery:    &eventingduckv1.DeliverySpec{DeadLetterSink: &dlsDest},
+						SourceSpec: duckv1.SourceSpec{
+							Sink: sinkDest,
+						},
+					}),
+					rtv1.WithPingSource(sourceUID),
+					rtv1.WithPingSourceObjectMetaGeneration(generation),
+					// Status Update:
+					rtv1.WithInitPingSourceConditions,
+					rtv1.WithPingSourceStatusObservedGeneration(generation),
+					rtv1.WithPingSourceSink(sinkURI),
+					rtv1.WithPingSourceDeadLetterSinkNotFound,
+				),
+			}},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, "DeadLetterSinkNotFound",
+					`Dead letter sink not found: {"ref":{"kind":"Channel","namespace":"testnamespace","name":"testdls","apiVersion":"messaging.knative.dev/v1"}}`),
+			},
+		}, {
+			Name: "valid with dead letter sink",
+			Ctx: feature.ToContext(context.TODO(), feature.Flags{
+				feature.SourceDelivery: feature.Enabled,
+			}),
+			Objects: []runtime.Object{
+				rtv1.NewPingSource(sourceName, testNS,
+					rtv1.WithPingSourceSpec(sourcesv1.PingSourceSpec{
+						Schedule:    testSchedule,
+						ContentType: testContentType,
+						Data:        testData,
+						Delivery:    &eventingduckv1.DeliverySpec{DeadLetterSink: &dlsDest},
+						SourceSpec: duckv1.SourceSpec{
+							Sink: sinkDest,
+						},
+					}),
+					rtv1.WithPingSource(sourceUID),
+					rtv1.WithPingSourceObjectMetaGeneration(generation),
+				),
+				rtv1.NewChannel(sinkName, testNS,
+					rtv1.WithInitChannelConditions,
+					rtv1.WithChannelAddress(sinkDNS),
+				),
+				rtv1.NewChannel(dlsName, testNS,
+					rtv1.WithInitChannelConditions,
+					rtv1.WithChannelAddress(dlsDNS),
+				),
+				makeAvailableMTAdapter(),
+			},
+			Key: testNS + "/" + sourceName,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: rtv1.NewPingSource(sourceName, testNS,
+					rtv1.WithPingSourceSpec(sourcesv1.PingSourceSpec{
+						Schedule:    testSchedule,
+						ContentType: testContentType,
+						Data:        testData,
+						Delivery:    &eventingduckv1.DeliverySpec{DeadLetterSink: &dlsDest},
+						SourceSpec: duckv1.SourceSpec{
+							Sink: sinkDest,
+						},
+					}),
+					rtv1.WithPingSource(sourceUID),
+					rtv1.WithPingSourceObjectMetaGeneration(generation),
+					// Status Update:
+					rtv1.WithInitPingSourceConditions,
+					rtv1.WithPingSourceDeployed,
+					rtv1.WithPingSourceSink(sinkURI),
+					rtv1.WithPingSourceDeadLetterSink(dlsURI),
+					rtv1.WithPingSourceCloudEventAttributes,
+					rtv1.WithPingSourceStatusObservedGeneration(generation),
+				),
+			}},
+		}, {
+			Name: "one-shot scheduled",
+			Ctx: feature.ToContext(context.TODO(), feature.Flags{
+				feature.PingSourceIntervalAt: feature.Enabled,
+			}),
+			Objects: []runtime.Object{
+				rtv1.NewPingSource(sourceName, testNS,
+					rtv1.WithPingSourceSpec(sourcesv1.PingSourceSpec{
+						At:          &testAt,
+						ContentType: testContentType,
+						Data:        testData,
+						SourceSpec: duckv1.SourceSpec{
+							Sink: sinkDest,
+						},
+					}),
+					rtv1.WithPingSource(sourceUID),
+					rtv1.WithPingSourceObjectMetaGeneration(generation),
+				),
+				rtv1.NewChannel(sinkName, testNS,
+					rtv1.WithInitChannelConditions,
+					rtv1.WithChannelAddress(sinkDNS),
+				),
+				makeAvailableMTAdapter(),
+			},
+			Key: testNS + "/" + sourceName,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: rtv1.NewPingSource(sourceName, testNS,
+					rtv1.WithPingSourceSpec(sourcesv1.PingSourceSpec{
+						At:          &testAt,
+						ContentType: testContentType,
+						Data:        testData,
+						SourceSpec: duckv1.SourceSpec{
+							Sink: sinkDest,
+						},
+					}),
+					rtv1.WithPingSource(sourceUID),
+					rtv1.WithPingSourceObjectMetaGeneration(generation),
+					// Status Update:
+					rtv1.WithInitPingSourceConditions,
+					rtv1.WithPingSourceDeployed,
+					rtv1.WithPingSourceSink(sinkURI),
+					rtv1.WithPingSourceCloudEventAttributes,
+					rtv1.WithPingSourceNotCompleted(testAt),
+					rtv1.WithPingSourceStatusObservedGeneration(generation),
+				),
+			}},
+		}, {
+			Name: "one-shot completed",
+			Ctx: feature.ToContext(context.TODO(), feature.Flags{
+				feature.PingSourceIntervalAt: feature.Enabled,
+			}),
+			Objects: []runtime.Object{
+				rtv1.NewPingSource(sourceName, testNS,
+					rtv1.WithPingSourceSpec(sourcesv1.PingSourceSpec{
+						At:          &testAt,
+						ContentType: testContentType,
+						Data:        testData,
+						SourceSpec: duckv1.SourceSpec{
+							Sink: sinkDest,
+						},
+					}),
+					rtv1.WithPingSource(sourceUID),
+					rtv1.WithPingSourceObjectMetaGeneration(generation),
+					rtv1.WithPingSourceLastScheduleTime(testAt),
+				),
+				rtv1.NewChannel(sinkName, testNS,
+					rtv1.WithInitChannelConditions,
+					rtv1.WithChannelAddress(sinkDNS),
+				),
+				makeAvailableMTAdapter(),
+			},
+			Key: testNS + "/" + sourceName,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: rtv1.NewPingSource(sourceName, testNS,
+					rtv1.WithPingSourceSpec(sourcesv1.PingSourceSpec{
+						At:          &testAt,
+						ContentType: testContentType,
+						Data:        testData,
+						SourceSpec: duckv1.SourceSpec{
+							Sink: sinkDest,
+						},
+					}),
+					rtv1.WithPingSource(sourceUID),
+					rtv1.WithPingSourceObjectMetaGeneration(generation),
+					rtv1.WithPingSourceLastScheduleTime(testAt),
+					// Status Update:
+					rtv1.WithInitPingSourceConditions,
+					rtv1.WithPingSourceDeployed,
+					rtv1.WithPingSourceSink(sinkURI),
+					rtv1.WithPingSourceCloudEventAttributes,
+					rtv1.WithPingSourceCompleted,
+					rtv1.WithPingSourceStatusObservedGeneration(generation),
+				),
+			}},
 		},
 	}
 
@@ -404,21 +617,59 @@ func TestAllCases(t *testing.T) {
 	table.Test(t, rtv1.MakeFactory(func(ctx context.Context, listers *rtv1.Listers, cmw configmap.Watcher) controller.Reconciler {
 		ctx = addressable.WithDuck(ctx)
 		r := &Reconciler{
-			configAcc:     &reconcilersource.EmptyVarsGenerator{},
-			kubeClientSet: fakekubeclient.Get(ctx),
-			tracker:       tracker.New(func(types.NamespacedName) {}, 0),
+			configAcc:         &reconcilersource.EmptyVarsGenerator{},
+			kubeClientSet:     fakekubeclient.Get(ctx),
+			eventingClientSet: fakeeventingclient.Get(ctx),
+			tracker:           tracker.New(func(types.NamespacedName) {}, 0),
 		}
 		r.sinkResolver = resolver.NewURIResolverFromTracker(ctx, tracker.New(func(types.NamespacedName) {}, 0))
 
 		return pingsource.NewReconciler(ctx, logging.FromContext(ctx),
 			fakeeventingclient.Get(ctx), listers.GetPingSourceLister(),
-			controller.GetEventRecorder(ctx), r)
+			controller.GetEventRecorder(ctx), r, controller.Options{SkipStatusUpdates: true})
 	},
 		true,
 		logger,
 	))
 }
 
+func TestUpdateStatusKeepsLastSchedule(t *testing.T) {
+	existing := rtv1.NewPingSource(sourceName, testNS)
+	// The receive adapter recorded a tick since the informer's copy was cached.
+	latest := existing.DeepCopy()
+	latest.ResourceVersion = "2"
+	latest.Status.LastScheduleTime = &testAt
+	latest.Status.LastSequence = 3
+
+	client := fakeeventingclientset.NewSimpleClientset(latest)
+	conflict := true
+	client.PrependReactor("update", "pingsources", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		if conflict {
+			conflict = false
+			return true, nil, apierrors.NewConflict(sourcesv1.Resource("pingsources"), sourceName, nil)
+		}
+		return false, nil, nil
+	})
+
+	desired := existing.DeepCopy()
+	desired.Status.MarkSink(sinkURI)
+	r := &Reconciler{eventingClientSet: client}
+	if err := r.updateStatus(context.Background(), existing, desired); err != nil {
+		t.Fatal("updateStatus() =", err)
+	}
+
+	got, err := client.SourcesV1().PingSources(testNS).Get(context.Background(), sourceName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.LastScheduleTime == nil || !got.Status.LastScheduleTime.Equal(&testAt) || got.Status.LastSequence != 3 {
+		t.Errorf("last schedule = %v, %d, want %v, 3", got.Status.LastScheduleTime, got.Status.LastSequence, testAt)
+	}
+	if got.Status.SinkURI.String() != sinkURI.String() {
+		t.Errorf("sink URI = %v, want %v", got.Status.SinkURI, sinkURI)
+	}
+}
+
 func MakeMTAdapter() *appsv1.Deployment {
 	args := resources.Args{
 		ConfigEnvVars:   (&reconcilersource.EmptyVarsGenerator{}).ToEnvVars(),
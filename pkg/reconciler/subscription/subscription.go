@@ -41,6 +41,7 @@ import (
 
 	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/apis/feature"
+	"knative.dev/eventing/pkg/apis/messaging"
 	v1 "knative.dev/eventing/pkg/apis/messaging/v1"
 	subscriptionreconciler "knative.dev/eventing/pkg/client/injection/reconciler/messaging/v1/subscription"
 	listers "knative.dev/eventing/pkg/client/listers/messaging/v1"
@@ -56,6 +57,7 @@ const (
 	subscriberResolveFailed             = "SubscriberResolveFailed"
 	replyResolveFailed                  = "ReplyResolveFailed"
 	deadLetterSinkResolveFailed         = "DeadLetterSinkResolveFailed"
+	subscriptionFiltersNotSupported     = "SubscriptionFiltersNotSupported"
 )
 
 var (
@@ -100,6 +102,11 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, subscription *v1.Subscri
 		return event
 	}
 
+	// The subscriber would receive all the events of a channel that doesn't evaluate its filters.
+	if event := r.checkChannelSupportsFilters(channel, subscription); event != nil {
+		return event
+	}
+
 	// Sync the resolved subscription into the channel.
 	if event := r.syncChannel(ctx, channel, subscription); event != nil {
 		return event
@@ -152,6 +159,26 @@ func (r Reconciler) checkChannelStatusForSubscription(ctx context.Context, chann
 	return nil
 }
 
+// checkChannelSupportsFilters marks the subscription as not added to the channel when it has filters
+// and the channel doesn't declare that it evaluates the filters of its subscribers.
+func (r Reconciler) checkChannelSupportsFilters(channel *eventingduckv1.Channelable, sub *v1.Subscription) pkgreconciler.Event {
+	if len(sub.Spec.Filters) == 0 || supportsFilters(channel) {
+		return nil
+	}
+	sub.Status.MarkNotAddedToChannel(subscriptionFiltersNotSupported, "Channel %q doesn't support the filters of the subscribers", channel.Name)
+	return pkgreconciler.NewEvent(corev1.EventTypeWarning, subscriptionFiltersNotSupported, "Channel %q doesn't support the filters of the subscribers", channel.Name)
+}
+
+// supportsFilters returns true if the channel evaluates the filters of its subscribers. The
+// InMemoryChannels always do, including the ones created before they were annotated by default.
+func supportsFilters(channel *eventingduckv1.Channelable) bool {
+	if channel.Annotations[messaging.SubscriptionFiltersAnnotationKey] == "true" {
+		return true
+	}
+	gvk := channel.GroupVersionKind()
+	return gvk.Group == messaging.GroupName && gvk.Kind == "InMemoryChannel"
+}
+
 func (r Reconciler) syncChannel(ctx context.Context, channel *eventingduckv1.Channelable, sub *v1.Subscription) pkgreconciler.Event {
 	// Ok, now that we have the Channel and at least one of the Call/Result, let's reconcile
 	// the Channel with this information.
@@ -489,20 +516,24 @@ func (r *Reconciler) updateChannelAddSubscription(channel *eventingduckv1.Channe
 	// Look to update subscriber.
 	for i, v := range channel.Spec.Subscribers {
 		if v.UID == sub.UID {
+			channel.Spec.Subscribers[i].Name = &sub.Name
 			channel.Spec.Subscribers[i].Generation = sub.Generation
 			channel.Spec.Subscribers[i].SubscriberURI = sub.Status.PhysicalSubscription.SubscriberURI
 			channel.Spec.Subscribers[i].ReplyURI = sub.Status.PhysicalSubscription.ReplyURI
 			channel.Spec.Subscribers[i].Delivery = deliverySpec(sub, channel)
+			channel.Spec.Subscribers[i].Filters = sub.Spec.Filters
 			return
 		}
 	}
 
 	toAdd := eventingduckv1.SubscriberSpec{
 		UID:           sub.UID,
+		Name:          &sub.Name,
 		Generation:    sub.Generation,
 		SubscriberURI: sub.Status.PhysicalSubscription.SubscriberURI,
 		ReplyURI:      sub.Status.PhysicalSubscription.ReplyURI,
 		Delivery:      deliverySpec(sub, channel),
+		Filters:       sub.Spec.Filters,
 	}
 
 	// Must not have been found. Add it.
@@ -523,7 +554,12 @@ func deliverySpec(sub *v1.Subscription, channel *eventingduckv1.Channelable) (de
 			channel.Spec.Delivery.Retry != nil ||
 			channel.Spec.Delivery.BackoffPolicy != nil ||
 			channel.Spec.Delivery.Timeout != nil ||
-			channel.Spec.Delivery.RetryAfterMax != nil {
+			channel.Spec.Delivery.RetryAfterMax != nil ||
+			channel.Spec.Delivery.CircuitBreaker != nil ||
+			channel.Spec.Delivery.BackoffMaxDelay != nil ||
+			channel.Spec.Delivery.BackoffJitter != nil ||
+			channel.Spec.Delivery.RetryStatusCodes != nil ||
+			channel.Spec.Delivery.DeadLetterPayload != nil {
 			if delivery == nil {
 				delivery = &eventingduckv1.DeliverySpec{}
 			}
@@ -532,6 +568,11 @@ func deliverySpec(sub *v1.Subscription, channel *eventingduckv1.Channelable) (de
 			delivery.BackoffDelay = channel.Spec.Delivery.BackoffDelay
 			delivery.Timeout = channel.Spec.Delivery.Timeout
 			delivery.RetryAfterMax = channel.Spec.Delivery.RetryAfterMax
+			delivery.CircuitBreaker = channel.Spec.Delivery.CircuitBreaker
+			delivery.BackoffMaxDelay = channel.Spec.Delivery.BackoffMaxDelay
+			delivery.BackoffJitter = channel.Spec.Delivery.BackoffJitter
+			delivery.RetryStatusCodes = channel.Spec.Delivery.RetryStatusCodes
+			delivery.DeadLetterPayload = channel.Spec.Delivery.DeadLetterPayload
 		}
 		return
 	}
@@ -550,7 +591,12 @@ func deliverySpec(sub *v1.Subscription, channel *eventingduckv1.Channelable) (de
 			sub.Spec.Delivery.Retry != nil ||
 			sub.Spec.Delivery.BackoffPolicy != nil ||
 			sub.Spec.Delivery.Timeout != nil ||
-			sub.Spec.Delivery.RetryAfterMax != nil) {
+			sub.Spec.Delivery.RetryAfterMax != nil ||
+			sub.Spec.Delivery.CircuitBreaker != nil ||
+			sub.Spec.Delivery.BackoffMaxDelay != nil ||
+			sub.Spec.Delivery.BackoffJitter != nil ||
+			sub.Spec.Delivery.RetryStatusCodes != nil ||
+			sub.Spec.Delivery.DeadLetterPayload != nil) {
 		if delivery == nil {
 			delivery = &eventingduckv1.DeliverySpec{}
 		}
@@ -559,6 +605,11 @@ func deliverySpec(sub *v1.Subscription, channel *eventingduckv1.Channelable) (de
 		delivery.BackoffDelay = sub.Spec.Delivery.BackoffDelay
 		delivery.Timeout = sub.Spec.Delivery.Timeout
 		delivery.RetryAfterMax = sub.Spec.Delivery.RetryAfterMax
+		delivery.CircuitBreaker = sub.Spec.Delivery.CircuitBreaker
+		delivery.BackoffMaxDelay = sub.Spec.Delivery.BackoffMaxDelay
+		delivery.BackoffJitter = sub.Spec.Delivery.BackoffJitter
+		delivery.RetryStatusCodes = sub.Spec.Delivery.RetryStatusCodes
+		delivery.DeadLetterPayload = sub.Spec.Delivery.DeadLetterPayload
 	}
 	return
 }
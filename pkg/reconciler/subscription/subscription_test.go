@@ -45,6 +45,7 @@ import (
 
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/apis/feature"
+	"knative.dev/eventing/pkg/apis/messaging"
 	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
 	eventingclient "knative.dev/eventing/pkg/client/injection/client"
 	"knative.dev/eventing/pkg/client/injection/ducks/duck/v1/channelable"
@@ -134,6 +135,9 @@ var (
 
 func TestAllCases(t *testing.T) {
 	linear := eventingduck.BackoffPolicyLinear
+	filters := []eventingduck.SubscriptionsAPIFilter{{
+		Exact: map[string]string{"type": "dev.knative.example"},
+	}}
 
 	table := TableTest{
 		{
@@ -174,6 +178,7 @@ func TestAllCases(t *testing.T) {
 					WithInMemoryChannelReady(channelDNS),
 					WithInMemoryChannelSubscribers([]eventingduck.SubscriberSpec{{
 						UID:           subscriptionUID,
+						Name:          pointer.String(subscriptionName),
 						Generation:    0,
 						SubscriberURI: subscriberURI,
 						ReplyURI:      replyURI,
@@ -240,6 +245,7 @@ func TestAllCases(t *testing.T) {
 					WithInMemoryChannelReady(channelDNS),
 					WithInMemoryChannelSubscribers([]eventingduck.SubscriberSpec{{
 						UID:           subscriptionUID,
+						Name:          pointer.String(subscriptionName),
 						Generation:    0,
 						SubscriberURI: subscriberURI,
 						ReplyURI:      replyURI,
@@ -306,6 +312,7 @@ func TestAllCases(t *testing.T) {
 					WithInMemoryChannelReady(channelDNS),
 					WithInMemoryChannelSubscribers([]eventingduck.SubscriberSpec{{
 						UID:           subscriptionUID,
+						Name:          pointer.String(subscriptionName),
 						Generation:    0,
 						SubscriberURI: subscriberURI,
 						ReplyURI:      replyURI,
@@ -375,6 +382,7 @@ func TestAllCases(t *testing.T) {
 					WithInMemoryChannelReady(channelDNS),
 					WithInMemoryChannelSubscribers([]eventingduck.SubscriberSpec{{
 						UID:           subscriptionUID,
+						Name:          pointer.String(subscriptionName),
 						Generation:    0,
 						SubscriberURI: subscriberURI,
 						ReplyURI:      replyURI,
@@ -454,6 +462,7 @@ func TestAllCases(t *testing.T) {
 					WithInMemoryChannelReady(channelDNS),
 					WithInMemoryChannelSubscribers([]eventingduck.SubscriberSpec{{
 						UID:           subscriptionUID,
+						Name:          pointer.String(subscriptionName),
 						Generation:    0,
 						SubscriberURI: subscriberURI,
 						ReplyURI:      replyURI,
@@ -533,6 +542,7 @@ func TestAllCases(t *testing.T) {
 					WithInMemoryChannelReady(channelDNS),
 					WithInMemoryChannelSubscribers([]eventingduck.SubscriberSpec{{
 						UID:           subscriptionUID,
+						Name:          pointer.String(subscriptionName),
 						Generation:    0,
 						SubscriberURI: subscriberURI,
 						ReplyURI:      replyURI,
@@ -620,6 +630,7 @@ func TestAllCases(t *testing.T) {
 					WithInMemoryChannelReady(channelDNS),
 					WithInMemoryChannelSubscribers([]eventingduck.SubscriberSpec{{
 						UID:           subscriptionUID,
+						Name:          pointer.String(subscriptionName),
 						Generation:    0,
 						SubscriberURI: subscriberURI,
 						ReplyURI:      replyURI,
@@ -909,7 +920,7 @@ func TestAllCases(t *testing.T) {
 			}},
 			WantPatches: []clientgotesting.PatchActionImpl{
 				patchSubscribers(testNS, channelName, []eventingduck.SubscriberSpec{
-					{UID: subscriptionUID, SubscriberURI: subscriberURI},
+					{UID: subscriptionUID, Name: pointer.String(subscriptionName), SubscriberURI: subscriberURI},
 				}),
 				patchFinalizers(testNS, subscriptionName),
 			},
@@ -995,7 +1006,7 @@ func TestAllCases(t *testing.T) {
 			}},
 			WantPatches: []clientgotesting.PatchActionImpl{
 				patchSubscribers(testNS, channelName, []eventingduck.SubscriberSpec{
-					{UID: subscriptionUID, SubscriberURI: subscriberURI, Delivery: &eventingduck.DeliverySpec{DeadLetterSink: &duckv1.Destination{URI: apis.HTTP("dls.mynamespace.svc.cluster.local")}}},
+					{UID: subscriptionUID, Name: pointer.String(subscriptionName), SubscriberURI: subscriberURI, Delivery: &eventingduck.DeliverySpec{DeadLetterSink: &duckv1.Destination{URI: apis.HTTP("dls.mynamespace.svc.cluster.local")}}},
 				}),
 				patchFinalizers(testNS, subscriptionName),
 			},
@@ -1045,7 +1056,7 @@ func TestAllCases(t *testing.T) {
 			}},
 			WantPatches: []clientgotesting.PatchActionImpl{
 				patchSubscribers(testNS, channelName, []eventingduck.SubscriberSpec{
-					{UID: subscriptionUID, SubscriberURI: subscriberURI},
+					{UID: subscriptionUID, Name: pointer.String(subscriptionName), SubscriberURI: subscriberURI},
 				}),
 				patchFinalizers(testNS, subscriptionName),
 			},
@@ -1134,7 +1145,7 @@ func TestAllCases(t *testing.T) {
 			}},
 			WantPatches: []clientgotesting.PatchActionImpl{
 				patchSubscribers(testNS, channelName, []eventingduck.SubscriberSpec{
-					{UID: subscriptionUID, ReplyURI: replyURI, SubscriberURI: subscriberURI},
+					{UID: subscriptionUID, Name: pointer.String(subscriptionName), ReplyURI: replyURI, SubscriberURI: subscriberURI},
 				}),
 				patchFinalizers(testNS, subscriptionName),
 			},
@@ -1182,7 +1193,7 @@ func TestAllCases(t *testing.T) {
 			}},
 			WantPatches: []clientgotesting.PatchActionImpl{
 				patchSubscribers(testNS, channelName, []eventingduck.SubscriberSpec{
-					{UID: subscriptionUID, SubscriberURI: subscriberURI, ReplyURI: replyURI},
+					{UID: subscriptionUID, Name: pointer.String(subscriptionName), SubscriberURI: subscriberURI, ReplyURI: replyURI},
 				}),
 				patchFinalizers(testNS, subscriptionName),
 			},
@@ -1231,7 +1242,7 @@ func TestAllCases(t *testing.T) {
 			}},
 			WantPatches: []clientgotesting.PatchActionImpl{
 				patchSubscribers(testNS, channelName, []eventingduck.SubscriberSpec{
-					{UID: subscriptionUID, Generation: subscriptionGeneration, SubscriberURI: subscriberURI},
+					{UID: subscriptionUID, Name: pointer.String(subscriptionName), Generation: subscriptionGeneration, SubscriberURI: subscriberURI},
 				}),
 			},
 		}, {
@@ -1269,7 +1280,7 @@ func TestAllCases(t *testing.T) {
 			}},
 			WantPatches: []clientgotesting.PatchActionImpl{
 				patchSubscribers(testNS, channelName, []eventingduck.SubscriberSpec{
-					{UID: subscriptionUID, SubscriberURI: serviceURI},
+					{UID: subscriptionUID, Name: pointer.String(subscriptionName), SubscriberURI: serviceURI},
 				}),
 				patchFinalizers(testNS, subscriptionName),
 			},
@@ -1318,7 +1329,7 @@ func TestAllCases(t *testing.T) {
 			}},
 			WantPatches: []clientgotesting.PatchActionImpl{
 				patchSubscribers(testNS, channelName, []eventingduck.SubscriberSpec{
-					{UID: "a-" + subscriptionUID, SubscriberURI: serviceURI},
+					{UID: "a-" + subscriptionUID, Name: pointer.String("a-" + subscriptionName), SubscriberURI: serviceURI},
 				}),
 				patchFinalizers(testNS, "a-"+subscriptionName),
 			},
@@ -1377,7 +1388,7 @@ func TestAllCases(t *testing.T) {
 			}},
 			WantPatches: []clientgotesting.PatchActionImpl{
 				patchSubscribers(testNS, channelName, []eventingduck.SubscriberSpec{
-					{UID: "a-" + subscriptionUID, SubscriberURI: serviceURI, Delivery: &eventingduck.DeliverySpec{DeadLetterSink: &duckv1.Destination{URI: apis.HTTP("dls.mynamespace.svc.cluster.local")}}},
+					{UID: "a-" + subscriptionUID, Name: pointer.String("a-" + subscriptionName), SubscriberURI: serviceURI, Delivery: &eventingduck.DeliverySpec{DeadLetterSink: &duckv1.Destination{URI: apis.HTTP("dls.mynamespace.svc.cluster.local")}}},
 				}),
 				patchFinalizers(testNS, "a-"+subscriptionName),
 			},
@@ -1451,6 +1462,7 @@ func TestAllCases(t *testing.T) {
 				patchSubscribers(testNS, channelName, []eventingduck.SubscriberSpec{
 					{
 						UID:           "a-" + subscriptionUID,
+						Name:          pointer.String("a-" + subscriptionName),
 						SubscriberURI: serviceURI,
 						Delivery: &eventingduck.DeliverySpec{
 							DeadLetterSink: &duckv1.Destination{
@@ -1521,6 +1533,7 @@ func TestAllCases(t *testing.T) {
 				patchSubscribers(testNS, channelName, []eventingduck.SubscriberSpec{
 					{
 						UID:           "a-" + subscriptionUID,
+						Name:          pointer.String("a-" + subscriptionName),
 						SubscriberURI: serviceURI,
 						Delivery: &eventingduck.DeliverySpec{
 							DeadLetterSink: &duckv1.Destination{
@@ -1535,6 +1548,108 @@ func TestAllCases(t *testing.T) {
 				patchFinalizers(testNS, "a-"+subscriptionName),
 			},
 		},
+		{
+			Name: "v1 imc - filters propagated to the subscriber",
+			Ctx: feature.ToContext(context.TODO(), feature.Flags{
+				feature.SubscriptionFilters: feature.Enabled,
+			}),
+			Objects: []runtime.Object{
+				NewSubscription("a-"+subscriptionName, testNS,
+					WithSubscriptionUID("a-"+subscriptionUID),
+					WithSubscriptionChannel(imcV1GVK, channelName),
+					WithSubscriptionSubscriberRef(serviceGVK, serviceName, testNS),
+					WithSubscriptionFilters(filters),
+				),
+				NewInMemoryChannel(channelName, testNS,
+					WithInitInMemoryChannelConditions,
+					WithInMemoryChannelSubscribers(nil),
+					WithInMemoryChannelAddress(channelDNS),
+					WithInMemoryChannelReadySubscriber("a-"+subscriptionUID),
+				),
+				NewService(serviceName, testNS),
+			},
+			Key:     testNS + "/" + "a-" + subscriptionName,
+			WantErr: false,
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "a-"+subscriptionName),
+				Eventf(corev1.EventTypeNormal, "SubscriberSync", "Subscription was synchronized to channel %q", channelName),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewSubscription("a-"+subscriptionName, testNS,
+					WithSubscriptionUID("a-"+subscriptionUID),
+					WithSubscriptionChannel(imcV1GVK, channelName),
+					WithSubscriptionSubscriberRef(serviceGVK, serviceName, testNS),
+					WithSubscriptionFilters(filters),
+					// The first reconciliation will initialize the status conditions.
+					WithInitSubscriptionConditions,
+					MarkReferencesResolved,
+					MarkAddedToChannel,
+					WithSubscriptionPhysicalSubscriptionSubscriber(serviceURI),
+				),
+			}},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchSubscribers(testNS, channelName, []eventingduck.SubscriberSpec{
+					{
+						UID:           "a-" + subscriptionUID,
+						Name:          pointer.String("a-" + subscriptionName),
+						SubscriberURI: serviceURI,
+						Filters:       filters,
+					},
+				}),
+				patchFinalizers(testNS, "a-"+subscriptionName),
+			},
+		},
+		{
+			Name: "v1 imc - filters supported by a channel created before the annotation",
+			Ctx: feature.ToContext(context.TODO(), feature.Flags{
+				feature.SubscriptionFilters: feature.Enabled,
+			}),
+			Objects: []runtime.Object{
+				NewSubscription("a-"+subscriptionName, testNS,
+					WithSubscriptionUID("a-"+subscriptionUID),
+					WithSubscriptionChannel(imcV1GVK, channelName),
+					WithSubscriptionSubscriberRef(serviceGVK, serviceName, testNS),
+					WithSubscriptionFilters(filters),
+				),
+				withoutSubscriptionFiltersAnnotation(NewInMemoryChannel(channelName, testNS,
+					WithInitInMemoryChannelConditions,
+					WithInMemoryChannelSubscribers(nil),
+					WithInMemoryChannelAddress(channelDNS),
+					WithInMemoryChannelReadySubscriber("a-"+subscriptionUID),
+				)),
+				NewService(serviceName, testNS),
+			},
+			Key:     testNS + "/" + "a-" + subscriptionName,
+			WantErr: false,
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "a-"+subscriptionName),
+				Eventf(corev1.EventTypeNormal, "SubscriberSync", "Subscription was synchronized to channel %q", channelName),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewSubscription("a-"+subscriptionName, testNS,
+					WithSubscriptionUID("a-"+subscriptionUID),
+					WithSubscriptionChannel(imcV1GVK, channelName),
+					WithSubscriptionSubscriberRef(serviceGVK, serviceName, testNS),
+					WithSubscriptionFilters(filters),
+					// The first reconciliation will initialize the status conditions.
+					WithInitSubscriptionConditions,
+					MarkReferencesResolved,
+					MarkAddedToChannel,
+					WithSubscriptionPhysicalSubscriptionSubscriber(serviceURI),
+				),
+			}},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchSubscribers(testNS, channelName, []eventingduck.SubscriberSpec{
+					{
+						UID:           "a-" + subscriptionUID,
+						Name:          pointer.String("a-" + subscriptionName),
+						SubscriberURI: serviceURI,
+						Filters:       filters,
+					},
+				}),
+				patchFinalizers(testNS, "a-"+subscriptionName),
+			},
+		},
 		{
 			Name: "v1 imc - delivery defaulting - optional features",
 			Ctx: feature.ToContext(context.TODO(), feature.Flags{
@@ -1585,6 +1700,7 @@ func TestAllCases(t *testing.T) {
 				patchSubscribers(testNS, channelName, []eventingduck.SubscriberSpec{
 					{
 						UID:           "a-" + subscriptionUID,
+						Name:          pointer.String("a-" + subscriptionName),
 						SubscriberURI: serviceURI,
 						Delivery: &eventingduck.DeliverySpec{
 							Timeout:       pointer.String("PT1S"),
@@ -1636,6 +1752,7 @@ func TestAllCases(t *testing.T) {
 				patchSubscribers(testNS, channelName, []eventingduck.SubscriberSpec{
 					{
 						UID:           "a-" + subscriptionUID,
+						Name:          pointer.String("a-" + subscriptionName),
 						SubscriberURI: serviceURI,
 					},
 				}),
@@ -1778,6 +1895,7 @@ func TestAllCases(t *testing.T) {
 				patchSubscribers(testNS, channelName, []eventingduck.SubscriberSpec{
 					{
 						UID:           "a-" + subscriptionUID,
+						Name:          pointer.String("a-" + subscriptionName),
 						SubscriberURI: serviceURI,
 						Delivery: &eventingduck.DeliverySpec{
 							DeadLetterSink: &duckv1.Destination{
@@ -1852,6 +1970,7 @@ func TestAllCases(t *testing.T) {
 				patchSubscribers(testNS, channelName, []eventingduck.SubscriberSpec{
 					{
 						UID:           "a-" + subscriptionUID,
+						Name:          pointer.String("a-" + subscriptionName),
 						SubscriberURI: serviceURI,
 						Delivery: &eventingduck.DeliverySpec{
 							Timeout:       pointer.String("PT1S"),
@@ -1916,7 +2035,7 @@ func TestAllCases(t *testing.T) {
 					WithInitInMemoryChannelConditions,
 					WithInMemoryChannelAddress(channelDNS),
 					WithInMemoryChannelSubscribers([]eventingduck.SubscriberSpec{
-						{UID: subscriptionUID, SubscriberURI: subscriberURI},
+						{UID: subscriptionUID, Name: pointer.String(subscriptionName), SubscriberURI: subscriberURI},
 					}),
 					WithInMemoryChannelReadySubscriber(subscriptionUID),
 				),
@@ -1991,12 +2110,67 @@ func TestAllCases(t *testing.T) {
 	}, false, logger))
 }
 
+func TestCheckChannelSupportsFilters(t *testing.T) {
+	filters := []eventingduck.SubscriptionsAPIFilter{{Exact: map[string]string{"type": "example"}}}
+	channel := func(kind string, annotations map[string]string) *eventingduck.Channelable {
+		return &eventingduck.Channelable{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "messaging.knative.dev/v1", Kind: kind},
+			ObjectMeta: metav1.ObjectMeta{Name: channelName, Namespace: testNS, Annotations: annotations},
+		}
+	}
+	tests := []struct {
+		name    string
+		channel *eventingduck.Channelable
+		filters []eventingduck.SubscriptionsAPIFilter
+		wantErr bool
+	}{{
+		name:    "no filters",
+		channel: channel("OtherKindOfChannel", nil),
+	}, {
+		name:    "annotated channel",
+		channel: channel("OtherKindOfChannel", map[string]string{messaging.SubscriptionFiltersAnnotationKey: "true"}),
+		filters: filters,
+	}, {
+		name:    "in-memory channel without the annotation",
+		channel: channel("InMemoryChannel", nil),
+		filters: filters,
+	}, {
+		name:    "channel without the annotation",
+		channel: channel("OtherKindOfChannel", nil),
+		filters: filters,
+		wantErr: true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sub := NewSubscription(subscriptionName, testNS, WithSubscriptionFilters(tc.filters), WithInitSubscriptionConditions)
+			err := Reconciler{}.checkChannelSupportsFilters(tc.channel, sub)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("checkChannelSupportsFilters() = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr && sub.Status.GetCondition(messagingv1.SubscriptionConditionAddedToChannel).IsTrue() {
+				t.Error("Subscription marked as added to the channel")
+			}
+		})
+	}
+}
+
 func WithSubscriptionDeliverySpec(d *eventingduck.DeliverySpec) SubscriptionOption {
 	return func(v *messagingv1.Subscription) {
 		v.Spec.Delivery = d
 	}
 }
 
+func WithSubscriptionFilters(f []eventingduck.SubscriptionsAPIFilter) SubscriptionOption {
+	return func(v *messagingv1.Subscription) {
+		v.Spec.Filters = f
+	}
+}
+
+func withoutSubscriptionFiltersAnnotation(imc *messagingv1.InMemoryChannel) *messagingv1.InMemoryChannel {
+	delete(imc.Annotations, messaging.SubscriptionFiltersAnnotationKey)
+	return imc
+}
+
 func patchSubscribers(namespace, name string, subscribers []eventingduck.SubscriberSpec) clientgotesting.PatchActionImpl {
 	action := clientgotesting.PatchActionImpl{}
 	action.Name = name
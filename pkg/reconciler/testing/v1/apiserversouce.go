@@ -70,6 +70,16 @@ func WithApiServerSourceSink(uri *apis.URL) ApiServerSourceOption {
 	}
 }
 
+func WithApiServerSourceDeadLetterSinkNotFound(s *v1.ApiServerSource) {
+	s.Status.MarkNoDeadLetterSink("NotFound", "")
+}
+
+func WithApiServerSourceDeadLetterSink(uri *apis.URL) ApiServerSourceOption {
+	return func(s *v1.ApiServerSource) {
+		s.Status.MarkDeadLetterSink(uri)
+	}
+}
+
 func WithApiServerSourceDeploymentUnavailable(s *v1.ApiServerSource) {
 	// The Deployment uses GenerateName, so its name is empty.
 	name := kmeta.ChildName(fmt.Sprintf("apiserversource-%s-", s.Name), string(s.GetUID()))
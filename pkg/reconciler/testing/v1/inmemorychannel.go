@@ -28,6 +28,7 @@ import (
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 
 	eventingv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	eventingduckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
 	"knative.dev/eventing/pkg/apis/eventing"
 	"knative.dev/eventing/pkg/apis/messaging"
 	v1 "knative.dev/eventing/pkg/apis/messaging/v1"
@@ -204,6 +205,33 @@ func WithInMemoryScopeAnnotation(value string) InMemoryChannelOption {
 	}
 }
 
+func WithInMemoryChannelBufferingAnnotation(value string) InMemoryChannelOption {
+	return func(imc *v1.InMemoryChannel) {
+		if imc.Annotations == nil {
+			imc.Annotations = make(map[string]string)
+		}
+		imc.Annotations[messaging.BufferingAnnotationKey] = value
+	}
+}
+
+func WithInMemoryChannelDispatch(dispatch *v1.InMemoryChannelDispatchSpec) InMemoryChannelOption {
+	return func(imc *v1.InMemoryChannel) {
+		imc.Spec.Dispatch = dispatch
+	}
+}
+
+func WithInMemoryChannelPlacements(placements ...eventingduckv1alpha1.Placement) InMemoryChannelOption {
+	return func(imc *v1.InMemoryChannel) {
+		imc.Status.Placements = placements
+	}
+}
+
+func WithInMemoryChannelStatefulSetReady() InMemoryChannelOption {
+	return func(imc *v1.InMemoryChannel) {
+		imc.Status.PropagateDispatcherStatefulSetStatus(&appsv1.StatefulSetStatus{Replicas: 1, ReadyReplicas: 1})
+	}
+}
+
 func WithInMemoryChannelStatusDLSURI(dlsURI *apis.URL) InMemoryChannelOption {
 	return func(imc *v1.InMemoryChannel) {
 		imc.Status.MarkDeadLetterSinkResolvedSucceeded(dlsURI)
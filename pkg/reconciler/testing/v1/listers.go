@@ -160,6 +160,10 @@ func (l *Listers) GetDeploymentLister() appsv1listers.DeploymentLister {
 	return appsv1listers.NewDeploymentLister(l.indexerFor(&appsv1.Deployment{}))
 }
 
+func (l *Listers) GetStatefulSetLister() appsv1listers.StatefulSetLister {
+	return appsv1listers.NewStatefulSetLister(l.indexerFor(&appsv1.StatefulSet{}))
+}
+
 func (l *Listers) GetK8sServiceLister() corev1listers.ServiceLister {
 	return corev1listers.NewServiceLister(l.indexerFor(&corev1.Service{}))
 }
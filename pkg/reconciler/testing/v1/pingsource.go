@@ -68,6 +68,16 @@ func WithPingSourceSink(uri *apis.URL) PingSourceOption {
 	}
 }
 
+func WithPingSourceDeadLetterSinkNotFound(s *v1.PingSource) {
+	s.Status.MarkNoDeadLetterSink("NotFound", "")
+}
+
+func WithPingSourceDeadLetterSink(uri *apis.URL) PingSourceOption {
+	return func(s *v1.PingSource) {
+		s.Status.MarkDeadLetterSink(uri)
+	}
+}
+
 func WithPingSourceDeployed(s *v1.PingSource) {
 	s.Status.PropagateDeploymentAvailability(testing.NewDeployment("any", "any", testing.WithDeploymentAvailable()))
 }
@@ -79,6 +89,22 @@ func WithPingSourceCloudEventAttributes(s *v1.PingSource) {
 	}}
 }
 
+func WithPingSourceLastScheduleTime(t metav1.Time) PingSourceOption {
+	return func(s *v1.PingSource) {
+		s.Status.LastScheduleTime = &t
+	}
+}
+
+func WithPingSourceCompleted(s *v1.PingSource) {
+	s.Status.MarkCompleted()
+}
+
+func WithPingSourceNotCompleted(at metav1.Time) PingSourceOption {
+	return func(s *v1.PingSource) {
+		s.Status.MarkNotCompleted("Scheduled", "The event is scheduled at %s.", at.UTC().Format(time.RFC3339))
+	}
+}
+
 func WithPingSourceSpec(spec v1.PingSourceSpec) PingSourceOption {
 	return func(c *v1.PingSource) {
 		c.Spec = spec
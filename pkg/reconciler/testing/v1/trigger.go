@@ -58,6 +58,26 @@ func WithTriggerSubscriberURI(rawurl string) TriggerOption {
 	}
 }
 
+func WithTriggerReplyURI(rawurl string) TriggerOption {
+	uri, _ := apis.ParseURL(rawurl)
+	return func(t *v1.Trigger) {
+		t.Spec.Reply = &duckv1.Destination{URI: uri}
+	}
+}
+
+func WithTriggerReplyRef(gvk metav1.GroupVersionKind, name, namespace string) TriggerOption {
+	return func(t *v1.Trigger) {
+		t.Spec.Reply = &duckv1.Destination{
+			Ref: &duckv1.KReference{
+				APIVersion: apiVersion(gvk),
+				Kind:       gvk.Kind,
+				Name:       name,
+				Namespace:  namespace,
+			},
+		}
+	}
+}
+
 func WithTriggerDeadLeaderSink(ref *duckv1.KReference, uri string) TriggerOption {
 	return func(t *v1.Trigger) {
 		if t.Spec.Delivery == nil {
@@ -189,6 +209,13 @@ func WithTriggerStatusSubscriberURI(uri string) TriggerOption {
 	}
 }
 
+func WithTriggerStatusReplyURI(uri string) TriggerOption {
+	return func(t *v1.Trigger) {
+		u, _ := apis.ParseURL(uri)
+		t.Status.ReplyURI = u
+	}
+}
+
 func WithTriggerStatusDeadLetterSinkURI(uri string) TriggerOption {
 	return func(t *v1.Trigger) {
 		u, _ := apis.ParseURL(uri)
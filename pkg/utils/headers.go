@@ -17,13 +17,27 @@ limitations under the License.
 package utils
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
+	"sync/atomic"
 
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
-// TODO make propagated headers/prefixes configurable (configmap?)
+const (
+	// HeadersConfigName is the name of the ConfigMap containing the header pass-through policy.
+	HeadersConfigName = "config-headers"
+
+	// Keys of the header pass-through policy ConfigMap. Each value is a comma or whitespace separated
+	// list of header names, a name ending with `*` matches all the headers with that prefix.
+	forwardHeadersKey      = "forward-headers"
+	stripForwardHeadersKey = "strip-forward-headers"
+	replyHeadersKey        = "reply-headers"
+	stripReplyHeadersKey   = "strip-reply-headers"
+)
 
 var (
 	// These MUST be lowercase strings, as they will be compared against lowercase strings.
@@ -39,25 +53,168 @@ var (
 		"knative-", // Knative
 		"x-b3-",    // Zipkin (Istio) B3
 	}
+	// replyHeaders are the headers proxied from a reply, other than the CloudEvents headers.
+	// Other headers are not proxied because of security concerns.
+	// These MUST be lowercase strings, as they will be compared against lowercase strings.
+	replyHeaders = sets.NewString(
+		"retry-after",
+	)
+
+	headersPolicy atomic.Value
 )
 
-// PassThroughHeaders extracts the headers from headers that are in the `forwardHeaders` set
-// or has any of the prefixes in `forwardPrefixes`.
-func PassThroughHeaders(headers http.Header) http.Header {
+func init() {
+	SetHeadersPolicy(DefaultHeadersPolicy())
+}
+
+// headerMatcher matches header names, either exactly or by prefix.
+type headerMatcher struct {
+	headers  sets.String
+	prefixes []string
+}
+
+func (m headerMatcher) matches(lower string) bool {
+	if m.headers.Has(lower) {
+		return true
+	}
+	for _, prefix := range m.prefixes {
+		if strings.HasPrefix(lower, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m headerMatcher) union(o headerMatcher) headerMatcher {
+	prefixes := make([]string, 0, len(m.prefixes)+len(o.prefixes))
+	prefixes = append(prefixes, m.prefixes...)
+	prefixes = append(prefixes, o.prefixes...)
+	return headerMatcher{headers: m.headers.Union(o.headers), prefixes: prefixes}
+}
+
+func parseHeaderMatcher(key, value string) (headerMatcher, error) {
+	m := headerMatcher{headers: sets.NewString()}
+	for _, name := range strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	}) {
+		name = strings.ToLower(name)
+		if prefix := strings.TrimSuffix(name, "*"); prefix != name {
+			if prefix == "" || strings.Contains(prefix, "*") {
+				return headerMatcher{}, fmt.Errorf("%s: invalid header prefix %q", key, name)
+			}
+			m.prefixes = append(m.prefixes, prefix)
+			continue
+		}
+		if strings.Contains(name, "*") {
+			return headerMatcher{}, fmt.Errorf("%s: invalid header name %q", key, name)
+		}
+		m.headers.Insert(name)
+	}
+	return m, nil
+}
+
+// HeadersPolicy is the policy deciding which HTTP headers are passed through when forwarding an event
+// and which are proxied from a reply.
+type HeadersPolicy struct {
+	forward      headerMatcher
+	stripForward headerMatcher
+	reply        headerMatcher
+	stripReply   headerMatcher
+}
+
+// DefaultHeadersPolicy returns the policy used when no header pass-through policy is configured.
+func DefaultHeadersPolicy() *HeadersPolicy {
+	return &HeadersPolicy{
+		forward:      headerMatcher{headers: forwardHeaders, prefixes: forwardPrefixes},
+		stripForward: headerMatcher{headers: sets.NewString()},
+		reply:        headerMatcher{headers: replyHeaders},
+		stripReply:   headerMatcher{headers: sets.NewString()},
+	}
+}
+
+// NewHeadersPolicyFromConfigMap creates a HeadersPolicy from the supplied ConfigMap. The configured headers
+// are added to the default ones, and the stripped headers are never passed through, even when they are
+// part of the defaults.
+func NewHeadersPolicyFromConfigMap(config *corev1.ConfigMap) (*HeadersPolicy, error) {
+	p := DefaultHeadersPolicy()
+	if config == nil {
+		return p, nil
+	}
+	for _, f := range []struct {
+		key     string
+		matcher *headerMatcher
+		add     bool
+	}{
+		{key: forwardHeadersKey, matcher: &p.forward, add: true},
+		{key: stripForwardHeadersKey, matcher: &p.stripForward},
+		{key: replyHeadersKey, matcher: &p.reply, add: true},
+		{key: stripReplyHeadersKey, matcher: &p.stripReply},
+	} {
+		m, err := parseHeaderMatcher(f.key, config.Data[f.key])
+		if err != nil {
+			return nil, err
+		}
+		if f.add {
+			m = f.matcher.union(m)
+		}
+		*f.matcher = m
+	}
+	return p, nil
+}
+
+// PassThroughHeaders extracts the headers from headers that are forwarded according to the policy.
+func (p *HeadersPolicy) PassThroughHeaders(headers http.Header) http.Header {
 	h := http.Header{}
 
 	for n, v := range headers {
 		lower := strings.ToLower(n)
-		if forwardHeaders.Has(lower) {
+		if p.forward.matches(lower) && !p.stripForward.matches(lower) {
 			h[n] = v
-			continue
-		}
-		for _, prefix := range forwardPrefixes {
-			if strings.HasPrefix(lower, prefix) {
-				h[n] = v
-				break
-			}
 		}
 	}
 	return h
 }
+
+// IsReplyHeaderAllowed returns true if the header is proxied from a reply according to the policy.
+func (p *HeadersPolicy) IsReplyHeaderAllowed(name string) bool {
+	lower := strings.ToLower(name)
+	return p.reply.matches(lower) && !p.stripReply.matches(lower)
+}
+
+// SetHeadersPolicy sets the header pass-through policy used by PassThroughHeaders and IsReplyHeaderAllowed.
+func SetHeadersPolicy(p *HeadersPolicy) {
+	headersPolicy.Store(p)
+}
+
+// GetHeadersPolicy returns the current header pass-through policy.
+func GetHeadersPolicy() *HeadersPolicy {
+	return headersPolicy.Load().(*HeadersPolicy)
+}
+
+// UpdateHeadersPolicyFromConfigMap returns a helper func that can be used to update the header
+// pass-through policy when a ConfigMap is updated. An invalid ConfigMap is ignored and the current
+// policy is kept.
+func UpdateHeadersPolicyFromConfigMap(logger *zap.SugaredLogger) func(configMap *corev1.ConfigMap) {
+	return func(configMap *corev1.ConfigMap) {
+		p, err := NewHeadersPolicyFromConfigMap(configMap)
+		if err != nil {
+			logger.Errorw("Failed to parse the header pass-through policy, keeping the current one", zap.Error(err))
+			return
+		}
+		SetHeadersPolicy(p)
+		logger.Infow("Updated the header pass-through policy", zap.String("configmap", configMap.Name))
+	}
+}
+
+// PassThroughHeaders extracts the headers from headers that are forwarded according to the current
+// header pass-through policy, by default the headers in the `forwardHeaders` set or with any of the
+// prefixes in `forwardPrefixes`.
+func PassThroughHeaders(headers http.Header) http.Header {
+	return GetHeadersPolicy().PassThroughHeaders(headers)
+}
+
+// IsReplyHeaderAllowed returns true if the header is proxied from a reply, other than the CloudEvents
+// headers, according to the current header pass-through policy.
+func IsReplyHeaderAllowed(name string) bool {
+	return GetHeadersPolicy().IsReplyHeaderAllowed(name)
+}
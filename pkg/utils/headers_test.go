@@ -21,6 +21,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	logtesting "knative.dev/pkg/logging/testing"
+
+	. "knative.dev/pkg/configmap/testing"
 )
 
 func TestPassThroughHeaders(t *testing.T) {
@@ -60,3 +64,126 @@ func TestPassThroughHeaders(t *testing.T) {
 		})
 	}
 }
+
+func TestPassThroughHeadersWithPolicy(t *testing.T) {
+	testCases := map[string]struct {
+		data                         map[string]string
+		additionalHeaders            http.Header
+		expectedPassedThroughHeaders http.Header
+	}{
+		"forward additional headers and prefixes": {
+			data: map[string]string{
+				forwardHeadersKey: "traceparent, Tracestate\nx-tenant-*",
+			},
+			additionalHeaders: map[string][]string{
+				"Traceparent":   {"00-1234-5678-01"},
+				"Tracestate":    {"foo=bar"},
+				"X-Tenant-Id":   {"tenant"},
+				"X-Request-Id":  {"1234"},
+				"Authorization": {"secret"},
+			},
+			expectedPassedThroughHeaders: map[string][]string{
+				"Traceparent":  {"00-1234-5678-01"},
+				"Tracestate":   {"foo=bar"},
+				"X-Tenant-Id":  {"tenant"},
+				"X-Request-Id": {"1234"},
+			},
+		},
+		"strip default headers and prefixes": {
+			data: map[string]string{
+				forwardHeadersKey:      "authorization",
+				stripForwardHeadersKey: "x-request-id authorization knative-internal-*",
+			},
+			additionalHeaders: map[string][]string{
+				"X-Request-Id":          {"1234"},
+				"Authorization":         {"secret"},
+				"Knative-Internal-Key":  {"secret"},
+				"Knative-Will-Pass":     {"true"},
+				"X-B3-Spanid":           {"5678"},
+				"Not-Passed-Through-At": {"all"},
+			},
+			expectedPassedThroughHeaders: map[string][]string{
+				"Knative-Will-Pass": {"true"},
+				"X-B3-Spanid":       {"5678"},
+			},
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			p, err := NewHeadersPolicyFromConfigMap(&corev1.ConfigMap{Data: tc.data})
+			if err != nil {
+				t.Fatal("NewHeadersPolicyFromConfigMap() =", err)
+			}
+			headers := p.PassThroughHeaders(tc.additionalHeaders)
+			assert.Equal(t, tc.expectedPassedThroughHeaders, headers)
+		})
+	}
+}
+
+func TestIsReplyHeaderAllowed(t *testing.T) {
+	testCases := map[string]struct {
+		data     map[string]string
+		header   string
+		expected bool
+	}{
+		"default allowed": {
+			header:   "Retry-After",
+			expected: true,
+		},
+		"default not allowed": {
+			header:   "Set-Cookie",
+			expected: false,
+		},
+		"additional header allowed": {
+			data:     map[string]string{replyHeadersKey: "x-tenant-*"},
+			header:   "X-Tenant-Id",
+			expected: true,
+		},
+		"default header stripped": {
+			data:     map[string]string{stripReplyHeadersKey: "retry-after"},
+			header:   "Retry-After",
+			expected: false,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			p, err := NewHeadersPolicyFromConfigMap(&corev1.ConfigMap{Data: tc.data})
+			if err != nil {
+				t.Fatal("NewHeadersPolicyFromConfigMap() =", err)
+			}
+			assert.Equal(t, tc.expected, p.IsReplyHeaderAllowed(tc.header))
+		})
+	}
+}
+
+func TestNewHeadersPolicyFromConfigMap(t *testing.T) {
+	actual, example := ConfigMapsFromTestFile(t, HeadersConfigName)
+	for _, cm := range []*corev1.ConfigMap{actual, example} {
+		if _, err := NewHeadersPolicyFromConfigMap(cm); err != nil {
+			t.Errorf("NewHeadersPolicyFromConfigMap(%s) = %v", cm.Name, err)
+		}
+	}
+
+	for _, value := range []string{"*", "x-*-id", "x-**"} {
+		if _, err := NewHeadersPolicyFromConfigMap(&corev1.ConfigMap{Data: map[string]string{forwardHeadersKey: value}}); err == nil {
+			t.Errorf("NewHeadersPolicyFromConfigMap(%q) = nil, wanted an error", value)
+		}
+	}
+}
+
+func TestUpdateHeadersPolicyFromConfigMap(t *testing.T) {
+	defer SetHeadersPolicy(DefaultHeadersPolicy())
+
+	update := UpdateHeadersPolicyFromConfigMap(logtesting.TestLogger(t))
+	headers := http.Header{"Traceparent": {"00-1234-5678-01"}}
+
+	update(&corev1.ConfigMap{Data: map[string]string{forwardHeadersKey: "traceparent"}})
+	assert.Equal(t, headers, PassThroughHeaders(headers))
+
+	// An invalid policy is ignored.
+	update(&corev1.ConfigMap{Data: map[string]string{forwardHeadersKey: "*"}})
+	assert.Equal(t, headers, PassThroughHeaders(headers))
+
+	update(&corev1.ConfigMap{})
+	assert.Equal(t, http.Header{}, PassThroughHeaders(headers))
+}